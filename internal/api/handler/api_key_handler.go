@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/username/haber/internal/api/middleware"
+	"github.com/username/haber/internal/domain"
+	"github.com/username/haber/internal/service"
+)
+
+// APIKeyHandler API anahtarı yönetim işleyicileri
+type APIKeyHandler struct {
+	apiKeyService     service.IAPIKeyService
+	requirePermission middleware.PermissionGuard
+}
+
+// NewAPIKeyHandler yeni bir APIKeyHandler oluşturur
+func NewAPIKeyHandler(apiKeyService service.IAPIKeyService, requirePermission middleware.PermissionGuard) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService:     apiKeyService,
+		requirePermission: requirePermission,
+	}
+}
+
+// RegisterRoutes rotaları kayıt eder
+func (h *APIKeyHandler) RegisterRoutes(router fiber.Router, authMw fiber.Handler, adminMw fiber.Handler) {
+	// API anahtarı yönetimi rotaları
+	adminRoutes := router.Group("/admin/api-keys", h.requirePermission(domain.PermAPIKeyManage))
+	adminRoutes.Get("/", h.ListAPIKeys)
+	adminRoutes.Get("/:id", h.GetAPIKey)
+	adminRoutes.Post("/", middleware.ValidateRequest(&domain.CreateAPIKeyRequest{}), h.CreateAPIKey)
+	adminRoutes.Delete("/:id", h.RevokeAPIKey)
+}
+
+// ListAPIKeys API anahtarlarını listeler
+// @Summary API anahtarlarını listele
+// @Description Oluşturulmuş tüm API anahtarlarını listeler (Sadece Admin)
+// @Tags Admin,API Anahtarları
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "Sayfa numarası (varsayılan: 1)"
+// @Param limit query int false "Sayfa başına sonuç sayısı (varsayılan: 20, maksimum: 100)"
+// @Success 200 {object} domain.PaginatedResponse{data=[]domain.APIKey} "API anahtarı listesi"
+// @Failure 401 {object} domain.ErrorResponse "Yetkisiz erişim"
+// @Failure 403 {object} domain.ErrorResponse "Yetersiz yetki"
+// @Router /admin/api-keys [get]
+func (h *APIKeyHandler) ListAPIKeys(c *fiber.Ctx) error {
+	// Sayfalama parametrelerini al
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	offset := (page - 1) * limit
+
+	keys, total, err := h.apiKeyService.ListKeys(offset, limit)
+	if err != nil {
+		return err
+	}
+
+	// Toplam sayfa sayısını hesapla
+	totalPages := (int(total) + limit - 1) / limit
+	if totalPages < 1 {
+		totalPages = 1
+	}
+
+	return c.JSON(fiber.Map{
+		"data": keys,
+		"meta": fiber.Map{
+			"current_page": page,
+			"per_page":     limit,
+			"total":        total,
+			"total_pages":  totalPages,
+		},
+	})
+}
+
+// GetAPIKey ID'ye göre API anahtarını getirir
+// @Summary API anahtarı detayları
+// @Description API anahtarının kapsamlarını ve kullanım bilgilerini getirir (Sadece Admin)
+// @Tags Admin,API Anahtarları
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "API anahtarı ID"
+// @Success 200 {object} domain.APIKey "API anahtarı"
+// @Failure 400 {object} domain.ErrorResponse "Geçersiz API anahtarı ID"
+// @Failure 401 {object} domain.ErrorResponse "Yetkisiz erişim"
+// @Failure 403 {object} domain.ErrorResponse "Yetersiz yetki"
+// @Failure 404 {object} domain.ErrorResponse "API anahtarı bulunamadı"
+// @Router /admin/api-keys/{id} [get]
+func (h *APIKeyHandler) GetAPIKey(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Geçersiz API anahtarı ID")
+	}
+
+	key, err := h.apiKeyService.GetKey(uint(id))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(key)
+}
+
+// CreateAPIKey yeni bir API anahtarı oluşturur
+// @Summary API anahtarı oluştur
+// @Description Kapsamları belirlenmiş yeni bir API anahtarı oluşturur. Anahtarın kendisi yalnızca bu yanıtta gösterilir (Sadece Admin)
+// @Tags Admin,API Anahtarları
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param key body domain.CreateAPIKeyRequest true "API anahtarı bilgileri"
+// @Success 201 {object} domain.APIKeyCreatedResponse "Oluşturulan API anahtarı"
+// @Failure 400 {object} domain.ErrorResponse "Geçersiz istek formatı"
+// @Failure 401 {object} domain.ErrorResponse "Yetkisiz erişim"
+// @Failure 403 {object} domain.ErrorResponse "Yetersiz yetki"
+// @Router /admin/api-keys [post]
+func (h *APIKeyHandler) CreateAPIKey(c *fiber.Ctx) error {
+	req := middleware.GetValidated(c).(*domain.CreateAPIKeyRequest)
+	created, err := h.apiKeyService.CreateKey(req, middleware.GetActor(c))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success": true,
+		"message": "API anahtarı oluşturuldu, anahtarı güvenli bir yerde saklayın",
+		"data":    created,
+	})
+}
+
+// RevokeAPIKey API anahtarını iptal eder
+// @Summary API anahtarını iptal et
+// @Description API anahtarını iptal eder; anahtarla yapılan sonraki istekler reddedilir (Sadece Admin)
+// @Tags Admin,API Anahtarları
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "API anahtarı ID"
+// @Success 200 {object} domain.MessageResponse "API anahtarı iptal edildi"
+// @Failure 400 {object} domain.ErrorResponse "Geçersiz API anahtarı ID"
+// @Failure 401 {object} domain.ErrorResponse "Yetkisiz erişim"
+// @Failure 403 {object} domain.ErrorResponse "Yetersiz yetki"
+// @Failure 404 {object} domain.ErrorResponse "API anahtarı bulunamadı"
+// @Router /admin/api-keys/{id} [delete]
+func (h *APIKeyHandler) RevokeAPIKey(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Geçersiz API anahtarı ID")
+	}
+
+	if err := h.apiKeyService.RevokeKey(uint(id), middleware.GetActor(c)); err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "API anahtarı iptal edildi",
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"strconv"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/username/haber/internal/api/middleware"
 	"github.com/username/haber/internal/domain"
 	"github.com/username/haber/internal/service"
 )
@@ -31,8 +32,9 @@ func (h *ArticleHandler) RegisterRoutes(router fiber.Router, authMw fiber.Handle
 	router.Get("/articles/tag/:tagID", h.GetArticlesByTag)
 	router.Get("/articles/author/:authorID", h.GetArticlesByAuthor)
 
-	// Admin ve editörler için rotalar
-	adminRoutes := router.Group("/admin/articles", adminMw)
+	// Yazı işleri ve articles:write kapsamlı API anahtarları için rotalar,
+	// yetki ve sahiplik kuralları servis katmanında uygulanır
+	adminRoutes := router.Group("/admin/articles", middleware.AllowAPIKey(domain.ScopeArticlesWrite), authMw)
 	adminRoutes.Post("/", h.CreateArticle)
 	adminRoutes.Put("/:id", h.UpdateArticle)
 	adminRoutes.Delete("/:id", h.DeleteArticle)
@@ -336,11 +338,8 @@ func (h *ArticleHandler) CreateArticle(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "Geçersiz istek formatı")
 	}
 
-	// Kullanıcı ID'sini al
-	userID := c.Locals("user_id").(uint)
-
 	// Makaleyi oluştur
-	article, err := h.articleService.CreateArticle(&req, userID)
+	article, err := h.articleService.CreateArticle(&req, middleware.GetActor(c))
 	if err != nil {
 		return err
 	}
@@ -375,7 +374,7 @@ func (h *ArticleHandler) UpdateArticle(c *fiber.Ctx) error {
 	}
 
 	// Makaleyi güncelle
-	article, err := h.articleService.UpdateArticle(uint(id), &req)
+	article, err := h.articleService.UpdateArticle(uint(id), &req, middleware.GetActor(c))
 	if err != nil {
 		return err
 	}
@@ -403,7 +402,7 @@ func (h *ArticleHandler) DeleteArticle(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "Geçersiz makale ID")
 	}
 
-	err = h.articleService.DeleteArticle(uint(id))
+	err = h.articleService.DeleteArticle(uint(id), middleware.GetActor(c))
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,203 @@
+package handler
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/username/haber/internal/api/middleware"
+	"github.com/username/haber/internal/domain"
+	"github.com/username/haber/internal/service"
+)
+
+// AuditHandler denetim kaydı işleyicileri
+type AuditHandler struct {
+	auditService      service.IAuditService
+	requirePermission middleware.PermissionGuard
+}
+
+// NewAuditHandler yeni bir AuditHandler oluşturur
+func NewAuditHandler(auditService service.IAuditService, requirePermission middleware.PermissionGuard) *AuditHandler {
+	return &AuditHandler{
+		auditService:      auditService,
+		requirePermission: requirePermission,
+	}
+}
+
+// RegisterRoutes rotaları kayıt eder
+func (h *AuditHandler) RegisterRoutes(router fiber.Router, authMw fiber.Handler, adminMw fiber.Handler) {
+	// Denetim kaydı okuma rotaları
+	adminRoutes := router.Group("/admin/audit-logs", h.requirePermission(domain.PermAuditRead))
+	adminRoutes.Get("/", h.ListAuditLogs)
+	adminRoutes.Get("/export", h.ExportAuditLogs)
+}
+
+// ListAuditLogs denetim kayıtlarını listeler
+// @Summary Denetim kayıtlarını listele
+// @Description Yönetim ve editörlük işlemlerinin kayıtlarını en yeniden eskiye listeler (Sadece Admin)
+// @Tags Admin,Denetim
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param actor_id query int false "İşlemi yapan kullanıcı ID"
+// @Param action query string false "İşlem (create, update, delete, upload, role_change, ...)"
+// @Param resource_type query string false "Kaynak türü (ör. Makale, Kullanıcı)"
+// @Param resource_id query string false "Kaynak ID"
+// @Param request_id query string false "İstek ID"
+// @Param from query string false "Başlangıç zamanı (RFC3339)"
+// @Param to query string false "Bitiş zamanı (RFC3339)"
+// @Param page query int false "Sayfa numarası (varsayılan: 1)"
+// @Param limit query int false "Sayfa başına sonuç sayısı (varsayılan: 20, maksimum: 100)"
+// @Success 200 {object} domain.PaginatedResponse{data=[]domain.AuditLog} "Denetim kaydı listesi"
+// @Failure 400 {object} domain.ErrorResponse "Geçersiz filtre"
+// @Failure 401 {object} domain.ErrorResponse "Yetkisiz erişim"
+// @Failure 403 {object} domain.ErrorResponse "Yetersiz yetki"
+// @Router /admin/audit-logs [get]
+func (h *AuditHandler) ListAuditLogs(c *fiber.Ctx) error {
+	filter, err := parseAuditFilter(c)
+	if err != nil {
+		return err
+	}
+
+	// Sayfalama parametrelerini al
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	offset := (page - 1) * limit
+
+	entries, total, err := h.auditService.List(filter, offset, limit)
+	if err != nil {
+		return err
+	}
+
+	// Toplam sayfa sayısını hesapla
+	totalPages := (int(total) + limit - 1) / limit
+	if totalPages < 1 {
+		totalPages = 1
+	}
+
+	return c.JSON(fiber.Map{
+		"data": entries,
+		"meta": fiber.Map{
+			"current_page": page,
+			"per_page":     limit,
+			"total":        total,
+			"total_pages":  totalPages,
+		},
+	})
+}
+
+// ExportAuditLogs filtreye uyan denetim kayıtlarını dosya olarak indirir
+// @Summary Denetim kayıtlarını dışa aktar
+// @Description Filtreye uyan tüm denetim kayıtlarını CSV veya JSON dosyası olarak indirir (Sadece Admin)
+// @Tags Admin,Denetim
+// @Produce text/csv
+// @Produce json
+// @Security ApiKeyAuth
+// @Param format query string false "Dosya biçimi: csv veya json (varsayılan: csv)"
+// @Param actor_id query int false "İşlemi yapan kullanıcı ID"
+// @Param action query string false "İşlem"
+// @Param resource_type query string false "Kaynak türü"
+// @Param resource_id query string false "Kaynak ID"
+// @Param request_id query string false "İstek ID"
+// @Param from query string false "Başlangıç zamanı (RFC3339)"
+// @Param to query string false "Bitiş zamanı (RFC3339)"
+// @Success 200 {file} file "Denetim kayıtları"
+// @Failure 400 {object} domain.ErrorResponse "Geçersiz filtre veya biçim"
+// @Failure 401 {object} domain.ErrorResponse "Yetkisiz erişim"
+// @Failure 403 {object} domain.ErrorResponse "Yetersiz yetki"
+// @Router /admin/audit-logs/export [get]
+func (h *AuditHandler) ExportAuditLogs(c *fiber.Ctx) error {
+	filter, err := parseAuditFilter(c)
+	if err != nil {
+		return err
+	}
+
+	format := c.Query("format", service.AuditExportCSV)
+	var contentType string
+	switch format {
+	case service.AuditExportCSV:
+		contentType = "text/csv; charset=utf-8"
+	case service.AuditExportJSON:
+		contentType = fiber.MIMEApplicationJSONCharsetUTF8
+	default:
+		return &domain.ValidationError{
+			Field:   "format",
+			Message: "Desteklenen biçimler: csv, json",
+		}
+	}
+
+	filename := fmt.Sprintf("audit-log-%s.%s", time.Now().UTC().Format("20060102-150405"), format)
+	c.Set(fiber.HeaderContentType, contentType)
+	c.Attachment(filename)
+
+	// Kayıtlar belleğe alınmadan parça parça yazılır; yanıt başladıktan sonra
+	// oluşan hata istemciye bildirilemeyeceğinden loglanır
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := h.auditService.Export(filter, format, w); err != nil {
+			log.Printf("Denetim kaydı dışa aktarılamadı: %v", err)
+		}
+		w.Flush()
+	})
+	return nil
+}
+
+// parseAuditFilter sorgu parametrelerinden denetim kaydı filtresi oluşturur
+func parseAuditFilter(c *fiber.Ctx) (*domain.AuditLogFilter, error) {
+	filter := &domain.AuditLogFilter{
+		Action:       c.Query("action"),
+		ResourceType: domain.ResourceType(c.Query("resource_type")),
+		ResourceID:   c.Query("resource_id"),
+		RequestID:    c.Query("request_id"),
+	}
+
+	if value := c.Query("actor_id"); value != "" {
+		id, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return nil, &domain.ValidationError{
+				Field:   "actor_id",
+				Message: "Geçersiz kullanıcı ID",
+			}
+		}
+		actorID := uint(id)
+		filter.ActorID = &actorID
+	}
+
+	for _, param := range []struct {
+		name   string
+		target **time.Time
+	}{
+		{"from", &filter.From},
+		{"to", &filter.To},
+	} {
+		value := c.Query(param.name)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, &domain.ValidationError{
+				Field:   param.name,
+				Message: "Tarih RFC3339 biçiminde olmalıdır (ör. 2024-01-31T15:04:05Z)",
+			}
+		}
+		*param.target = &t
+	}
+
+	if filter.From != nil && filter.To != nil && filter.To.Before(*filter.From) {
+		return nil, &domain.ValidationError{
+			Field:   "to",
+			Message: "Bitiş zamanı başlangıçtan önce olamaz",
+		}
+	}
+
+	return filter, nil
+}
@@ -1,57 +1,93 @@
 package handler
 
 import (
+	"strconv"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/username/haber/internal/api/middleware"
 	"github.com/username/haber/internal/domain"
 	"github.com/username/haber/internal/service"
+	"github.com/username/haber/pkg/auth"
 )
 
 // RefreshTokenRequest token yenileme isteği
 type RefreshTokenRequest struct {
-	RefreshToken string `json:"refresh_token"`
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+// LogoutRequest çıkış isteği
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token,omitempty"`
 }
 
 // ResetPasswordRequest şifre sıfırlama isteği
 type ResetPasswordRequest struct {
-	Email string `json:"email"`
+	Email string `json:"email" validate:"required,email"`
 }
 
 // ConfirmResetPasswordRequest şifre sıfırlama onay isteği
 type ConfirmResetPasswordRequest struct {
-	Token           string `json:"token"`
-	NewPassword     string `json:"new_password"`
-	ConfirmPassword string `json:"confirm_password"`
+	Token           string `json:"token" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,min=6"`
+	ConfirmPassword string `json:"confirm_password" validate:"required,eqfield=NewPassword"`
+}
+
+// VerifyEmailRequest e-posta doğrulama isteği
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// UnlockAccountRequest hesap kilidi açma isteği
+type UnlockAccountRequest struct {
+	Token string `json:"token" validate:"required"`
 }
 
 // AuthHandler kimlik doğrulama işleyicileri
 type AuthHandler struct {
-	authService service.IAuthService
+	authService       service.IAuthService
+	requirePermission middleware.PermissionGuard
 }
 
 // NewAuthHandler yeni bir AuthHandler oluşturur
-func NewAuthHandler(authService service.IAuthService) *AuthHandler {
+func NewAuthHandler(authService service.IAuthService, requirePermission middleware.PermissionGuard) *AuthHandler {
 	return &AuthHandler{
-		authService: authService,
+		authService:       authService,
+		requirePermission: requirePermission,
 	}
 }
 
 // RegisterRoutes rotaları kayıt eder
-func (h *AuthHandler) RegisterRoutes(app *fiber.App) {
-	auth := app.Group("/api/auth")
+func (h *AuthHandler) RegisterRoutes(router fiber.Router, authMw fiber.Handler, adminMw fiber.Handler) {
+	auth := router.Group("/auth")
 
 	// Public routes
 	auth.Post("/register", middleware.ValidateRequest(&domain.RegisterUserRequest{}), h.Register)
 	auth.Post("/login", middleware.ValidateRequest(&domain.LoginRequest{}), h.Login)
-	auth.Post("/refresh", h.RefreshToken)
-	auth.Post("/forgot-password", h.ForgotPassword)
-	auth.Post("/reset-password", h.ResetPassword)
+	auth.Post("/refresh", middleware.ValidateRequest(&RefreshTokenRequest{}), h.RefreshToken)
+	auth.Post("/forgot-password", middleware.ValidateRequest(&ResetPasswordRequest{}), h.ForgotPassword)
+	auth.Post("/reset-password", middleware.ValidateRequest(&ConfirmResetPasswordRequest{}), h.ResetPassword)
+	auth.Post("/verify-email", middleware.ValidateRequest(&VerifyEmailRequest{}), h.VerifyEmail)
+	auth.Post("/unlock-account", middleware.ValidateRequest(&UnlockAccountRequest{}), h.UnlockAccount)
+	auth.Post("/mfa/verify", middleware.ValidateRequest(&domain.MFAChallengeRequest{}), h.VerifyMFA)
+	auth.Post("/mfa/challenge/enroll", middleware.ValidateRequest(&domain.MFAChallengeEnrollRequest{}), h.StartChallengeEnrollment)
+	auth.Post("/mfa/challenge/confirm", middleware.ValidateRequest(&domain.MFAChallengeRequest{}), h.ConfirmChallengeEnrollment)
+
+	// Protected routes; /auth altına başka handler'lar da herkese açık rota
+	// eklediğinden (ör. SSO) middleware gruba değil rotalara eklenir
+	auth.Get("/me", authMw, h.GetCurrentUser)
+	auth.Post("/logout", authMw, h.Logout)
+	auth.Put("/change-password", authMw, middleware.ValidateRequest(&domain.UpdatePasswordRequest{}), h.ChangePassword)
+	auth.Post("/resend-verification", authMw, h.ResendVerification)
+	auth.Post("/mfa/enroll", authMw, h.StartMFAEnrollment)
+	auth.Post("/mfa/enable", authMw, middleware.ValidateRequest(&domain.MFACodeRequest{}), h.ConfirmMFAEnrollment)
+	auth.Post("/mfa/recovery-codes", authMw, middleware.ValidateRequest(&domain.MFACodeRequest{}), h.RegenerateRecoveryCodes)
+	auth.Post("/mfa/disable", authMw, middleware.ValidateRequest(&domain.DisableMFARequest{}), h.DisableMFA)
 
-	// Protected routes
-	auth.Use(middleware.NewAuthMiddleware(nil).Authenticate)
-	auth.Get("/me", h.GetCurrentUser)
-	auth.Post("/logout", h.Logout)
-	auth.Put("/change-password", middleware.ValidateRequest(&domain.UpdatePasswordRequest{}), h.ChangePassword)
+	// Kullanıcı yönetimi rotaları
+	adminRoutes := router.Group("/admin/users", h.requirePermission(domain.PermUserManage))
+	adminRoutes.Post("/:id/revoke-tokens", h.RevokeUserTokens)
+	adminRoutes.Post("/:id/verify-email", h.MarkEmailVerified)
+	adminRoutes.Post("/:id/unlock", h.AdminUnlockAccount)
 }
 
 // Register kullanıcı kaydını sağlar
@@ -69,8 +105,8 @@ func (h *AuthHandler) Register(c *fiber.Ctx) error {
 	// Validasyon middleware ile doğrulanmış veriyi al
 	reqData := middleware.GetValidated(c).(*domain.RegisterUserRequest)
 
-	// Kullanıcı oluştur
-	user, token, err := h.authService.Register(reqData)
+	// Kullanıcı oluştur ve oturum aç
+	result, err := h.authService.Register(reqData, clientInfo(c))
 	if err != nil {
 		return err
 	}
@@ -78,40 +114,35 @@ func (h *AuthHandler) Register(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
 		"success": true,
 		"message": "Kullanıcı başarıyla oluşturuldu",
-		"data": fiber.Map{
-			"user":         user,
-			"access_token": token,
-		},
+		"data":    result,
 	})
 }
 
 // Login kullanıcı girişini sağlar
 // @Summary Kullanıcı girişi
-// @Description Kullanıcı adı ve şifre ile giriş yaparak token alır
+// @Description Kullanıcı adı veya e-posta adresi ve şifre ile giriş yaparak token alır. remember seçilirse yenileme token'ı daha uzun ömürlüdür.
 // @Tags Kimlik Doğrulama
 // @Accept json
 // @Produce json
 // @Param login body domain.LoginRequest true "Kullanıcı giriş bilgileri"
-// @Success 200 {object} domain.AuthResponse "Başarılı giriş"
+// @Success 200 {object} domain.LoginResponse "Başarılı giriş veya iki adımlı doğrulama gerekli"
 // @Failure 400 {object} domain.ErrorResponse "Geçersiz istek formatı"
 // @Failure 401 {object} domain.ErrorResponse "Geçersiz kullanıcı adı veya şifre"
+// @Failure 429 {object} domain.ErrorResponse "Çok fazla hatalı deneme"
 // @Router /auth/login [post]
 func (h *AuthHandler) Login(c *fiber.Ctx) error {
 	// Validasyon middleware ile doğrulanmış veriyi al
 	reqData := middleware.GetValidated(c).(*domain.LoginRequest)
 
-	// Giriş yap
-	user, token, err := h.authService.Login(reqData.Username, reqData.Password, reqData.Remember)
+	// Giriş yap; iki adımlı doğrulama gerekiyorsa yanıt yalnızca doğrulama token'ı içerir
+	result, err := h.authService.Login(reqData.Username, reqData.Password, reqData.Remember, clientInfo(c))
 	if err != nil {
 		return err
 	}
 
 	return c.JSON(fiber.Map{
 		"success": true,
-		"data": fiber.Map{
-			"user":         user,
-			"access_token": token,
-		},
+		"data":    result,
 	})
 }
 
@@ -133,7 +164,9 @@ func (h *AuthHandler) GetCurrentUser(c *fiber.Ctx) error {
 		Email:        user.Email,
 		FullName:     user.FullName,
 		Role:         user.Role,
+		Status:       user.Status,
 		ProfileImage: user.ProfileImage,
+		Bio:          user.Bio,
 		CreatedAt:    user.CreatedAt,
 	}
 
@@ -157,53 +190,86 @@ func (h *AuthHandler) GetCurrentUser(c *fiber.Ctx) error {
 // @Failure 401 {object} domain.ErrorResponse "Geçersiz refresh token"
 // @Router /auth/refresh [post]
 func (h *AuthHandler) RefreshToken(c *fiber.Ctx) error {
-	// TODO: Refresh token implementasyonu
-	return nil
-}
+	// Validasyon middleware ile doğrulanmış veriyi al
+	reqData := middleware.GetValidated(c).(*RefreshTokenRequest)
+
+	// Token'ı döndür ve yeni çifti al
+	tokens, err := h.authService.RefreshTokens(reqData.RefreshToken, clientInfo(c))
+	if err != nil {
+		return err
+	}
 
-// Logout kullanıcının çıkış yapmasını sağlar
-func (h *AuthHandler) Logout(c *fiber.Ctx) error {
-	// TODO: Logout implementasyonu
 	return c.JSON(fiber.Map{
 		"success": true,
-		"message": "Başarıyla çıkış yapıldı",
+		"data":    tokens,
 	})
 }
 
-// ForgotPassword şifre sıfırlama bağlantısı gönderir
-func (h *AuthHandler) ForgotPassword(c *fiber.Ctx) error {
-	// TODO: Şifre sıfırlama implementasyonu
-	var request struct {
-		Email string `json:"email" validate:"required,email"`
+// Logout kullanıcının çıkış yapmasını sağlar
+// @Summary Çıkış
+// @Description Mevcut erişim token'ını ve gönderilirse yenileme token'ını iptal eder
+// @Tags Kimlik Doğrulama
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param logout body LogoutRequest false "Yenileme token bilgisi"
+// @Success 200 {object} domain.MessageResponse "Başarıyla çıkış yapıldı"
+// @Failure 401 {object} domain.ErrorResponse "Yetkisiz erişim"
+// @Router /auth/logout [post]
+func (h *AuthHandler) Logout(c *fiber.Ctx) error {
+	claims, ok := c.Locals("token_claims").(*auth.JWTCustomClaims)
+	if !ok {
+		return fiber.NewError(fiber.StatusUnauthorized, "Oturum bilgisi bulunamadı")
 	}
 
-	if err := c.BodyParser(&request); err != nil {
-		return domain.NewValidationError([]middleware.ValidationError{
-			{
-				Field:   "email",
-				Message: "Geçersiz email formatı",
-			},
-		})
+	// Gövde isteğe bağlı, boş gövdeyle yalnızca erişim token'ı iptal edilir
+	var req LogoutRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "Geçersiz istek formatı")
+		}
 	}
 
-	// Validasyon yap
-	if validationErrors, err := middleware.ValidateStruct(request); err != nil {
-		return domain.NewValidationError(validationErrors)
+	if err := h.authService.Logout(claims, req.RefreshToken); err != nil {
+		return err
 	}
 
-	// Email gönderme işlemi yapılır
-	err := h.authService.ForgotPassword(request.Email)
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Başarıyla çıkış yapıldı",
+	})
+}
+
+// RevokeUserTokens kullanıcının tüm token'larını iptal eder
+// @Summary Kullanıcı token'larını iptal et
+// @Description Kullanıcının tüm erişim ve yenileme token'larını iptal eder (Sadece Admin)
+// @Tags Admin,Kimlik Doğrulama
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Kullanıcı ID"
+// @Success 200 {object} domain.MessageResponse "Token'lar iptal edildi"
+// @Failure 400 {object} domain.ErrorResponse "Geçersiz kullanıcı ID"
+// @Failure 403 {object} domain.ErrorResponse "Yetersiz yetki"
+// @Failure 404 {object} domain.ErrorResponse "Kullanıcı bulunamadı"
+// @Router /admin/users/{id}/revoke-tokens [post]
+func (h *AuthHandler) RevokeUserTokens(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
 	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Geçersiz kullanıcı ID")
+	}
+
+	if err := h.authService.RevokeUserTokens(uint(id), middleware.GetActor(c)); err != nil {
 		return err
 	}
 
 	return c.JSON(fiber.Map{
 		"success": true,
-		"message": "Şifre sıfırlama bağlantısı email adresinize gönderildi",
+		"message": "Kullanıcının tüm oturumları sonlandırıldı",
 	})
 }
 
-// ResetPassword şifre sıfırlama isteği oluşturur
+// ForgotPassword şifre sıfırlama bağlantısı gönderir
 // @Summary Şifre sıfırlama isteği
 // @Description E-posta adresi ile şifre sıfırlama isteği oluşturur
 // @Tags Kimlik Doğrulama
@@ -212,11 +278,47 @@ func (h *AuthHandler) ForgotPassword(c *fiber.Ctx) error {
 // @Param reset body ResetPasswordRequest true "E-posta bilgisi"
 // @Success 200 {object} domain.MessageResponse "Şifre sıfırlama e-postası gönderildi"
 // @Failure 400 {object} domain.ErrorResponse "Geçersiz istek formatı"
-// @Failure 404 {object} domain.ErrorResponse "E-posta adresi bulunamadı"
+// @Router /auth/forgot-password [post]
+func (h *AuthHandler) ForgotPassword(c *fiber.Ctx) error {
+	// Validasyon middleware ile doğrulanmış veriyi al
+	reqData := middleware.GetValidated(c).(*ResetPasswordRequest)
+
+	// Kayıtlı olmayan adresler için de aynı yanıt döner
+	err := h.authService.ForgotPassword(reqData.Email)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Şifre sıfırlama bağlantısı email adresinize gönderildi",
+	})
+}
+
+// ResetPassword şifre sıfırlama işlemini tamamlar
+// @Summary Şifre sıfırlama işlemini tamamla
+// @Description Token ve yeni şifre ile şifre sıfırlama işlemini tamamlar, tüm oturumları sonlandırır
+// @Tags Kimlik Doğrulama
+// @Accept json
+// @Produce json
+// @Param confirm body ConfirmResetPasswordRequest true "Token ve yeni şifre bilgileri"
+// @Success 200 {object} domain.MessageResponse "Şifre başarıyla güncellendi"
+// @Failure 400 {object} domain.ErrorResponse "Geçersiz istek formatı veya şifreler eşleşmiyor"
+// @Failure 401 {object} domain.ErrorResponse "Geçersiz veya süresi dolmuş token"
 // @Router /auth/reset-password [post]
 func (h *AuthHandler) ResetPassword(c *fiber.Ctx) error {
-	// TODO: Şifre sıfırlama implementasyonu
-	return nil
+	// Validasyon middleware ile doğrulanmış veriyi al
+	reqData := middleware.GetValidated(c).(*ConfirmResetPasswordRequest)
+
+	err := h.authService.ConfirmResetPassword(reqData.Token, reqData.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Şifreniz başarıyla güncellendi, lütfen tekrar giriş yapın",
+	})
 }
 
 // ChangePassword şifre değiştirir
@@ -238,3 +340,334 @@ func (h *AuthHandler) ChangePassword(c *fiber.Ctx) error {
 		"message": "Şifreniz başarıyla değiştirildi",
 	})
 }
+
+// VerifyEmail e-posta adresini doğrular
+// @Summary E-posta doğrulama
+// @Description Doğrulama bağlantısındaki token ile kullanıcının e-posta adresini doğrular
+// @Tags Kimlik Doğrulama
+// @Accept json
+// @Produce json
+// @Param verify body VerifyEmailRequest true "Doğrulama token'ı"
+// @Success 200 {object} domain.MessageResponse "E-posta adresi doğrulandı"
+// @Failure 400 {object} domain.ErrorResponse "Geçersiz istek formatı"
+// @Failure 401 {object} domain.ErrorResponse "Geçersiz veya süresi dolmuş token"
+// @Router /auth/verify-email [post]
+func (h *AuthHandler) VerifyEmail(c *fiber.Ctx) error {
+	// Validasyon middleware ile doğrulanmış veriyi al
+	reqData := middleware.GetValidated(c).(*VerifyEmailRequest)
+
+	if _, err := h.authService.VerifyEmail(reqData.Token); err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "E-posta adresiniz doğrulandı",
+	})
+}
+
+// ResendVerification doğrulama e-postasını yeniden gönderir
+// @Summary Doğrulama e-postasını yeniden gönder
+// @Description Giriş yapmış ve e-postasını doğrulamamış kullanıcıya yeni bir doğrulama bağlantısı gönderir
+// @Tags Kimlik Doğrulama
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} domain.MessageResponse "Doğrulama e-postası gönderildi"
+// @Failure 400 {object} domain.ErrorResponse "E-posta adresi zaten doğrulanmış"
+// @Failure 401 {object} domain.ErrorResponse "Yetkisiz erişim"
+// @Failure 429 {object} domain.ErrorResponse "Çok fazla istek"
+// @Router /auth/resend-verification [post]
+func (h *AuthHandler) ResendVerification(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uint)
+
+	if err := h.authService.ResendVerificationEmail(userID); err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Doğrulama bağlantısı e-posta adresinize gönderildi",
+	})
+}
+
+// MarkEmailVerified kullanıcının e-posta adresini doğrulanmış olarak işaretler
+// @Summary E-postayı doğrulanmış işaretle
+// @Description Kullanıcının e-posta adresini bağlantı beklemeden doğrulanmış olarak işaretler (Sadece Admin)
+// @Tags Admin,Kimlik Doğrulama
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Kullanıcı ID"
+// @Success 200 {object} domain.User "Güncellenmiş kullanıcı"
+// @Failure 400 {object} domain.ErrorResponse "Geçersiz kullanıcı ID"
+// @Failure 403 {object} domain.ErrorResponse "Yetersiz yetki"
+// @Failure 404 {object} domain.ErrorResponse "Kullanıcı bulunamadı"
+// @Router /admin/users/{id}/verify-email [post]
+func (h *AuthHandler) MarkEmailVerified(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Geçersiz kullanıcı ID")
+	}
+
+	user, err := h.authService.MarkEmailVerified(uint(id), middleware.GetActor(c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Kullanıcının e-posta adresi doğrulanmış olarak işaretlendi",
+		"data":    user,
+	})
+}
+
+// VerifyMFA giriş sırasında iki adımlı doğrulama kodunu doğrular
+// @Summary İki adımlı doğrulama
+// @Description Girişte dönen doğrulama token'ı ve TOTP ya da kurtarma kodu ile token alır
+// @Tags Kimlik Doğrulama
+// @Accept json
+// @Produce json
+// @Param mfa body domain.MFAChallengeRequest true "Doğrulama token'ı ve kod"
+// @Success 200 {object} domain.AuthResponse "Başarılı giriş"
+// @Failure 400 {object} domain.ErrorResponse "Doğrulama kodu hatalı"
+// @Failure 401 {object} domain.ErrorResponse "Geçersiz veya süresi dolmuş token"
+// @Router /auth/mfa/verify [post]
+func (h *AuthHandler) VerifyMFA(c *fiber.Ctx) error {
+	// Validasyon middleware ile doğrulanmış veriyi al
+	reqData := middleware.GetValidated(c).(*domain.MFAChallengeRequest)
+
+	result, err := h.authService.VerifyMFAChallenge(reqData.ChallengeToken, reqData.Code, clientInfo(c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// StartChallengeEnrollment zorunlu iki adımlı doğrulama kurulumunu giriş sırasında başlatır
+// @Summary Girişte 2FA kurulumunu başlat
+// @Description 2FA zorunlu olan ve henüz kurmamış personel için TOTP anahtarı üretir
+// @Tags Kimlik Doğrulama
+// @Accept json
+// @Produce json
+// @Param mfa body domain.MFAChallengeEnrollRequest true "Doğrulama token'ı"
+// @Success 200 {object} domain.MFAEnrollment "TOTP anahtarı ve otpauth adresi"
+// @Failure 401 {object} domain.ErrorResponse "Geçersiz veya süresi dolmuş token"
+// @Router /auth/mfa/challenge/enroll [post]
+func (h *AuthHandler) StartChallengeEnrollment(c *fiber.Ctx) error {
+	// Validasyon middleware ile doğrulanmış veriyi al
+	reqData := middleware.GetValidated(c).(*domain.MFAChallengeEnrollRequest)
+
+	enrollment, err := h.authService.StartChallengeEnrollment(reqData.ChallengeToken)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    enrollment,
+	})
+}
+
+// ConfirmChallengeEnrollment giriş sırasındaki 2FA kurulumunu tamamlar
+// @Summary Girişte 2FA kurulumunu tamamla
+// @Description İlk TOTP koduyla kurulumu tamamlar, kurtarma kodlarını ve token'ları döndürür
+// @Tags Kimlik Doğrulama
+// @Accept json
+// @Produce json
+// @Param mfa body domain.MFAChallengeRequest true "Doğrulama token'ı ve kod"
+// @Success 200 {object} domain.MFAEnableResponse "Kurtarma kodları ve token'lar"
+// @Failure 400 {object} domain.ErrorResponse "Doğrulama kodu hatalı"
+// @Failure 401 {object} domain.ErrorResponse "Geçersiz veya süresi dolmuş token"
+// @Router /auth/mfa/challenge/confirm [post]
+func (h *AuthHandler) ConfirmChallengeEnrollment(c *fiber.Ctx) error {
+	// Validasyon middleware ile doğrulanmış veriyi al
+	reqData := middleware.GetValidated(c).(*domain.MFAChallengeRequest)
+
+	result, err := h.authService.ConfirmChallengeEnrollment(reqData.ChallengeToken, reqData.Code, clientInfo(c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// StartMFAEnrollment iki adımlı doğrulama kurulumunu başlatır
+// @Summary 2FA kurulumunu başlat
+// @Description Yeni bir TOTP anahtarı ve QR kod içeriği üretir; anahtar ilk kod doğrulanana kadar etkin olmaz
+// @Tags Kimlik Doğrulama
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} domain.MFAEnrollment "TOTP anahtarı ve otpauth adresi"
+// @Failure 400 {object} domain.ErrorResponse "İki adımlı doğrulama zaten etkin"
+// @Failure 401 {object} domain.ErrorResponse "Yetkisiz erişim"
+// @Router /auth/mfa/enroll [post]
+func (h *AuthHandler) StartMFAEnrollment(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uint)
+
+	enrollment, err := h.authService.StartMFAEnrollment(userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    enrollment,
+	})
+}
+
+// ConfirmMFAEnrollment iki adımlı doğrulamayı etkinleştirir
+// @Summary 2FA'yı etkinleştir
+// @Description İlk TOTP koduyla kurulumu tamamlar ve tek kullanımlık kurtarma kodlarını döndürür
+// @Tags Kimlik Doğrulama
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param mfa body domain.MFACodeRequest true "TOTP kodu"
+// @Success 200 {object} domain.MFAEnableResponse "Kurtarma kodları"
+// @Failure 400 {object} domain.ErrorResponse "Doğrulama kodu hatalı"
+// @Failure 401 {object} domain.ErrorResponse "Yetkisiz erişim"
+// @Router /auth/mfa/enable [post]
+func (h *AuthHandler) ConfirmMFAEnrollment(c *fiber.Ctx) error {
+	// Validasyon middleware ile doğrulanmış veriyi al
+	reqData := middleware.GetValidated(c).(*domain.MFACodeRequest)
+	userID := c.Locals("user_id").(uint)
+
+	result, err := h.authService.ConfirmMFAEnrollment(userID, reqData.Code)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "İki adımlı doğrulama etkinleştirildi, kurtarma kodlarınızı güvenli bir yerde saklayın",
+		"data":    result,
+	})
+}
+
+// RegenerateRecoveryCodes kurtarma kodlarını yeniler
+// @Summary Kurtarma kodlarını yenile
+// @Description Geçerli bir kod ile eski kurtarma kodlarını geçersiz kılar ve yenilerini üretir
+// @Tags Kimlik Doğrulama
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param mfa body domain.MFACodeRequest true "TOTP veya kurtarma kodu"
+// @Success 200 {object} domain.MFAEnableResponse "Yeni kurtarma kodları"
+// @Failure 400 {object} domain.ErrorResponse "Doğrulama kodu hatalı"
+// @Failure 401 {object} domain.ErrorResponse "Yetkisiz erişim"
+// @Router /auth/mfa/recovery-codes [post]
+func (h *AuthHandler) RegenerateRecoveryCodes(c *fiber.Ctx) error {
+	// Validasyon middleware ile doğrulanmış veriyi al
+	reqData := middleware.GetValidated(c).(*domain.MFACodeRequest)
+	userID := c.Locals("user_id").(uint)
+
+	codes, err := h.authService.RegenerateRecoveryCodes(userID, reqData.Code)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data": &domain.MFAEnableResponse{
+			RecoveryCodes: codes,
+		},
+	})
+}
+
+// DisableMFA iki adımlı doğrulamayı kapatır
+// @Summary 2FA'yı kapat
+// @Description Şifre ve geçerli bir kod ile iki adımlı doğrulamayı kapatır; zorunlu olan hesaplarda kapatılamaz
+// @Tags Kimlik Doğrulama
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param mfa body domain.DisableMFARequest true "Şifre ve kod"
+// @Success 200 {object} domain.MessageResponse "İki adımlı doğrulama kapatıldı"
+// @Failure 400 {object} domain.ErrorResponse "Şifre veya kod hatalı"
+// @Failure 403 {object} domain.ErrorResponse "İki adımlı doğrulama zorunlu"
+// @Router /auth/mfa/disable [post]
+func (h *AuthHandler) DisableMFA(c *fiber.Ctx) error {
+	// Validasyon middleware ile doğrulanmış veriyi al
+	reqData := middleware.GetValidated(c).(*domain.DisableMFARequest)
+	userID := c.Locals("user_id").(uint)
+
+	if err := h.authService.DisableMFA(userID, reqData.Password, reqData.Code); err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "İki adımlı doğrulama kapatıldı",
+	})
+}
+
+// UnlockAccount e-postadaki bağlantı ile hesap kilidini açar
+// @Summary Hesap kilidini aç
+// @Description Hatalı giriş denemeleri nedeniyle kilitlenen hesabı e-postadaki token ile açar
+// @Tags Kimlik Doğrulama
+// @Accept json
+// @Produce json
+// @Param unlock body UnlockAccountRequest true "Kilit açma token'ı"
+// @Success 200 {object} domain.MessageResponse "Hesap kilidi açıldı"
+// @Failure 400 {object} domain.ErrorResponse "Geçersiz istek formatı"
+// @Failure 401 {object} domain.ErrorResponse "Geçersiz veya süresi dolmuş token"
+// @Router /auth/unlock-account [post]
+func (h *AuthHandler) UnlockAccount(c *fiber.Ctx) error {
+	// Validasyon middleware ile doğrulanmış veriyi al
+	reqData := middleware.GetValidated(c).(*UnlockAccountRequest)
+
+	if err := h.authService.UnlockAccount(reqData.Token); err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Hesabınızın kilidi açıldı, tekrar giriş yapabilirsiniz",
+	})
+}
+
+// AdminUnlockAccount kullanıcının giriş kilidini kaldırır
+// @Summary Kullanıcı kilidini aç
+// @Description Hatalı giriş denemeleri nedeniyle kilitlenen hesabın kilidini kaldırır (Sadece Admin)
+// @Tags Admin,Kimlik Doğrulama
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Kullanıcı ID"
+// @Success 200 {object} domain.MessageResponse "Hesap kilidi açıldı"
+// @Failure 400 {object} domain.ErrorResponse "Geçersiz kullanıcı ID"
+// @Failure 403 {object} domain.ErrorResponse "Yetersiz yetki"
+// @Failure 404 {object} domain.ErrorResponse "Kullanıcı bulunamadı"
+// @Router /admin/users/{id}/unlock [post]
+func (h *AuthHandler) AdminUnlockAccount(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Geçersiz kullanıcı ID")
+	}
+
+	if err := h.authService.AdminUnlockAccount(uint(id), middleware.GetActor(c)); err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Kullanıcının hesap kilidi kaldırıldı",
+	})
+}
+
+// clientInfo oturum kaydı için istemci bilgilerini toplar
+func clientInfo(c *fiber.Ctx) *domain.ClientInfo {
+	return &domain.ClientInfo{
+		IP:        c.IP(),
+		UserAgent: c.Get(fiber.HeaderUserAgent),
+	}
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/username/haber/internal/service"
+)
+
+// AuthorHandler herkese açık yazar profili işleyicileri
+type AuthorHandler struct {
+	authorService service.IAuthorService
+}
+
+// NewAuthorHandler yeni bir AuthorHandler oluşturur
+func NewAuthorHandler(authorService service.IAuthorService) *AuthorHandler {
+	return &AuthorHandler{
+		authorService: authorService,
+	}
+}
+
+// RegisterRoutes rotaları kayıt eder
+func (h *AuthorHandler) RegisterRoutes(router fiber.Router, authMw fiber.Handler, adminMw fiber.Handler) {
+	// Herkese açık rotalar
+	router.Get("/authors", h.ListAuthors)
+	router.Get("/authors/:username", h.GetAuthor)
+}
+
+// ListAuthors yazarları listeler
+// @Summary Yazarları listele
+// @Description Yayın ekibini ve makalesi yayımlanmış yazarları makale ve okunma sayılarıyla listeler ("ekibimiz" sayfası için)
+// @Tags Yazarlar
+// @Accept json
+// @Produce json
+// @Param page query int false "Sayfa numarası (varsayılan: 1)"
+// @Param limit query int false "Sayfa başına sonuç sayısı (varsayılan: 20, maksimum: 100)"
+// @Success 200 {object} domain.PaginatedResponse{data=[]domain.AuthorProfile}
+// @Router /authors [get]
+func (h *AuthorHandler) ListAuthors(c *fiber.Ctx) error {
+	// Sayfalama parametrelerini al
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	offset := (page - 1) * limit
+
+	authors, total, err := h.authorService.ListAuthors(offset, limit)
+	if err != nil {
+		return err
+	}
+
+	// Toplam sayfa sayısını hesapla
+	totalPages := (int(total) + limit - 1) / limit
+	if totalPages < 1 {
+		totalPages = 1
+	}
+
+	return c.JSON(fiber.Map{
+		"data": authors,
+		"meta": fiber.Map{
+			"current_page": page,
+			"per_page":     limit,
+			"total":        total,
+			"total_pages":  totalPages,
+		},
+	})
+}
+
+// GetAuthor yazar profilini getirir
+// @Summary Yazar profili
+// @Description Yazarın herkese açık profilini, makale ve okunma sayılarını ve son yayımlanan makalelerini getirir
+// @Tags Yazarlar
+// @Accept json
+// @Produce json
+// @Param username path string true "Kullanıcı adı"
+// @Success 200 {object} domain.AuthorProfile
+// @Failure 404 {object} domain.ErrorResponse "Yazar bulunamadı"
+// @Router /authors/{username} [get]
+func (h *AuthorHandler) GetAuthor(c *fiber.Ctx) error {
+	profile, err := h.authorService.GetAuthorProfile(c.Params("username"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(profile)
+}
@@ -4,6 +4,7 @@ import (
 	"strconv"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/username/haber/internal/api/middleware"
 	"github.com/username/haber/internal/domain"
 	"github.com/username/haber/internal/service"
 )
@@ -27,8 +28,8 @@ func (h *CategoryHandler) RegisterRoutes(router fiber.Router, authMw fiber.Handl
 	router.Get("/categories/:id", h.GetCategory)
 	router.Get("/categories/slug/:slug", h.GetCategoryBySlug)
 
-	// Sadece admin rotaları
-	adminRoutes := router.Group("/admin/categories", adminMw)
+	// category.manage yetkili kullanıcılar ve categories:write kapsamlı API anahtarları
+	adminRoutes := router.Group("/admin/categories", middleware.AllowAPIKey(domain.ScopeCategoriesWrite), authMw)
 	adminRoutes.Post("/", h.CreateCategory)
 	adminRoutes.Put("/:id", h.UpdateCategory)
 	adminRoutes.Delete("/:id", h.DeleteCategory)
@@ -116,7 +117,7 @@ func (h *CategoryHandler) CreateCategory(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "Geçersiz istek formatı")
 	}
 
-	category, err := h.categoryService.CreateCategory(&req)
+	category, err := h.categoryService.CreateCategory(&req, middleware.GetActor(c))
 	if err != nil {
 		return err
 	}
@@ -136,7 +137,7 @@ func (h *CategoryHandler) UpdateCategory(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "Geçersiz istek formatı")
 	}
 
-	category, err := h.categoryService.UpdateCategory(uint(id), &req)
+	category, err := h.categoryService.UpdateCategory(uint(id), &req, middleware.GetActor(c))
 	if err != nil {
 		return err
 	}
@@ -151,7 +152,7 @@ func (h *CategoryHandler) DeleteCategory(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "Geçersiz kategori ID")
 	}
 
-	err = h.categoryService.DeleteCategory(uint(id))
+	err = h.categoryService.DeleteCategory(uint(id), middleware.GetActor(c))
 	if err != nil {
 		return err
 	}
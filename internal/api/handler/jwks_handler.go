@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/username/haber/internal/api/middleware"
+	"github.com/username/haber/internal/domain"
+	"github.com/username/haber/pkg/auth"
+)
+
+// JWKSHandler token imza anahtarlarının yayınlanması ve döndürülmesi işleyicileri
+type JWKSHandler struct {
+	jwtAuth           *auth.JWTAuth
+	requirePermission middleware.PermissionGuard
+}
+
+// NewJWKSHandler yeni bir JWKSHandler oluşturur
+func NewJWKSHandler(jwtAuth *auth.JWTAuth, requirePermission middleware.PermissionGuard) *JWKSHandler {
+	return &JWKSHandler{
+		jwtAuth:           jwtAuth,
+		requirePermission: requirePermission,
+	}
+}
+
+// RegisterWellKnownRoutes JWKS rotasını kayıt eder
+//
+// Diğer servisler anahtarları standart adresten aradığı için uygulamanın
+// köküne (API ön eki olmadan) kaydedilmelidir.
+func (h *JWKSHandler) RegisterWellKnownRoutes(app fiber.Router) {
+	app.Get("/.well-known/jwks.json", h.GetJWKS)
+}
+
+// RegisterRoutes rotaları kayıt eder
+func (h *JWKSHandler) RegisterRoutes(router fiber.Router, authMw fiber.Handler, adminMw fiber.Handler) {
+	// İmza anahtarları sistem ayarı sayılır
+	router.Post("/admin/jwt/rotate", h.requirePermission(domain.PermSettingsManage), h.RotateKeys)
+}
+
+// GetJWKS token doğrulamak için açık anahtarları döndürür
+// @Summary JWKS
+// @Description Erişim token'larını doğrulamak için kullanılabilecek açık anahtarları JWK Set olarak döndürür
+// @Tags Kimlik Doğrulama
+// @Produce json
+// @Success 200 {object} auth.JSONWebKeySet "Açık anahtar kümesi"
+// @Router /.well-known/jwks.json [get]
+func (h *JWKSHandler) GetJWKS(c *fiber.Ctx) error {
+	set, err := h.jwtAuth.JWKS()
+	if err != nil {
+		return err
+	}
+
+	// Doğrulayıcılar bilinmeyen bir kid gördüğünde kümeyi yeniden ister
+	c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+	c.Set(fiber.HeaderContentType, "application/jwk-set+json")
+	return c.JSON(set)
+}
+
+// RotateKeys imza anahtarını hemen döndürür
+// @Summary İmza anahtarını döndür
+// @Description Yeni bir imza anahtarı üretir; önceki anahtarlar token ömrü boyunca doğrulama için tutulur (Sadece Admin)
+// @Tags Admin,Kimlik Doğrulama
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} domain.MessageResponse "Anahtar döndürüldü"
+// @Failure 400 {object} domain.ErrorResponse "HS256 modunda döndürme desteklenmez"
+// @Failure 401 {object} domain.ErrorResponse "Yetkisiz erişim"
+// @Failure 403 {object} domain.ErrorResponse "Yetersiz yetki"
+// @Router /admin/jwt/rotate [post]
+func (h *JWKSHandler) RotateKeys(c *fiber.Ctx) error {
+	if !h.jwtAuth.IsAsymmetric() {
+		return fiber.NewError(fiber.StatusBadRequest, "HS256 modunda anahtar döndürme desteklenmez")
+	}
+
+	key, err := h.jwtAuth.RotateKeys(true)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"success":   true,
+		"message":   "İmza anahtarı döndürüldü",
+		"kid":       key.ID,
+		"algorithm": key.Algorithm,
+	})
+}
@@ -0,0 +1,506 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/username/haber/internal/api/middleware"
+	"github.com/username/haber/internal/domain"
+	"github.com/username/haber/internal/service"
+)
+
+// MediaHandler medya yönetimi işleyicileri
+type MediaHandler struct {
+	mediaService      service.IMediaService
+	requirePermission middleware.PermissionGuard
+}
+
+// NewMediaHandler yeni bir MediaHandler oluşturur
+func NewMediaHandler(mediaService service.IMediaService, requirePermission middleware.PermissionGuard) *MediaHandler {
+	return &MediaHandler{
+		mediaService:      mediaService,
+		requirePermission: requirePermission,
+	}
+}
+
+// RegisterRoutes rotaları kayıt eder
+func (h *MediaHandler) RegisterRoutes(router fiber.Router, authMw fiber.Handler, adminMw fiber.Handler) {
+	// Medya kütüphanesi; düzenleme yetkisi serviste kontrol edilir
+	readMw := []fiber.Handler{middleware.AllowAPIKey(domain.ScopeMediaRead), authMw}
+	router.Get("/media", append(readMw, h.SearchMedia)...)
+	router.Get("/media/:id", append(readMw, h.GetMedia)...)
+	router.Get("/media/:id/usages", append(readMw, h.ListUsages)...)
+	router.Put("/media/:id", middleware.AllowAPIKey(domain.ScopeMediaWrite), authMw,
+		middleware.RequireVerifiedEmail(), middleware.ValidateRequest(&domain.UpdateMediaRequest{}), h.UpdateMedia)
+
+	// Medya bakım rotaları (türevler, özetler, çöp toplama, içe aktarma)
+	adminRoutes := router.Group("/admin/media", h.requirePermission(domain.PermMediaManage))
+	adminRoutes.Post("/derivatives/regenerate", h.RegenerateDerivatives)
+	adminRoutes.Get("/derivatives/regenerate", h.GetDerivativeJob)
+	adminRoutes.Post("/hashes/backfill", h.BackfillContentHashes)
+	adminRoutes.Get("/hashes/backfill", h.GetContentHashJob)
+	adminRoutes.Get("/duplicates", h.ListDuplicates)
+	adminRoutes.Post("/usages/rebuild", h.RebuildUsages)
+	adminRoutes.Get("/usages/rebuild", h.GetUsageJob)
+	adminRoutes.Post("/gc", h.RunGarbageCollection)
+	adminRoutes.Get("/gc", h.GetGarbageCollection)
+	adminRoutes.Post("/import", h.ImportArchive)
+	adminRoutes.Get("/import/:jobId", h.GetImportJob)
+}
+
+// SearchMedia medya kütüphanesinde arama yapar
+// @Summary Medya kütüphanesinde ara
+// @Description Dosya adı, alternatif metin, açıklama, künye ve telif bilgilerinde arar; etikete, yükleyene ve dosya grubuna göre filtreler
+// @Tags Medya
+// @Produce json
+// @Security ApiKeyAuth
+// @Param q query string false "Arama metni"
+// @Param tag_id query int false "Etiket ID"
+// @Param user_id query int false "Yükleyen kullanıcı ID"
+// @Param kind query string false "Dosya grubu: image, document veya archive"
+// @Param missing_alt query bool false "Yalnızca alternatif metni eksik olanlar"
+// @Param page query int false "Sayfa numarası (varsayılan: 1)"
+// @Param limit query int false "Sayfa başına sonuç sayısı (varsayılan: 20, maksimum: 100)"
+// @Success 200 {object} domain.PaginatedResponse{data=[]domain.MediaResponse}
+// @Failure 400 {object} domain.ErrorResponse "Geçersiz filtre"
+// @Failure 401 {object} domain.ErrorResponse "Yetkisiz erişim"
+// @Router /media [get]
+func (h *MediaHandler) SearchMedia(c *fiber.Ctx) error {
+	filter := &domain.MediaFilter{
+		Query:          c.Query("q"),
+		Kind:           c.Query("kind"),
+		MissingAltText: c.QueryBool("missing_alt"),
+	}
+	for _, param := range []struct {
+		name   string
+		target **uint
+	}{
+		{"tag_id", &filter.TagID},
+		{"user_id", &filter.UserID},
+	} {
+		value := c.Query(param.name)
+		if value == "" {
+			continue
+		}
+		id, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return &domain.ValidationError{
+				Field:   param.name,
+				Message: "Geçersiz ID",
+			}
+		}
+		parsed := uint(id)
+		*param.target = &parsed
+	}
+
+	// Sayfalama parametrelerini al
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	offset := (page - 1) * limit
+
+	media, total, err := h.mediaService.SearchMedia(filter, offset, limit)
+	if err != nil {
+		return err
+	}
+
+	responses := make([]*domain.MediaResponse, len(media))
+	for i, item := range media {
+		responses[i] = h.mediaService.ToResponse(item)
+	}
+
+	// Toplam sayfa sayısını hesapla
+	totalPages := (int(total) + limit - 1) / limit
+	if totalPages < 1 {
+		totalPages = 1
+	}
+
+	return c.JSON(fiber.Map{
+		"data": responses,
+		"meta": fiber.Map{
+			"current_page": page,
+			"per_page":     limit,
+			"total":        total,
+			"total_pages":  totalPages,
+		},
+	})
+}
+
+// GetMedia medya bilgilerini getirir
+// @Summary Medya bilgisi
+// @Description Medyanın künye, EXIF ve türev bilgilerini getirir
+// @Tags Medya
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Medya ID"
+// @Success 200 {object} domain.MediaResponse
+// @Failure 400 {object} domain.ErrorResponse "Geçersiz medya ID"
+// @Failure 404 {object} domain.ErrorResponse "Medya bulunamadı"
+// @Router /media/{id} [get]
+func (h *MediaHandler) GetMedia(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Geçersiz medya ID")
+	}
+
+	media, err := h.mediaService.GetMedia(uint(id))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(h.mediaService.ToResponse(media))
+}
+
+// ListUsages medyanın kullanıldığı yerleri listeler
+// @Summary Medya kullanımları
+// @Description Medyanın kapak görseli, içerik veya profil görseli olarak kullanıldığı makale ve kullanıcıları listeler
+// @Tags Medya
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Medya ID"
+// @Success 200 {array} domain.MediaUsage
+// @Failure 400 {object} domain.ErrorResponse "Geçersiz medya ID"
+// @Failure 404 {object} domain.ErrorResponse "Medya bulunamadı"
+// @Router /media/{id}/usages [get]
+func (h *MediaHandler) ListUsages(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Geçersiz medya ID")
+	}
+
+	usages, err := h.mediaService.ListUsages(uint(id))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    usages,
+	})
+}
+
+// UpdateMedia medya künye bilgilerini günceller
+// @Summary Medya künyesini güncelle
+// @Description Alternatif metin, açıklama, künye, kaynak, telif ve etiketleri günceller
+// @Tags Medya
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Medya ID"
+// @Param media body domain.UpdateMediaRequest true "Künye bilgileri"
+// @Success 200 {object} domain.MediaResponse
+// @Failure 400 {object} domain.ErrorResponse "Geçersiz istek"
+// @Failure 401 {object} domain.ErrorResponse "Yetkisiz erişim"
+// @Failure 403 {object} domain.ErrorResponse "Bu dosyayı düzenleme yetkiniz yok"
+// @Failure 404 {object} domain.ErrorResponse "Medya veya etiket bulunamadı"
+// @Router /media/{id} [put]
+func (h *MediaHandler) UpdateMedia(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Geçersiz medya ID")
+	}
+
+	req := middleware.GetValidated(c).(*domain.UpdateMediaRequest)
+	media, err := h.mediaService.UpdateMedia(uint(id), req, middleware.GetActor(c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(h.mediaService.ToResponse(media))
+}
+
+// RegenerateDerivatives tüm görsellerin türevlerini yeniden üretir
+// @Summary Görsel türevlerini yeniden üret
+// @Description Türev tanımı değiştirildikten sonra tüm görsellerin türevlerini arka planda yeniden üretir (Sadece Admin)
+// @Tags Admin,Medya
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 202 {object} domain.MediaJob "Başlatılan iş"
+// @Failure 401 {object} domain.ErrorResponse "Yetkisiz erişim"
+// @Failure 403 {object} domain.ErrorResponse "Yetersiz yetki"
+// @Failure 409 {object} domain.ErrorResponse "Devam eden bir iş var"
+// @Router /admin/media/derivatives/regenerate [post]
+func (h *MediaHandler) RegenerateDerivatives(c *fiber.Ctx) error {
+	job, err := h.mediaService.RegenerateDerivatives(middleware.GetActor(c))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"success": true,
+		"data":    job,
+	})
+}
+
+// GetDerivativeJob son türev yeniden üretim işinin durumunu getirir
+// @Summary Türev işi durumu
+// @Description Son başlatılan türev yeniden üretim işinin ilerlemesini getirir (Sadece Admin)
+// @Tags Admin,Medya
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} domain.MediaJob "İş durumu"
+// @Failure 401 {object} domain.ErrorResponse "Yetkisiz erişim"
+// @Failure 403 {object} domain.ErrorResponse "Yetersiz yetki"
+// @Failure 404 {object} domain.ErrorResponse "Henüz iş başlatılmadı"
+// @Router /admin/media/derivatives/regenerate [get]
+func (h *MediaHandler) GetDerivativeJob(c *fiber.Ctx) error {
+	job, err := h.mediaService.GetDerivativeJob()
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    job,
+	})
+}
+
+// BackfillContentHashes eski yüklemelerin içerik özetlerini hesaplayan işi başlatır
+// @Summary İçerik özetlerini hesapla
+// @Description Tekilleştirme öncesi yüklenen dosyaların SHA-256 özetlerini arka planda hesaplar; kopya raporu bu özetlerle oluşturulur (Sadece Admin)
+// @Tags Admin,Medya
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 202 {object} domain.MediaJob "Başlatılan iş"
+// @Failure 401 {object} domain.ErrorResponse "Yetkisiz erişim"
+// @Failure 403 {object} domain.ErrorResponse "Yetersiz yetki"
+// @Failure 409 {object} domain.ErrorResponse "Devam eden bir iş var"
+// @Router /admin/media/hashes/backfill [post]
+func (h *MediaHandler) BackfillContentHashes(c *fiber.Ctx) error {
+	job, err := h.mediaService.BackfillContentHashes(middleware.GetActor(c))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"success": true,
+		"data":    job,
+	})
+}
+
+// GetContentHashJob son içerik özeti işinin durumunu getirir
+// @Summary İçerik özeti işi durumu
+// @Description Son başlatılan içerik özeti hesaplama işinin ilerlemesini getirir (Sadece Admin)
+// @Tags Admin,Medya
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} domain.MediaJob "İş durumu"
+// @Failure 401 {object} domain.ErrorResponse "Yetkisiz erişim"
+// @Failure 403 {object} domain.ErrorResponse "Yetersiz yetki"
+// @Failure 404 {object} domain.ErrorResponse "Henüz iş başlatılmadı"
+// @Router /admin/media/hashes/backfill [get]
+func (h *MediaHandler) GetContentHashJob(c *fiber.Ctx) error {
+	job, err := h.mediaService.GetContentHashJob()
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    job,
+	})
+}
+
+// ListDuplicates aynı içeriğe sahip ayrı dosyaları raporlar
+// @Summary Kopya dosya raporu
+// @Description Aynı içeriği depoda ayrı nesneler olarak saklayan medya gruplarını boşa harcanan alana göre listeler (Sadece Admin)
+// @Tags Admin,Medya
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "Sayfa numarası (varsayılan: 1)"
+// @Param limit query int false "Sayfa başına grup sayısı (varsayılan: 20, maksimum: 100)"
+// @Success 200 {object} domain.PaginatedResponse{data=[]domain.DuplicateCluster}
+// @Failure 401 {object} domain.ErrorResponse "Yetkisiz erişim"
+// @Failure 403 {object} domain.ErrorResponse "Yetersiz yetki"
+// @Router /admin/media/duplicates [get]
+func (h *MediaHandler) ListDuplicates(c *fiber.Ctx) error {
+	// Sayfalama parametrelerini al
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	offset := (page - 1) * limit
+
+	clusters, total, err := h.mediaService.ListDuplicates(offset, limit)
+	if err != nil {
+		return err
+	}
+
+	// Toplam sayfa sayısını hesapla
+	totalPages := (int(total) + limit - 1) / limit
+	if totalPages < 1 {
+		totalPages = 1
+	}
+
+	return c.JSON(fiber.Map{
+		"data": clusters,
+		"meta": fiber.Map{
+			"current_page": page,
+			"per_page":     limit,
+			"total":        total,
+			"total_pages":  totalPages,
+		},
+	})
+}
+
+// RebuildUsages medya kullanım kayıtlarını yeniden oluşturan işi başlatır
+// @Summary Medya kullanımlarını yeniden oluştur
+// @Description Tüm makale ve kullanıcıların medya referanslarını arka planda yeniden tarar; kullanım takibinden önce kaydedilmiş içerik için çalıştırılır (Sadece Admin)
+// @Tags Admin,Medya
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 202 {object} domain.MediaJob "Başlatılan iş"
+// @Failure 401 {object} domain.ErrorResponse "Yetkisiz erişim"
+// @Failure 403 {object} domain.ErrorResponse "Yetersiz yetki"
+// @Failure 409 {object} domain.ErrorResponse "Devam eden bir iş var"
+// @Router /admin/media/usages/rebuild [post]
+func (h *MediaHandler) RebuildUsages(c *fiber.Ctx) error {
+	job, err := h.mediaService.RebuildUsages(middleware.GetActor(c))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"success": true,
+		"data":    job,
+	})
+}
+
+// GetUsageJob son kullanım yeniden oluşturma işinin durumunu getirir
+// @Summary Kullanım işi durumu
+// @Description Son başlatılan kullanım yeniden oluşturma işinin ilerlemesini getirir (Sadece Admin)
+// @Tags Admin,Medya
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} domain.MediaJob "İş durumu"
+// @Failure 401 {object} domain.ErrorResponse "Yetkisiz erişim"
+// @Failure 403 {object} domain.ErrorResponse "Yetersiz yetki"
+// @Failure 404 {object} domain.ErrorResponse "Henüz iş başlatılmadı"
+// @Router /admin/media/usages/rebuild [get]
+func (h *MediaHandler) GetUsageJob(c *fiber.Ctx) error {
+	job, err := h.mediaService.GetUsageJob()
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    job,
+	})
+}
+
+// RunGarbageCollection depo taramasını başlatır
+// @Summary Depo taraması başlat
+// @Description Depoda kaydı olmayan dosyaları ve dosyası olmayan medya kayıtlarını arar. delete=true ise sahipsiz dosyalar ve kullanımda olmayan kayıtlar silinir (Sadece Admin)
+// @Tags Admin,Medya
+// @Produce json
+// @Security ApiKeyAuth
+// @Param delete query bool false "Bulunanları sil (varsayılan: yalnızca raporla)"
+// @Success 202 {object} domain.MediaJob "Başlatılan iş"
+// @Failure 401 {object} domain.ErrorResponse "Yetkisiz erişim"
+// @Failure 403 {object} domain.ErrorResponse "Yetersiz yetki"
+// @Failure 409 {object} domain.ErrorResponse "Devam eden bir tarama var"
+// @Failure 503 {object} domain.ErrorResponse "Dosya deposu kullanılamıyor"
+// @Router /admin/media/gc [post]
+func (h *MediaHandler) RunGarbageCollection(c *fiber.Ctx) error {
+	job, err := h.mediaService.RunGarbageCollection(c.QueryBool("delete"), middleware.GetActor(c))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"success": true,
+		"data":    job,
+	})
+}
+
+// GetGarbageCollection son depo taramasının durumunu ve raporunu getirir
+// @Summary Depo taraması raporu
+// @Description Son taramanın ilerlemesini ve son tamamlanan taramanın raporunu getirir (Sadece Admin)
+// @Tags Admin,Medya
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} domain.MediaGCReport "İş durumu (job) ve rapor (report)"
+// @Failure 401 {object} domain.ErrorResponse "Yetkisiz erişim"
+// @Failure 403 {object} domain.ErrorResponse "Yetersiz yetki"
+// @Failure 404 {object} domain.ErrorResponse "Henüz tarama yapılmadı"
+// @Router /admin/media/gc [get]
+func (h *MediaHandler) GetGarbageCollection(c *fiber.Ctx) error {
+	job, report, err := h.mediaService.GetGarbageCollection()
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data": fiber.Map{
+			"job":    job,
+			"report": report,
+		},
+	})
+}
+
+// ImportArchive ZIP arşivindeki dosyaları medya kütüphanesine aktaran işi başlatır
+// @Summary ZIP arşivinden toplu içe aktar
+// @Description Arşivdeki her dosya normal yükleme doğrulamasından ve türev üretiminden geçirilir. Arşiv kökündeki manifest.csv (filename, caption, alt_text, credit, source, copyright sütunları) varsa künye bilgileri buradan okunur. Dosya sayısı ve açılmış boyut sınırlıdır (Sadece Admin)
+// @Tags Admin,Medya
+// @Accept multipart/form-data
+// @Produce json
+// @Security ApiKeyAuth
+// @Param file formData file true "ZIP arşivi"
+// @Param folder formData string false "Klasör adı (varsayılan: general)"
+// @Success 202 {object} domain.MediaJob "Başlatılan iş"
+// @Failure 400 {object} domain.ErrorResponse "Geçersiz arşiv veya sınır aşıldı"
+// @Failure 401 {object} domain.ErrorResponse "Yetkisiz erişim"
+// @Failure 403 {object} domain.ErrorResponse "Yetersiz yetki"
+// @Failure 409 {object} domain.ErrorResponse "Devam eden bir içe aktarma var"
+// @Router /admin/media/import [post]
+func (h *MediaHandler) ImportArchive(c *fiber.Ctx) error {
+	file, err := c.FormFile("file")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Arşiv yüklenemedi")
+	}
+
+	job, err := h.mediaService.ImportArchive(file, c.FormValue("folder", "general"), middleware.GetActor(c))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"success": true,
+		"data":    job,
+	})
+}
+
+// GetImportJob içe aktarma işinin durumunu getirir
+// @Summary İçe aktarma durumu
+// @Description İçe aktarma işinin ilerlemesini ve her dosyanın sonucunu (oluşturulan medya veya hata) getirir (Sadece Admin)
+// @Tags Admin,Medya
+// @Produce json
+// @Security ApiKeyAuth
+// @Param jobId path string true "İş ID"
+// @Success 200 {object} domain.MediaJob "İş durumu"
+// @Failure 401 {object} domain.ErrorResponse "Yetkisiz erişim"
+// @Failure 403 {object} domain.ErrorResponse "Yetersiz yetki"
+// @Failure 404 {object} domain.ErrorResponse "İş bulunamadı"
+// @Router /admin/media/import/{jobId} [get]
+func (h *MediaHandler) GetImportJob(c *fiber.Ctx) error {
+	job, err := h.mediaService.GetImportJob(c.Params("jobId"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    job,
+	})
+}
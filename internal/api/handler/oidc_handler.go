@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"net/url"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/username/haber/internal/api/middleware"
+	"github.com/username/haber/internal/domain"
+	"github.com/username/haber/internal/service"
+)
+
+// oidcStateCookie SSO girişini başlatan tarayıcıya yazılan state çerezinin adı
+const oidcStateCookie = "oidc_state"
+
+// OIDCHandler OpenID Connect ile tek oturum açma işleyicileri
+type OIDCHandler struct {
+	oidcService service.IOIDCService
+}
+
+// NewOIDCHandler yeni bir OIDCHandler oluşturur
+func NewOIDCHandler(oidcService service.IOIDCService) *OIDCHandler {
+	return &OIDCHandler{
+		oidcService: oidcService,
+	}
+}
+
+// RegisterRoutes rotaları kayıt eder
+func (h *OIDCHandler) RegisterRoutes(router fiber.Router, authMw fiber.Handler, adminMw fiber.Handler) {
+	// Public routes
+	router.Get("/auth/oidc/login", h.Login)
+	router.Get("/auth/oidc/callback", h.Callback)
+	router.Post("/auth/oidc/exchange", middleware.ValidateRequest(&domain.OIDCExchangeRequest{}), h.Exchange)
+
+	// Protected routes
+	router.Get("/users/me/identities", authMw, h.ListMyIdentities)
+}
+
+// Login kimlik sağlayıcıyla girişi başlatır
+// @Summary SSO girişi başlat
+// @Description Kurumsal kimlik sağlayıcının giriş sayfasına yönlendirir. format=json verilirse yönlendirme yerine adres döner. Girişi tarayıcıya bağlayan state çerezi her iki durumda da yazılır.
+// @Tags Kimlik Doğrulama
+// @Produce json
+// @Param return_to query string false "Giriş sonrası dönülecek uygulama içi yol"
+// @Param format query string false "json ise adres JSON olarak döner"
+// @Success 200 {object} map[string]interface{} "Yetkilendirme adresi"
+// @Success 302 "Kimlik sağlayıcıya yönlendirme"
+// @Failure 403 {object} domain.ErrorResponse "SSO girişi etkin değil"
+// @Failure 503 {object} domain.ErrorResponse "Kimlik sağlayıcıya ulaşılamıyor"
+// @Router /auth/oidc/login [get]
+func (h *OIDCHandler) Login(c *fiber.Ctx) error {
+	authURL, state, err := h.oidcService.StartLogin(c.Query("return_to"))
+	if err != nil {
+		return err
+	}
+
+	// Kimlik sağlayıcıdan dönüş üst düzey bir yönlendirme olduğundan Lax yeterlidir
+	setOIDCStateCookie(c, state, int(service.OIDCStateTTL.Seconds()))
+
+	if c.Query("format") == "json" {
+		return c.JSON(fiber.Map{
+			"success": true,
+			"data": fiber.Map{
+				"authorization_url": authURL,
+			},
+		})
+	}
+
+	return c.Redirect(authURL, fiber.StatusFound)
+}
+
+// Callback kimlik sağlayıcıdan dönüşü işler ve uygulamaya yönlendirir
+// @Summary SSO dönüşü
+// @Description Kimlik sağlayıcının gönderdiği kodu doğrular, hesabı bulur, bağlar ya da oluşturur. State çerezi girişi başlatan tarayıcıyla eşleşmelidir. Başarılı girişte return_to adresine, fragment'ta /auth/oidc/exchange ile token'larla değiştirilecek tek kullanımlık sso_code ile yönlendirir.
+// @Tags Kimlik Doğrulama
+// @Produce json
+// @Param code query string true "Yetkilendirme kodu"
+// @Param state query string true "Giriş durumu"
+// @Success 302 "Uygulamaya yönlendirme"
+// @Failure 400 {object} domain.ErrorResponse "Eksik parametre"
+// @Failure 401 {object} domain.ErrorResponse "Geçersiz veya süresi dolmuş giriş"
+// @Failure 403 {object} domain.ErrorResponse "Eşleşen hesap yok veya izin verilmeyen alan adı"
+// @Router /auth/oidc/callback [get]
+func (h *OIDCHandler) Callback(c *fiber.Ctx) error {
+	// Kullanıcı girişi reddettiyse sağlayıcı kod yerine hata gönderir
+	if errCode := c.Query("error"); errCode != "" {
+		message := c.Query("error_description")
+		if message == "" {
+			message = errCode
+		}
+		return &domain.AuthError{
+			Message: "Kimlik sağlayıcı girişi reddetti: " + message,
+		}
+	}
+
+	code, state := c.Query("code"), c.Query("state")
+	if code == "" || state == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "code ve state parametreleri gereklidir")
+	}
+
+	// Giriş, başlatıldığı tarayıcıda tamamlanmalıdır
+	cookieState := c.Cookies(oidcStateCookie)
+	setOIDCStateCookie(c, "", -1)
+	if cookieState == "" || subtle.ConstantTimeCompare([]byte(cookieState), []byte(state)) != 1 {
+		return &domain.AuthError{
+			Message: "SSO girişi bu tarayıcıda başlatılmamış, lütfen tekrar deneyin",
+		}
+	}
+
+	handoff, err := h.oidcService.CompleteLogin(state, code)
+	if err != nil {
+		return err
+	}
+
+	// Token'lar adres çubuğunda görünmesin diye yalnızca giriş kodu fragment'ta taşınır
+	target := handoff.ReturnTo
+	if i := strings.IndexByte(target, '#'); i >= 0 {
+		target = target[:i]
+	}
+	if target == "" {
+		target = "/"
+	}
+
+	return c.Redirect(target+"#sso_code="+url.QueryEscape(handoff.Code), fiber.StatusFound)
+}
+
+// Exchange SSO dönüşünde verilen giriş kodunu token'larla değiştirir
+// @Summary SSO giriş kodunu değiştir
+// @Description Dönüş adresindeki tek kullanımlık sso_code değerini token'larla değiştirir. İki adımlı doğrulama gerekiyorsa token yerine /auth/mfa/verify ile kullanılacak doğrulama token'ı döner.
+// @Tags Kimlik Doğrulama
+// @Accept json
+// @Produce json
+// @Param request body domain.OIDCExchangeRequest true "Giriş kodu"
+// @Success 200 {object} domain.LoginResponse "Başarılı giriş"
+// @Failure 400 {object} domain.ErrorResponse "Geçersiz istek formatı"
+// @Failure 401 {object} domain.ErrorResponse "Geçersiz veya süresi dolmuş kod"
+// @Router /auth/oidc/exchange [post]
+func (h *OIDCHandler) Exchange(c *fiber.Ctx) error {
+	reqData := middleware.GetValidated(c).(*domain.OIDCExchangeRequest)
+
+	result, err := h.oidcService.ExchangeCode(reqData.Code, clientInfo(c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// ListMyIdentities mevcut kullanıcıya bağlı harici kimlikleri listeler
+// @Summary Bağlı kimlikler
+// @Description Hesaba bağlı kurumsal kimlik sağlayıcı hesaplarını listeler
+// @Tags Kullanıcılar
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} domain.UserIdentity "Kimlik listesi"
+// @Failure 401 {object} domain.ErrorResponse "Yetkisiz erişim"
+// @Router /users/me/identities [get]
+func (h *OIDCHandler) ListMyIdentities(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uint)
+
+	identities, err := h.oidcService.ListIdentities(userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    identities,
+	})
+}
+
+// setOIDCStateCookie state çerezini yazar; maxAge negatifse çerezi siler
+func setOIDCStateCookie(c *fiber.Ctx, state string, maxAge int) {
+	c.Cookie(&fiber.Cookie{
+		Name:     oidcStateCookie,
+		Value:    state,
+		Path:     "/",
+		MaxAge:   maxAge,
+		Secure:   c.Protocol() == "https",
+		HTTPOnly: true,
+		SameSite: fiber.CookieSameSiteLaxMode,
+	})
+}
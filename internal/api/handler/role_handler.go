@@ -0,0 +1,244 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/username/haber/internal/api/middleware"
+	"github.com/username/haber/internal/domain"
+	"github.com/username/haber/internal/service"
+)
+
+// RoleHandler rol ve yetki yönetimi işleyicileri
+type RoleHandler struct {
+	roleService       service.IRoleService
+	requirePermission middleware.PermissionGuard
+}
+
+// NewRoleHandler yeni bir RoleHandler oluşturur
+func NewRoleHandler(roleService service.IRoleService, requirePermission middleware.PermissionGuard) *RoleHandler {
+	return &RoleHandler{
+		roleService:       roleService,
+		requirePermission: requirePermission,
+	}
+}
+
+// RegisterRoutes rotaları kayıt eder
+func (h *RoleHandler) RegisterRoutes(router fiber.Router, authMw fiber.Handler, adminMw fiber.Handler) {
+	// Rol yönetimi rotaları
+	roleMw := h.requirePermission(domain.PermRoleManage)
+	router.Get("/admin/permissions", roleMw, h.ListPermissions)
+
+	adminRoutes := router.Group("/admin/roles", roleMw)
+	adminRoutes.Get("/", h.ListRoles)
+	adminRoutes.Get("/:name", h.GetRole)
+	adminRoutes.Post("/", middleware.ValidateRequest(&domain.CreateRoleRequest{}), h.CreateRole)
+	adminRoutes.Put("/:name", middleware.ValidateRequest(&domain.UpdateRoleRequest{}), h.UpdateRole)
+	adminRoutes.Delete("/:name", h.DeleteRole)
+
+	// Bölüm editörlerinin sorumlu olduğu kategoriler
+	userMw := h.requirePermission(domain.PermUserManage)
+	router.Get("/admin/users/:id/categories", userMw, h.GetAssignedCategories)
+	router.Put("/admin/users/:id/categories", userMw, middleware.ValidateRequest(&domain.AssignCategoriesRequest{}), h.AssignCategories)
+}
+
+// ListPermissions tanımlı yetkileri listeler
+// @Summary Yetkileri listele
+// @Description Rollere atanabilecek tüm yetkileri listeler (Sadece Admin)
+// @Tags Admin,Roller
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} string "Yetki listesi"
+// @Failure 401 {object} domain.ErrorResponse "Yetkisiz erişim"
+// @Failure 403 {object} domain.ErrorResponse "Yetersiz yetki"
+// @Router /admin/permissions [get]
+func (h *RoleHandler) ListPermissions(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    h.roleService.ListPermissions(),
+	})
+}
+
+// ListRoles rolleri listeler
+// @Summary Rolleri listele
+// @Description Tüm rolleri yetkileriyle birlikte listeler (Sadece Admin)
+// @Tags Admin,Roller
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} domain.Role "Rol listesi"
+// @Failure 401 {object} domain.ErrorResponse "Yetkisiz erişim"
+// @Failure 403 {object} domain.ErrorResponse "Yetersiz yetki"
+// @Router /admin/roles [get]
+func (h *RoleHandler) ListRoles(c *fiber.Ctx) error {
+	roles, err := h.roleService.ListRoles()
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    roles,
+	})
+}
+
+// GetRole ada göre rol getirir
+// @Summary Rol detayları
+// @Description Rolün yetkilerini getirir (Sadece Admin)
+// @Tags Admin,Roller
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param name path string true "Rol adı"
+// @Success 200 {object} domain.Role "Rol"
+// @Failure 401 {object} domain.ErrorResponse "Yetkisiz erişim"
+// @Failure 403 {object} domain.ErrorResponse "Yetersiz yetki"
+// @Failure 404 {object} domain.ErrorResponse "Rol bulunamadı"
+// @Router /admin/roles/{name} [get]
+func (h *RoleHandler) GetRole(c *fiber.Ctx) error {
+	role, err := h.roleService.GetRole(c.Params("name"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(role)
+}
+
+// CreateRole yeni bir rol oluşturur
+// @Summary Rol oluştur
+// @Description Verilen yetkilere sahip yeni bir rol oluşturur (Sadece Admin)
+// @Tags Admin,Roller
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param role body domain.CreateRoleRequest true "Rol bilgileri"
+// @Success 201 {object} domain.Role "Oluşturulan rol"
+// @Failure 400 {object} domain.ErrorResponse "Geçersiz istek formatı veya tanımsız yetki"
+// @Failure 401 {object} domain.ErrorResponse "Yetkisiz erişim"
+// @Failure 403 {object} domain.ErrorResponse "Yetersiz yetki"
+// @Failure 409 {object} domain.ErrorResponse "Rol adı zaten kullanımda"
+// @Router /admin/roles [post]
+func (h *RoleHandler) CreateRole(c *fiber.Ctx) error {
+	req := middleware.GetValidated(c).(*domain.CreateRoleRequest)
+
+	role, err := h.roleService.CreateRole(req, middleware.GetActor(c))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(role)
+}
+
+// UpdateRole rolün yetkilerini günceller
+// @Summary Rol güncelle
+// @Description Rolün açıklamasını ve yetki listesini günceller (Sadece Admin)
+// @Tags Admin,Roller
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param name path string true "Rol adı"
+// @Param role body domain.UpdateRoleRequest true "Rol bilgileri"
+// @Success 200 {object} domain.Role "Güncellenmiş rol"
+// @Failure 400 {object} domain.ErrorResponse "Geçersiz istek formatı veya tanımsız yetki"
+// @Failure 401 {object} domain.ErrorResponse "Yetkisiz erişim"
+// @Failure 403 {object} domain.ErrorResponse "Yetersiz yetki"
+// @Failure 404 {object} domain.ErrorResponse "Rol bulunamadı"
+// @Router /admin/roles/{name} [put]
+func (h *RoleHandler) UpdateRole(c *fiber.Ctx) error {
+	req := middleware.GetValidated(c).(*domain.UpdateRoleRequest)
+
+	role, err := h.roleService.UpdateRole(c.Params("name"), req, middleware.GetActor(c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(role)
+}
+
+// DeleteRole rolü siler
+// @Summary Rol sil
+// @Description Sistem rolü olmayan ve hiçbir kullanıcıya atanmamış rolü siler (Sadece Admin)
+// @Tags Admin,Roller
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param name path string true "Rol adı"
+// @Success 204 "Başarıyla silindi"
+// @Failure 400 {object} domain.ErrorResponse "Sistem rolü veya kullanımda olan rol"
+// @Failure 401 {object} domain.ErrorResponse "Yetkisiz erişim"
+// @Failure 403 {object} domain.ErrorResponse "Yetersiz yetki"
+// @Failure 404 {object} domain.ErrorResponse "Rol bulunamadı"
+// @Router /admin/roles/{name} [delete]
+func (h *RoleHandler) DeleteRole(c *fiber.Ctx) error {
+	if err := h.roleService.DeleteRole(c.Params("name"), middleware.GetActor(c)); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusNoContent).Send(nil)
+}
+
+// GetAssignedCategories kullanıcıya atanmış kategorileri getirir
+// @Summary Atanmış kategoriler
+// @Description Kullanıcının bölüm editörü olarak sorumlu olduğu kategorileri getirir (Sadece Admin)
+// @Tags Admin,Roller
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Kullanıcı ID"
+// @Success 200 {array} int "Kategori ID listesi"
+// @Failure 400 {object} domain.ErrorResponse "Geçersiz kullanıcı ID"
+// @Failure 401 {object} domain.ErrorResponse "Yetkisiz erişim"
+// @Failure 403 {object} domain.ErrorResponse "Yetersiz yetki"
+// @Failure 404 {object} domain.ErrorResponse "Kullanıcı bulunamadı"
+// @Router /admin/users/{id}/categories [get]
+func (h *RoleHandler) GetAssignedCategories(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Geçersiz kullanıcı ID")
+	}
+
+	ids, err := h.roleService.GetAssignedCategories(uint(id))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    ids,
+	})
+}
+
+// AssignCategories kullanıcıya kategori atar
+// @Summary Kategori ata
+// @Description Kullanıcının sorumlu olduğu kategorileri verilen listeyle değiştirir (Sadece Admin)
+// @Tags Admin,Roller
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Kullanıcı ID"
+// @Param categories body domain.AssignCategoriesRequest true "Kategori ID listesi"
+// @Success 200 {array} int "Atanmış kategori ID listesi"
+// @Failure 400 {object} domain.ErrorResponse "Geçersiz istek"
+// @Failure 401 {object} domain.ErrorResponse "Yetkisiz erişim"
+// @Failure 403 {object} domain.ErrorResponse "Yetersiz yetki"
+// @Failure 404 {object} domain.ErrorResponse "Kullanıcı veya kategori bulunamadı"
+// @Router /admin/users/{id}/categories [put]
+func (h *RoleHandler) AssignCategories(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Geçersiz kullanıcı ID")
+	}
+
+	req := middleware.GetValidated(c).(*domain.AssignCategoriesRequest)
+
+	ids, err := h.roleService.AssignCategories(uint(id), req.CategoryIDs, middleware.GetActor(c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    ids,
+	})
+}
@@ -0,0 +1,196 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/username/haber/internal/api/middleware"
+	"github.com/username/haber/internal/domain"
+	"github.com/username/haber/internal/service"
+	"github.com/username/haber/pkg/auth"
+)
+
+// SessionHandler oturum yönetimi işleyicileri
+type SessionHandler struct {
+	sessionService    service.ISessionService
+	requirePermission middleware.PermissionGuard
+}
+
+// NewSessionHandler yeni bir SessionHandler oluşturur
+func NewSessionHandler(sessionService service.ISessionService, requirePermission middleware.PermissionGuard) *SessionHandler {
+	return &SessionHandler{
+		sessionService:    sessionService,
+		requirePermission: requirePermission,
+	}
+}
+
+// RegisterRoutes rotaları kayıt eder
+func (h *SessionHandler) RegisterRoutes(router fiber.Router, authMw fiber.Handler, adminMw fiber.Handler) {
+	// Kullanıcının kendi oturumları
+	router.Get("/users/me/sessions", authMw, h.ListMySessions)
+	router.Delete("/users/me/sessions/:id", authMw, h.RevokeMySession)
+
+	// Kullanıcı yönetimi rotaları
+	userMw := h.requirePermission(domain.PermUserManage)
+	router.Get("/admin/users/:id/sessions", userMw, h.ListUserSessions)
+	router.Delete("/admin/users/:id/sessions", userMw, h.RevokeAllUserSessions)
+	router.Delete("/admin/users/:id/sessions/:sessionId", userMw, h.RevokeUserSession)
+}
+
+// ListMySessions mevcut kullanıcının açık oturumlarını listeler
+// @Summary Oturumlarım
+// @Description Giriş yapılmış cihazları son görülme zamanıyla listeler; isteği yapan oturum "current" ile işaretlenir
+// @Tags Kullanıcılar
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} domain.Session "Oturum listesi"
+// @Failure 401 {object} domain.ErrorResponse "Yetkisiz erişim"
+// @Router /users/me/sessions [get]
+func (h *SessionHandler) ListMySessions(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uint)
+
+	sessions, err := h.sessionService.ListSessions(userID, currentSessionID(c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    sessions,
+	})
+}
+
+// RevokeMySession mevcut kullanıcının bir oturumunu sonlandırır
+// @Summary Oturumu sonlandır
+// @Description Seçilen cihazdaki oturumu sonlandırır; o cihazın token'ları hemen geçersiz olur
+// @Tags Kullanıcılar
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Oturum ID"
+// @Success 200 {object} domain.MessageResponse "Oturum sonlandırıldı"
+// @Failure 400 {object} domain.ErrorResponse "Geçersiz oturum ID"
+// @Failure 401 {object} domain.ErrorResponse "Yetkisiz erişim"
+// @Failure 404 {object} domain.ErrorResponse "Oturum bulunamadı"
+// @Router /users/me/sessions/{id} [delete]
+func (h *SessionHandler) RevokeMySession(c *fiber.Ctx) error {
+	sessionID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Geçersiz oturum ID")
+	}
+
+	userID := c.Locals("user_id").(uint)
+
+	if err := h.sessionService.RevokeSession(userID, uint(sessionID), middleware.GetActor(c)); err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Oturum sonlandırıldı",
+	})
+}
+
+// ListUserSessions kullanıcının açık oturumlarını listeler
+// @Summary Kullanıcı oturumları
+// @Description Kullanıcının açık oturumlarını listeler (Sadece Admin)
+// @Tags Admin,Kullanıcılar
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Kullanıcı ID"
+// @Success 200 {array} domain.Session "Oturum listesi"
+// @Failure 400 {object} domain.ErrorResponse "Geçersiz kullanıcı ID"
+// @Failure 401 {object} domain.ErrorResponse "Yetkisiz erişim"
+// @Failure 403 {object} domain.ErrorResponse "Yetersiz yetki"
+// @Router /admin/users/{id}/sessions [get]
+func (h *SessionHandler) ListUserSessions(c *fiber.Ctx) error {
+	userID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Geçersiz kullanıcı ID")
+	}
+
+	sessions, err := h.sessionService.ListSessions(uint(userID), "")
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    sessions,
+	})
+}
+
+// RevokeUserSession kullanıcının bir oturumunu sonlandırır
+// @Summary Kullanıcı oturumunu sonlandır
+// @Description Kullanıcının seçilen oturumunu sonlandırır (Sadece Admin)
+// @Tags Admin,Kullanıcılar
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Kullanıcı ID"
+// @Param sessionId path int true "Oturum ID"
+// @Success 200 {object} domain.MessageResponse "Oturum sonlandırıldı"
+// @Failure 400 {object} domain.ErrorResponse "Geçersiz ID"
+// @Failure 401 {object} domain.ErrorResponse "Yetkisiz erişim"
+// @Failure 403 {object} domain.ErrorResponse "Yetersiz yetki"
+// @Failure 404 {object} domain.ErrorResponse "Oturum bulunamadı"
+// @Router /admin/users/{id}/sessions/{sessionId} [delete]
+func (h *SessionHandler) RevokeUserSession(c *fiber.Ctx) error {
+	userID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Geçersiz kullanıcı ID")
+	}
+
+	sessionID, err := strconv.ParseUint(c.Params("sessionId"), 10, 32)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Geçersiz oturum ID")
+	}
+
+	if err := h.sessionService.RevokeSession(uint(userID), uint(sessionID), middleware.GetActor(c)); err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Oturum sonlandırıldı",
+	})
+}
+
+// RevokeAllUserSessions kullanıcının tüm oturumlarını sonlandırır
+// @Summary Tüm oturumları sonlandır
+// @Description Kullanıcının bütün cihazlardaki oturumlarını sonlandırır (Sadece Admin)
+// @Tags Admin,Kullanıcılar
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Kullanıcı ID"
+// @Success 200 {object} domain.MessageResponse "Oturumlar sonlandırıldı"
+// @Failure 400 {object} domain.ErrorResponse "Geçersiz kullanıcı ID"
+// @Failure 401 {object} domain.ErrorResponse "Yetkisiz erişim"
+// @Failure 403 {object} domain.ErrorResponse "Yetersiz yetki"
+// @Router /admin/users/{id}/sessions [delete]
+func (h *SessionHandler) RevokeAllUserSessions(c *fiber.Ctx) error {
+	userID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Geçersiz kullanıcı ID")
+	}
+
+	if err := h.sessionService.RevokeAllSessions(uint(userID), middleware.GetActor(c)); err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Kullanıcının tüm oturumları sonlandırıldı",
+	})
+}
+
+// currentSessionID isteği yapan erişim token'ının oturum kimliğini döndürür
+func currentSessionID(c *fiber.Ctx) string {
+	if claims, ok := c.Locals("token_claims").(*auth.JWTCustomClaims); ok {
+		return claims.SessionID
+	}
+	return ""
+}
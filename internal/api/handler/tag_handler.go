@@ -4,6 +4,7 @@ import (
 	"strconv"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/username/haber/internal/api/middleware"
 	"github.com/username/haber/internal/domain"
 	"github.com/username/haber/internal/service"
 )
@@ -28,8 +29,8 @@ func (h *TagHandler) RegisterRoutes(router fiber.Router, authMw fiber.Handler, a
 	router.Get("/tags/slug/:slug", h.GetTagBySlug)
 	router.Get("/tags/article/:articleID", h.GetTagsByArticle)
 
-	// Sadece admin rotaları
-	adminRoutes := router.Group("/admin/tags", adminMw)
+	// tag.manage yetkili kullanıcılar ve tags:write kapsamlı API anahtarları
+	adminRoutes := router.Group("/admin/tags", middleware.AllowAPIKey(domain.ScopeTagsWrite), authMw)
 	adminRoutes.Post("/", h.CreateTag)
 	adminRoutes.Put("/:id", h.UpdateTag)
 	adminRoutes.Delete("/:id", h.DeleteTag)
@@ -160,7 +161,7 @@ func (h *TagHandler) CreateTag(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "Geçersiz istek formatı")
 	}
 
-	tag, err := h.tagService.CreateTag(req.Name, req.Slug)
+	tag, err := h.tagService.CreateTag(req.Name, req.Slug, middleware.GetActor(c))
 	if err != nil {
 		return err
 	}
@@ -194,7 +195,7 @@ func (h *TagHandler) UpdateTag(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "Geçersiz istek formatı")
 	}
 
-	tag, err := h.tagService.UpdateTag(uint(id), req.Name, req.Slug)
+	tag, err := h.tagService.UpdateTag(uint(id), req.Name, req.Slug, middleware.GetActor(c))
 	if err != nil {
 		return err
 	}
@@ -222,7 +223,7 @@ func (h *TagHandler) DeleteTag(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "Geçersiz etiket ID")
 	}
 
-	err = h.tagService.DeleteTag(uint(id))
+	err = h.tagService.DeleteTag(uint(id), middleware.GetActor(c))
 	if err != nil {
 		return err
 	}
@@ -1,35 +1,50 @@
 package handler
 
 import (
-	"path/filepath"
 	"strconv"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/username/haber/internal/api/middleware"
 	"github.com/username/haber/internal/domain"
 	"github.com/username/haber/internal/service"
 )
 
 // UploadHandler dosya yükleme işleyicileri
 type UploadHandler struct {
-	uploadService service.IUploadService
+	mediaService service.IMediaService
 }
 
 // NewUploadHandler yeni bir UploadHandler oluşturur
-func NewUploadHandler(uploadService service.IUploadService) *UploadHandler {
+func NewUploadHandler(mediaService service.IMediaService) *UploadHandler {
 	return &UploadHandler{
-		uploadService: uploadService,
+		mediaService: mediaService,
 	}
 }
 
 // RegisterRoutes rotaları kayıt eder
 func (h *UploadHandler) RegisterRoutes(router fiber.Router, authMw fiber.Handler, adminMw fiber.Handler) {
-	// Yalnızca giriş yapmış kullanıcılar yükleme yapabilir
-	uploadRoutes := router.Group("/uploads", authMw)
-	uploadRoutes.Post("/", h.UploadFile)
-	uploadRoutes.Delete("/:id", h.DeleteFile)
-
-	// Herkese açık
+	// Herkese açık; grup middleware'i tüm /uploads önekine uygulandığından
+	// gruptan önce kayıt edilmelidir
 	router.Get("/uploads/:id", h.GetFile)
+	router.Get("/uploads/:id/:preset/:width", h.GetDerivative)
+
+	// Yalnızca giriş yapmış kullanıcılar ve media:write kapsamlı API anahtarları yükleme yapabilir
+	uploadRoutes := router.Group("/uploads", middleware.AllowAPIKey(domain.ScopeMediaWrite), authMw)
+	uploadRoutes.Post("/", middleware.RequireVerifiedEmail(), h.UploadFile)
+	uploadRoutes.Delete("/:id", middleware.RequireVerifiedEmail(), h.DeleteFile)
+
+	// İmzalı adresle doğrudan depoya yükleme
+	uploadRoutes.Post("/direct", middleware.RequireVerifiedEmail(),
+		middleware.ValidateRequest(&domain.CreateDirectUploadRequest{}), h.CreateDirectUpload)
+	uploadRoutes.Post("/direct/:uploadId/confirm", middleware.RequireVerifiedEmail(), h.ConfirmDirectUpload)
+
+	// Kesintiye dayanıklı parçalı yükleme (tus 1.0)
+	tusRoutes := uploadRoutes.Group("/tus", tusProtocol)
+	tusRoutes.Options("/", h.TusOptions)
+	tusRoutes.Post("/", middleware.RequireVerifiedEmail(), h.TusCreate)
+	tusRoutes.Head("/:uploadId", h.TusHead)
+	tusRoutes.Patch("/:uploadId", middleware.RequireVerifiedEmail(), h.TusPatch)
+	tusRoutes.Delete("/:uploadId", h.TusDelete)
 }
 
 // UploadFile dosya yükleme işlemini gerçekleştirir
@@ -41,7 +56,7 @@ func (h *UploadHandler) RegisterRoutes(router fiber.Router, authMw fiber.Handler
 // @Security ApiKeyAuth
 // @Param file formData file true "Yüklenecek dosya"
 // @Param folder formData string false "Klasör adı (varsayılan: general)"
-// @Success 201 {object} domain.Media "Yüklenen medya bilgisi"
+// @Success 201 {object} domain.MediaResponse "Yüklenen medya bilgisi"
 // @Failure 400 {object} domain.ErrorResponse "Dosya yüklenemedi"
 // @Failure 401 {object} domain.ErrorResponse "Yetkisiz erişim"
 // @Router /uploads [post]
@@ -52,31 +67,78 @@ func (h *UploadHandler) UploadFile(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "Dosya yüklenemedi")
 	}
 
-	// Kullanıcı ID'sini al
-	userID := c.Locals("user_id").(uint)
-
 	// Yükleme klasörünü belirle (form parametresinden)
 	folder := c.FormValue("folder", "general")
 
 	// Dosyayı yükle
-	media, err := h.uploadService.UploadFile(file, folder, userID)
+	media, err := h.mediaService.UploadFile(file, folder, middleware.GetActor(c))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(h.mediaService.ToResponse(media))
+}
+
+// CreateDirectUpload doğrudan depoya yükleme adresi üretir
+// @Summary Doğrudan yükleme başlat
+// @Description Dosyanın sunucudan geçmeden depoya yüklenebileceği süreli imzalı PUT adresini ve dosyanın sınırlarını döner. Yükleme bittikten sonra onay uç noktası çağrılmalıdır; onaylanmayan dosyalar süre dolunca silinir. Yalnızca MinIO/S3 deposunda kullanılabilir
+// @Tags Medya
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param upload body domain.CreateDirectUploadRequest true "Dosya bilgileri"
+// @Success 201 {object} domain.DirectUploadResponse "Yükleme adresi"
+// @Failure 400 {object} domain.ErrorResponse "Geçersiz istek"
+// @Failure 401 {object} domain.ErrorResponse "Yetkisiz erişim"
+// @Failure 503 {object} domain.ErrorResponse "Depo doğrudan yüklemeyi desteklemiyor"
+// @Router /uploads/direct [post]
+func (h *UploadHandler) CreateDirectUpload(c *fiber.Ctx) error {
+	req := middleware.GetValidated(c).(*domain.CreateDirectUploadRequest)
+	upload, err := h.mediaService.CreateDirectUpload(req, middleware.GetActor(c))
 	if err != nil {
 		return err
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(media)
+	return c.Status(fiber.StatusCreated).JSON(upload)
+}
+
+// ConfirmDirectUpload doğrudan yüklenen dosyayı onaylar
+// @Summary Doğrudan yüklemeyi onayla
+// @Description Depoya yüklenen dosyanın boyutunu, içerikten tespit edilen türünü ve bildirildiyse SHA-256 özetini doğrular ve medya kaydını oluşturur. Reddedilen dosyalar silinir; tekrar onaylamak aynı medyayı döner
+// @Tags Medya
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uploadId path string true "Yükleme ID"
+// @Success 201 {object} domain.MediaResponse "Oluşturulan medya"
+// @Failure 400 {object} domain.ErrorResponse "Dosya yüklenmedi veya reddedildi"
+// @Failure 401 {object} domain.ErrorResponse "Yetkisiz erişim"
+// @Failure 404 {object} domain.ErrorResponse "Yükleme bulunamadı veya süresi doldu"
+// @Router /uploads/direct/{uploadId}/confirm [post]
+func (h *UploadHandler) ConfirmDirectUpload(c *fiber.Ctx) error {
+	media, err := h.mediaService.ConfirmDirectUpload(c.Params("uploadId"), middleware.GetActor(c))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(h.mediaService.ToResponse(media))
 }
 
 // GetFile dosyayı getirir
 // @Summary Dosyayı indir
-// @Description Medya ID'sine göre dosyayı getirir
+// @Description Medya ID'sine göre dosyayı depodan akış olarak getirir. Video ve ses dosyalarında ileri sarma için tek aralıklı Range istekleri desteklenir; ETag ve Last-Modified ile koşullu istekler 304 döner. Dosya içeriği aynı adreste hiç değişmediğinden süresiz önbelleklenebilir
 // @Tags Medya
 // @Accept json
 // @Produce octet-stream
 // @Param id path int true "Medya ID"
+// @Param Range header string false "Bayt aralığı (ör. bytes=0-1023)"
+// @Param If-None-Match header string false "Önbellekteki kopyanın ETag değeri"
+// @Param If-Modified-Since header string false "Önbellekteki kopyanın tarihi"
 // @Success 200 {file} file "Dosya içeriği"
+// @Success 206 {file} file "İstenen bayt aralığı"
+// @Success 304 "Önbellekteki kopya geçerli"
 // @Failure 400 {object} domain.ErrorResponse "Geçersiz medya ID"
 // @Failure 404 {object} domain.ErrorResponse "Dosya bulunamadı"
+// @Failure 416 {object} domain.ErrorResponse "İstenen aralık dosyanın dışında"
 // @Router /uploads/{id} [get]
 func (h *UploadHandler) GetFile(c *fiber.Ctx) error {
 	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
@@ -85,31 +147,71 @@ func (h *UploadHandler) GetFile(c *fiber.Ctx) error {
 	}
 
 	// Medya bilgilerini getir
-	media, err := h.uploadService.GetMedia(uint(id))
+	media, err := h.mediaService.GetMedia(uint(id))
+	if err != nil {
+		return err
+	}
+
+	content, err := h.mediaService.GetContent(media, "", 0)
+	if err != nil {
+		return err
+	}
+
+	return h.serveContent(c, content)
+}
+
+// GetDerivative görselin yeniden boyutlandırılmış türevini getirir
+// @Summary Görsel türevini indir
+// @Description Görselin belirtilen türev adı ve genişlikteki kopyasını getirir (srcset adresleri). Range ve koşullu istekler orijinal dosyadaki gibi desteklenir; türevler yeniden üretilebildiğinden bir gün önbelleklenir
+// @Tags Medya
+// @Produce octet-stream
+// @Param id path int true "Medya ID"
+// @Param preset path string true "Türev adı (ör. thumbnail, card, hero)"
+// @Param width path int true "Genişlik (piksel)"
+// @Success 200 {file} file "Türev içeriği"
+// @Success 206 {file} file "İstenen bayt aralığı"
+// @Success 304 "Önbellekteki kopya geçerli"
+// @Failure 400 {object} domain.ErrorResponse "Geçersiz medya ID veya genişlik"
+// @Failure 404 {object} domain.ErrorResponse "Türev bulunamadı"
+// @Router /uploads/{id}/{preset}/{width} [get]
+func (h *UploadHandler) GetDerivative(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Geçersiz medya ID")
+	}
+	width, err := strconv.Atoi(c.Params("width"))
+	if err != nil || width <= 0 {
+		return fiber.NewError(fiber.StatusBadRequest, "Geçersiz genişlik")
+	}
+
+	media, err := h.mediaService.GetMedia(uint(id))
 	if err != nil {
 		return err
 	}
 
-	// Dosya yolunu belirle
-	filePath := filepath.Join("./uploads", media.ObjectName)
+	content, err := h.mediaService.GetContent(media, c.Params("preset"), width)
+	if err != nil {
+		return err
+	}
 
-	// Dosyayı gönder
-	return c.SendFile(filePath)
+	return h.serveContent(c, content)
 }
 
 // DeleteFile dosyayı siler
 // @Summary Dosya sil
-// @Description Belirtilen dosyayı sistemden siler
+// @Description Belirtilen dosyayı sistemden siler. Makale veya profillerde kullanılan dosyalar yalnızca force=true ile ve tüm medyayı silme yetkisiyle silinebilir
 // @Tags Medya
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
 // @Param id path int true "Medya ID"
+// @Param force query bool false "Kullanımdaki dosyayı yine de sil"
 // @Success 204 "Başarıyla silindi"
 // @Failure 400 {object} domain.ErrorResponse "Geçersiz medya ID"
 // @Failure 401 {object} domain.ErrorResponse "Yetkisiz erişim"
 // @Failure 403 {object} domain.ErrorResponse "Bu dosyayı silme yetkiniz yok"
 // @Failure 404 {object} domain.ErrorResponse "Dosya bulunamadı"
+// @Failure 409 {object} domain.ErrorResponse "Dosya kullanımda"
 // @Router /uploads/{id} [delete]
 func (h *UploadHandler) DeleteFile(c *fiber.Ctx) error {
 	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
@@ -117,22 +219,8 @@ func (h *UploadHandler) DeleteFile(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "Geçersiz medya ID")
 	}
 
-	// Kullanıcı ID'sini al
-	userID := c.Locals("user_id").(uint)
-
-	// Medya bilgilerini getir (silmeden önce yetki kontrolü için)
-	media, err := h.uploadService.GetMedia(uint(id))
-	if err != nil {
-		return err
-	}
-
-	// Yetki kontrolü yap (admin veya dosyanın sahibi olmalı)
-	if media.UserID != userID && c.Locals("user_role") != domain.RoleAdmin {
-		return fiber.NewError(fiber.StatusForbidden, "Bu dosyayı silme yetkiniz yok")
-	}
-
-	// Dosyayı sil
-	err = h.uploadService.DeleteMedia(uint(id))
+	// Dosyayı sil (yetki kontrolü serviste yapılır)
+	err = h.mediaService.DeleteMedia(uint(id), c.QueryBool("force"), middleware.GetActor(c))
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,200 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/username/haber/internal/domain"
+	"github.com/username/haber/pkg/storage"
+)
+
+// Medya yanıtlarının önbellek başlıkları
+const (
+	// Orijinal dosyalar aynı adreste hiç değişmez
+	immutableCacheControl = "public, max-age=31536000, immutable"
+	// Türevler yeniden üretilebilir; süre dolunca ETag ile doğrulanır
+	derivativeCacheControl = "public, max-age=86400"
+)
+
+// errRangeNotSatisfiable istenen aralık dosyanın tamamen dışında kaldığında döner
+var errRangeNotSatisfiable = errors.New("aralık karşılanamıyor")
+
+// serveContent dosyayı depodan akış olarak sunar
+//
+// Tek aralıklı Range istekleri 206 ile yanıtlanır; çok aralıklı istekler
+// desteklenmez ve dosyanın tamamı döner. If-None-Match ve If-Modified-Since
+// koşulları depoya gidilmeden değerlendirilir. Dosya hiçbir zaman belleğe
+// alınmaz.
+func (h *UploadHandler) serveContent(c *fiber.Ctx, content *domain.MediaContent) error {
+	c.Set(fiber.HeaderAcceptRanges, "bytes")
+
+	if notModified(c, content) {
+		setCacheHeaders(c, content)
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	// Aralık yalnızca istemcinin elindeki kopya hâlâ geçerliyse uygulanır
+	var rng *storage.Range
+	if header := c.Get(fiber.HeaderRange); header != "" && ifRangeMatches(c.Get(fiber.HeaderIfRange), content) {
+		info, err := h.mediaService.StatContent(content)
+		if err != nil {
+			return err
+		}
+		rng, err = parseByteRange(header, info.Size)
+		if errors.Is(err, errRangeNotSatisfiable) {
+			c.Set(fiber.HeaderContentRange, fmt.Sprintf("bytes */%d", info.Size))
+			return fiber.NewError(fiber.StatusRequestedRangeNotSatisfiable, "İstenen bayt aralığı dosyanın dışında")
+		}
+	}
+
+	// HEAD isteklerinde içerik açılmaz
+	if c.Method() == fiber.MethodHead {
+		info, err := h.mediaService.StatContent(content)
+		if err != nil {
+			return err
+		}
+		setCacheHeaders(c, content)
+		c.Set(fiber.HeaderContentType, info.ContentType)
+		length := info.Size
+		if rng != nil {
+			c.Set(fiber.HeaderContentRange, contentRange(rng, info.Size))
+			c.Status(fiber.StatusPartialContent)
+			length = rng.Length
+		}
+		c.Response().SkipBody = true
+		c.Response().Header.SetContentLength(int(length))
+		return nil
+	}
+
+	// Okuyucu yanıt gönderildikten sonra kapatılır
+	reader, info, err := h.mediaService.OpenContent(content, rng)
+	if err != nil {
+		return err
+	}
+
+	setCacheHeaders(c, content)
+	c.Set(fiber.HeaderContentType, info.ContentType)
+	if rng != nil {
+		c.Set(fiber.HeaderContentRange, contentRange(rng, info.Size))
+		c.Status(fiber.StatusPartialContent)
+		return c.SendStream(reader, int(rng.Length))
+	}
+	return c.SendStream(reader, int(info.Size))
+}
+
+// setCacheHeaders başarılı ve 304 yanıtlarına doğrulayıcıları ve önbellek
+// süresini ekler; hata yanıtları önbelleklenmez
+func setCacheHeaders(c *fiber.Ctx, content *domain.MediaContent) {
+	c.Set(fiber.HeaderETag, content.ETag)
+	c.Set(fiber.HeaderLastModified, content.LastModified.UTC().Format(http.TimeFormat))
+	if content.Immutable {
+		c.Set(fiber.HeaderCacheControl, immutableCacheControl)
+	} else {
+		c.Set(fiber.HeaderCacheControl, derivativeCacheControl)
+	}
+}
+
+// notModified istemcinin önbelleğindeki kopyanın hâlâ geçerli olup olmadığını belirtir
+//
+// If-None-Match gönderilmişse If-Modified-Since yok sayılır (RFC 9110 13.2.2).
+func notModified(c *fiber.Ctx, content *domain.MediaContent) bool {
+	if header := c.Get(fiber.HeaderIfNoneMatch); header != "" {
+		for _, tag := range strings.Split(header, ",") {
+			tag = strings.TrimSpace(tag)
+			if tag == "*" || strings.TrimPrefix(tag, "W/") == content.ETag {
+				return true
+			}
+		}
+		return false
+	}
+
+	if header := c.Get(fiber.HeaderIfModifiedSince); header != "" {
+		since, err := http.ParseTime(header)
+		if err != nil {
+			return false
+		}
+		return !content.LastModified.Truncate(time.Second).After(since)
+	}
+
+	return false
+}
+
+// ifRangeMatches If-Range koşulunun sağlanıp sağlanmadığını belirtir; başlık
+// yoksa koşul sağlanmış sayılır
+//
+// ETag karşılaştırması güçlüdür; tarih ise Last-Modified ile birebir eşleşmelidir.
+func ifRangeMatches(header string, content *domain.MediaContent) bool {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return true
+	}
+	if strings.HasPrefix(header, `"`) || strings.HasPrefix(header, "W/") {
+		return header == content.ETag
+	}
+
+	date, err := http.ParseTime(header)
+	if err != nil {
+		return false
+	}
+	return content.LastModified.Truncate(time.Second).Equal(date)
+}
+
+// parseByteRange "bytes=başlangıç-bitiş" biçimindeki Range başlığını çözer
+//
+// Geçersiz veya çok aralıklı başlıklar yok sayılır (nil döner) ve dosyanın
+// tamamı sunulur. Aralık dosyanın tamamen dışındaysa errRangeNotSatisfiable döner.
+func parseByteRange(header string, size int64) (*storage.Range, error) {
+	spec, ok := strings.CutPrefix(strings.TrimSpace(header), "bytes=")
+	if !ok || strings.Contains(spec, ",") {
+		return nil, nil
+	}
+	first, last, ok := strings.Cut(strings.TrimSpace(spec), "-")
+	if !ok {
+		return nil, nil
+	}
+
+	// Son n bayt (bytes=-500)
+	if first == "" {
+		n, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || n < 0 {
+			return nil, nil
+		}
+		if n == 0 || size == 0 {
+			return nil, errRangeNotSatisfiable
+		}
+		if n > size {
+			n = size
+		}
+		return &storage.Range{Offset: size - n, Length: n}, nil
+	}
+
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil || start < 0 {
+		return nil, nil
+	}
+	end := size - 1
+	if last != "" {
+		end, err = strconv.ParseInt(last, 10, 64)
+		if err != nil || end < start {
+			return nil, nil
+		}
+		if end > size-1 {
+			end = size - 1
+		}
+	}
+	if start >= size {
+		return nil, errRangeNotSatisfiable
+	}
+
+	return &storage.Range{Offset: start, Length: end - start + 1}, nil
+}
+
+// contentRange 206 yanıtlarının Content-Range başlığını üretir
+func contentRange(rng *storage.Range, size int64) string {
+	return fmt.Sprintf("bytes %d-%d/%d", rng.Offset, rng.Offset+rng.Length-1, size)
+}
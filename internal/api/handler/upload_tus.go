@@ -0,0 +1,208 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/base64"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/username/haber/internal/api/middleware"
+	"github.com/username/haber/internal/domain"
+)
+
+// tus 1.0 protokol sabitleri
+const (
+	tusVersion       = "1.0.0"
+	tusExtensions    = "creation,expiration,termination"
+	tusContentType   = "application/offset+octet-stream"
+	tusMediaIDHeader = "Upload-Media-Id" // Tamamlanan yüklemenin medya ID'si
+)
+
+// tusProtocol tus sürüm başlığını yanıta ekler ve istemcinin sürümünü doğrular
+//
+// OPTIONS istekleri sürüm başlığı olmadan da yanıtlanır.
+func tusProtocol(c *fiber.Ctx) error {
+	c.Set("Tus-Resumable", tusVersion)
+	if c.Method() == fiber.MethodOptions {
+		return c.Next()
+	}
+	if c.Get("Tus-Resumable") != tusVersion {
+		c.Set("Tus-Version", tusVersion)
+		return fiber.NewError(fiber.StatusPreconditionFailed, "Desteklenmeyen tus sürümü")
+	}
+	return c.Next()
+}
+
+// TusOptions sunucunun desteklediği tus özelliklerini bildirir
+// @Summary Parçalı yükleme yetenekleri
+// @Description tus 1.0 sürüm, uzantı ve en büyük dosya boyutu bilgilerini başlıklarda döner
+// @Tags Medya
+// @Security ApiKeyAuth
+// @Success 204 "Tus-Version, Tus-Extension ve Tus-Max-Size başlıkları"
+// @Router /uploads/tus [options]
+func (h *UploadHandler) TusOptions(c *fiber.Ctx) error {
+	c.Set("Tus-Version", tusVersion)
+	c.Set("Tus-Extension", tusExtensions)
+	if size := h.mediaService.MaxUploadSize(); size > 0 {
+		c.Set("Tus-Max-Size", strconv.FormatInt(size, 10))
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// TusCreate yeni bir parçalı yükleme oluşturur
+// @Summary Parçalı yükleme başlat
+// @Description tus 1.0 creation uzantısı. Dosya adı ve klasör Upload-Metadata başlığında (filename, folder) gönderilir
+// @Tags Medya
+// @Security ApiKeyAuth
+// @Param Tus-Resumable header string true "1.0.0"
+// @Param Upload-Length header int true "Dosya boyutu (bayt)"
+// @Param Upload-Metadata header string false "filename <base64>,folder <base64>"
+// @Success 201 "Location başlığında yükleme adresi"
+// @Failure 400 {object} domain.ErrorResponse "Geçersiz istek"
+// @Failure 401 {object} domain.ErrorResponse "Yetkisiz erişim"
+// @Failure 412 {object} domain.ErrorResponse "Desteklenmeyen tus sürümü"
+// @Failure 413 {object} domain.ErrorResponse "Dosya çok büyük"
+// @Router /uploads/tus [post]
+func (h *UploadHandler) TusCreate(c *fiber.Ctx) error {
+	if c.Get("Upload-Defer-Length") != "" {
+		return fiber.NewError(fiber.StatusBadRequest, "Boyutu sonradan bildirilen yüklemeler desteklenmiyor")
+	}
+	length, err := strconv.ParseInt(c.Get("Upload-Length"), 10, 64)
+	if err != nil || length < 0 {
+		return fiber.NewError(fiber.StatusBadRequest, "Geçersiz Upload-Length")
+	}
+	if size := h.mediaService.MaxUploadSize(); size > 0 && length > size {
+		return fiber.NewError(fiber.StatusRequestEntityTooLarge, "Dosya çok büyük")
+	}
+
+	metadata, err := parseTusMetadata(c.Get("Upload-Metadata"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Geçersiz Upload-Metadata")
+	}
+	filename := metadata["filename"]
+	if filename == "" {
+		filename = metadata["name"]
+	}
+
+	upload, err := h.mediaService.CreateResumableUpload(length, filename, metadata["folder"], middleware.GetActor(c))
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderLocation, c.BaseURL()+strings.TrimSuffix(c.Path(), "/")+"/"+upload.ID)
+	c.Set("Upload-Expires", upload.ExpiresAt.UTC().Format(http.TimeFormat))
+	return c.SendStatus(fiber.StatusCreated)
+}
+
+// TusHead parçalı yüklemenin durumunu döner
+// @Summary Parçalı yükleme durumu
+// @Description Sunucuya ulaşan bayt sayısını Upload-Offset başlığında döner; tamamlanan yüklemelerde medya ID'si Upload-Media-Id başlığındadır
+// @Tags Medya
+// @Security ApiKeyAuth
+// @Param Tus-Resumable header string true "1.0.0"
+// @Param uploadId path string true "Yükleme ID"
+// @Success 200 "Upload-Offset ve Upload-Length başlıkları"
+// @Failure 404 {object} domain.ErrorResponse "Yükleme bulunamadı veya süresi doldu"
+// @Router /uploads/tus/{uploadId} [head]
+func (h *UploadHandler) TusHead(c *fiber.Ctx) error {
+	upload, err := h.mediaService.GetResumableUpload(c.Params("uploadId"), middleware.GetActor(c))
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	setTusUploadHeaders(c, upload)
+	c.Set("Upload-Length", strconv.FormatInt(upload.Length, 10))
+	return c.SendStatus(fiber.StatusOK)
+}
+
+// TusPatch parçalı yüklemeye bir parça ekler
+// @Summary Parça yükle
+// @Description Upload-Offset konumundan itibaren bir parça ekler. Son parçada dosya doğrulanıp medya kaydı oluşturulur ve ID'si Upload-Media-Id başlığında döner
+// @Tags Medya
+// @Accept application/offset+octet-stream
+// @Security ApiKeyAuth
+// @Param Tus-Resumable header string true "1.0.0"
+// @Param Upload-Offset header int true "Parçanın başladığı konum"
+// @Param uploadId path string true "Yükleme ID"
+// @Success 204 "Yeni Upload-Offset başlığı"
+// @Failure 400 {object} domain.ErrorResponse "Geçersiz istek veya dosya reddedildi"
+// @Failure 404 {object} domain.ErrorResponse "Yükleme bulunamadı veya süresi doldu"
+// @Failure 409 {object} domain.ErrorResponse "Konum uyuşmuyor"
+// @Failure 415 {object} domain.ErrorResponse "Geçersiz içerik türü"
+// @Router /uploads/tus/{uploadId} [patch]
+func (h *UploadHandler) TusPatch(c *fiber.Ctx) error {
+	if c.Get(fiber.HeaderContentType) != tusContentType {
+		return fiber.NewError(fiber.StatusUnsupportedMediaType, "İçerik türü "+tusContentType+" olmalıdır")
+	}
+	offset, err := strconv.ParseInt(c.Get("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		return fiber.NewError(fiber.StatusBadRequest, "Geçersiz Upload-Offset")
+	}
+
+	body := c.Body()
+	upload, _, err := h.mediaService.AppendResumableUpload(
+		c.Params("uploadId"), offset, bytes.NewReader(body), int64(len(body)), middleware.GetActor(c))
+	if err != nil {
+		return err
+	}
+
+	setTusUploadHeaders(c, upload)
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// TusDelete parçalı yüklemeyi iptal eder
+// @Summary Parçalı yüklemeyi iptal et
+// @Description tus 1.0 termination uzantısı; yüklenen parçalar silinir
+// @Tags Medya
+// @Security ApiKeyAuth
+// @Param Tus-Resumable header string true "1.0.0"
+// @Param uploadId path string true "Yükleme ID"
+// @Success 204 "Yükleme silindi"
+// @Failure 404 {object} domain.ErrorResponse "Yükleme bulunamadı veya süresi doldu"
+// @Router /uploads/tus/{uploadId} [delete]
+func (h *UploadHandler) TusDelete(c *fiber.Ctx) error {
+	if err := h.mediaService.DeleteResumableUpload(c.Params("uploadId"), middleware.GetActor(c)); err != nil {
+		return err
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// setTusUploadHeaders yüklemenin konum, son kullanma ve medya başlıklarını ekler
+func setTusUploadHeaders(c *fiber.Ctx, upload *domain.ResumableUpload) {
+	c.Set("Upload-Offset", strconv.FormatInt(upload.Offset, 10))
+	c.Set("Upload-Expires", upload.ExpiresAt.UTC().Format(http.TimeFormat))
+	if upload.MediaID != nil {
+		c.Set(tusMediaIDHeader, strconv.FormatUint(uint64(*upload.MediaID), 10))
+	}
+}
+
+// parseTusMetadata "anahtar base64değer" çiftlerinden oluşan Upload-Metadata
+// başlığını çözer; değeri olmayan anahtarlar boş metin olarak döner
+func parseTusMetadata(header string) (map[string]string, error) {
+	metadata := make(map[string]string)
+	if strings.TrimSpace(header) == "" {
+		return metadata, nil
+	}
+
+	for _, pair := range strings.Split(header, ",") {
+		parts := strings.Fields(pair)
+		switch len(parts) {
+		case 0:
+			continue
+		case 1:
+			metadata[parts[0]] = ""
+		case 2:
+			value, err := base64.StdEncoding.DecodeString(parts[1])
+			if err != nil {
+				return nil, err
+			}
+			metadata[parts[0]] = string(value)
+		default:
+			return nil, fiber.ErrBadRequest
+		}
+	}
+	return metadata, nil
+}
@@ -2,23 +2,28 @@ package handler
 
 import (
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/username/haber/internal/api/middleware"
 	"github.com/username/haber/internal/domain"
 	"github.com/username/haber/internal/service"
-	"github.com/username/haber/pkg/auth"
 )
 
 // UserHandler kullanıcı işleyicileri
 type UserHandler struct {
-	userService service.IUserService
+	userService       service.IUserService
+	authService       service.IAuthService
+	requirePermission middleware.PermissionGuard
 }
 
 // NewUserHandler yeni bir UserHandler oluşturur
-func NewUserHandler(userService service.IUserService) *UserHandler {
+func NewUserHandler(userService service.IUserService, authService service.IAuthService, requirePermission middleware.PermissionGuard) *UserHandler {
 	return &UserHandler{
-		userService: userService,
+		userService:       userService,
+		authService:       authService,
+		requirePermission: requirePermission,
 	}
 }
 
@@ -27,15 +32,15 @@ func (h *UserHandler) RegisterRoutes(router fiber.Router, authMw fiber.Handler,
 	// Kimlik doğrulama gerektiren rotalar
 	protectedRoutes := router.Group("/users", authMw)
 	protectedRoutes.Get("/me", h.GetCurrentUser)
-	protectedRoutes.Put("/me", h.UpdateCurrentUser)
+	protectedRoutes.Put("/me", middleware.ValidateRequest(&domain.UpdateUserRequest{}), h.UpdateCurrentUser)
 	protectedRoutes.Put("/me/password", h.UpdatePassword)
 
-	// Sadece admin rotaları
-	adminRoutes := router.Group("/admin/users", adminMw)
+	// Kullanıcı yönetimi rotaları
+	adminRoutes := router.Group("/admin/users", h.requirePermission(domain.PermUserManage))
 	adminRoutes.Get("/", h.ListUsers)
 	adminRoutes.Get("/:id", h.GetUser)
 	adminRoutes.Post("/", h.CreateUser)
-	adminRoutes.Put("/:id", h.UpdateUser)
+	adminRoutes.Put("/:id", middleware.ValidateRequest(&domain.UpdateUserRequest{}), h.UpdateUser)
 	adminRoutes.Delete("/:id", h.DeleteUser)
 }
 
@@ -61,23 +66,19 @@ func (h *UserHandler) GetCurrentUser(c *fiber.Ctx) error {
 
 // UpdateCurrentUser mevcut kullanıcı bilgilerini günceller
 // @Summary Kullanıcı profilini güncelle
-// @Description Mevcut kullanıcı bilgilerini günceller
+// @Description Mevcut kullanıcı bilgilerini ve yazar sayfasında görünen profil alanlarını (ad, biyografi, profil resmi) günceller. E-posta adresi değişirse hesap yeniden doğrulama bekler, açık oturumlar sonlandırılır ve yanıtta yeni token'lar döner.
 // @Tags Kullanıcılar
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
 // @Param user body domain.UpdateUserRequest true "Güncellenecek kullanıcı bilgileri"
-// @Success 200 {object} domain.User "Güncellenmiş kullanıcı bilgileri"
+// @Success 200 {object} domain.ProfileUpdateResponse "Güncellenmiş kullanıcı bilgileri"
 // @Failure 400 {object} domain.ErrorResponse "Geçersiz istek formatı"
 // @Failure 401 {object} domain.ErrorResponse "Yetkisiz erişim"
 // @Router /users/me [put]
 func (h *UserHandler) UpdateCurrentUser(c *fiber.Ctx) error {
 	userID := c.Locals("user_id").(uint)
-
-	var req domain.UpdateUserRequest
-	if err := c.BodyParser(&req); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "Geçersiz istek formatı")
-	}
+	req := middleware.GetValidated(c).(*domain.UpdateUserRequest)
 
 	// Mevcut kullanıcıyı getir
 	user, err := h.userService.GetUserByID(userID)
@@ -89,8 +90,12 @@ func (h *UserHandler) UpdateCurrentUser(c *fiber.Ctx) error {
 	if req.Username != "" {
 		user.Username = req.Username
 	}
-	if req.Email != "" {
+	// Yeni adres doğrulanana kadar hesap doğrulanmamış sayılır
+	emailChanged := req.Email != "" && !strings.EqualFold(req.Email, user.Email)
+	if emailChanged {
 		user.Email = req.Email
+		user.Status = domain.UserStatusUnverified
+		user.EmailVerifiedAt = nil
 	}
 	if req.FullName != "" {
 		user.FullName = req.FullName
@@ -98,18 +103,36 @@ func (h *UserHandler) UpdateCurrentUser(c *fiber.Ctx) error {
 	if req.ProfileImage != "" {
 		user.ProfileImage = req.ProfileImage
 	}
-	// Sadece admin rolü değiştirebilir
-	if req.Role != "" && c.Locals("user_role") == domain.RoleAdmin {
+	if req.Bio != nil {
+		user.Bio = strings.TrimSpace(*req.Bio)
+	}
+	// Rol değişikliği user.manage yetkisi gerektirir
+	if req.Role != "" && req.Role != user.Role {
+		if err := h.userService.ChangeRole(middleware.GetActor(c), userID, req.Role); err != nil {
+			return err
+		}
 		user.Role = req.Role
 	}
 	user.UpdatedAt = time.Now()
 
-	err = h.userService.UpdateUser(user)
+	err = h.userService.UpdateUser(user, middleware.GetActor(c))
 	if err != nil {
 		return err
 	}
 
-	return c.JSON(user)
+	response := &domain.ProfileUpdateResponse{User: user}
+	if emailChanged {
+		if err := h.authService.RestartEmailVerification(user, middleware.GetActor(c)); err != nil {
+			return err
+		}
+		// Token'lardaki hesap durumu eskidiği için yenileri verilir
+		response.Tokens, err = h.authService.IssueTokens(user, clientInfo(c), false)
+		if err != nil {
+			return err
+		}
+	}
+
+	return c.JSON(response)
 }
 
 // UpdatePassword kullanıcı şifresini günceller
@@ -256,17 +279,12 @@ func (h *UserHandler) CreateUser(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "Şifreler eşleşmiyor")
 	}
 
-	// Yeni kullanıcı objesi oluştur
-	hashedPassword, err := auth.HashPassword(req.Password)
-	if err != nil {
-		return err
-	}
-
+	// Yeni kullanıcı objesi oluştur; şifre servis tarafından hashlenir
 	now := time.Now()
 	user := &domain.User{
 		Username:     req.Username,
 		Email:        req.Email,
-		PasswordHash: hashedPassword,
+		PasswordHash: req.Password,
 		FullName:     req.FullName,
 		Role:         req.Role,
 		CreatedAt:    now,
@@ -274,8 +292,7 @@ func (h *UserHandler) CreateUser(c *fiber.Ctx) error {
 	}
 
 	// Kullanıcıyı oluştur
-	err = h.userService.CreateUser(user)
-	if err != nil {
+	if err := h.userService.CreateUser(user, middleware.GetActor(c)); err != nil {
 		return err
 	}
 
@@ -289,10 +306,7 @@ func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "Geçersiz kullanıcı ID")
 	}
 
-	var req domain.UpdateUserRequest
-	if err := c.BodyParser(&req); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "Geçersiz istek formatı")
-	}
+	req := middleware.GetValidated(c).(*domain.UpdateUserRequest)
 
 	// Mevcut kullanıcıyı getir
 	user, err := h.userService.GetUserByID(uint(id))
@@ -304,8 +318,12 @@ func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 	if req.Username != "" {
 		user.Username = req.Username
 	}
-	if req.Email != "" {
+	// Yeni adres doğrulanana kadar hesap doğrulanmamış sayılır
+	emailChanged := req.Email != "" && !strings.EqualFold(req.Email, user.Email)
+	if emailChanged {
 		user.Email = req.Email
+		user.Status = domain.UserStatusUnverified
+		user.EmailVerifiedAt = nil
 	}
 	if req.FullName != "" {
 		user.FullName = req.FullName
@@ -313,16 +331,30 @@ func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 	if req.ProfileImage != "" {
 		user.ProfileImage = req.ProfileImage
 	}
-	if req.Role != "" {
+	if req.Bio != nil {
+		user.Bio = strings.TrimSpace(*req.Bio)
+	}
+	// Rol değişikliği ayrı denetim kaydı tutulması ve oturumların kapanması için ChangeRole ile yapılır
+	if req.Role != "" && req.Role != user.Role {
+		if err := h.userService.ChangeRole(middleware.GetActor(c), user.ID, req.Role); err != nil {
+			return err
+		}
 		user.Role = req.Role
 	}
 	user.UpdatedAt = time.Now()
 
-	err = h.userService.UpdateUser(user)
+	err = h.userService.UpdateUser(user, middleware.GetActor(c))
 	if err != nil {
 		return err
 	}
 
+	// Kullanıcının eski adresle aldığı oturumlar kapanır, yeni adrese bağlantı gider
+	if emailChanged {
+		if err := h.authService.RestartEmailVerification(user, middleware.GetActor(c)); err != nil {
+			return err
+		}
+	}
+
 	return c.JSON(user)
 }
 
@@ -333,7 +365,7 @@ func (h *UserHandler) DeleteUser(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "Geçersiz kullanıcı ID")
 	}
 
-	err = h.userService.DeleteUser(uint(id))
+	err = h.userService.DeleteUser(uint(id), middleware.GetActor(c))
 	if err != nil {
 		return err
 	}
@@ -1,21 +1,42 @@
 package middleware
 
 import (
+	"errors"
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/username/haber/internal/domain"
 	"github.com/username/haber/pkg/auth"
 )
 
+// APIKeyHeader makine istemcilerinin API anahtarını gönderdiği header
+const APIKeyHeader = "X-API-Key"
+
+// APIKeyAuthenticator API anahtarlarını doğrulayan servis arayüzü
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(rawKey, clientIP string) (*domain.APIKey, error)
+}
+
+// PermissionChecker rol yetkilerini kontrol eden servis arayüzü
+type PermissionChecker interface {
+	HasPermission(actor *domain.Actor, permission string) (bool, error)
+}
+
 // AuthMiddleware kimlik doğrulama işlemlerini yönetir
 type AuthMiddleware struct {
-	jwtAuth *auth.JWTAuth
+	jwtAuth     *auth.JWTAuth
+	revocations auth.RevocationStore
+	apiKeys     APIKeyAuthenticator
+	permissions PermissionChecker
 }
 
 // NewAuthMiddleware yeni bir AuthMiddleware oluşturur
-func NewAuthMiddleware(jwtAuth *auth.JWTAuth) *AuthMiddleware {
+func NewAuthMiddleware(jwtAuth *auth.JWTAuth, revocations auth.RevocationStore, apiKeys APIKeyAuthenticator, permissions PermissionChecker) *AuthMiddleware {
 	return &AuthMiddleware{
-		jwtAuth: jwtAuth,
+		jwtAuth:     jwtAuth,
+		revocations: revocations,
+		apiKeys:     apiKeys,
+		permissions: permissions,
 	}
 }
 
@@ -27,27 +48,9 @@ func (m *AuthMiddleware) Authenticate(c *fiber.Ctx) error {
 // Protected kimlik doğrulama gerektiren istekleri korur
 func (m *AuthMiddleware) Protected() fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		// Token'ı al
-		token := m.extractToken(c)
-		if token == "" {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "Kimlik doğrulama token'ı gereklidir",
-			})
-		}
-
-		// Token'ı doğrula
-		user, err := m.jwtAuth.ValidateToken(token)
-		if err != nil {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "Geçersiz veya süresi dolmuş token",
-			})
+		if ok, err := m.authenticate(c); !ok {
+			return err
 		}
-
-		// Kullanıcıyı context'e ekle
-		c.Locals("user", user)
-		c.Locals("user_id", user.ID)
-		c.Locals("user_role", user.Role)
-
 		return c.Next()
 	}
 }
@@ -56,13 +59,12 @@ func (m *AuthMiddleware) Protected() fiber.Handler {
 func (m *AuthMiddleware) RequireRole(roles ...string) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		// Önce kullanıcının giriş yapıp yapmadığını kontrol et
-		err := m.Protected()(c)
-		if err != nil {
+		if ok, err := m.authenticate(c); !ok {
 			return err
 		}
 
-		// Kullanıcının rolünü al
-		userRole := c.Locals("user_role").(string)
+		// Kullanıcının rolünü al; API anahtarlarının rolü yoktur
+		userRole, _ := c.Locals("user_role").(string)
 
 		// Rolü kontrol et
 		for _, role := range roles {
@@ -78,6 +80,204 @@ func (m *AuthMiddleware) RequireRole(roles ...string) fiber.Handler {
 	}
 }
 
+// PermissionGuard verilen yetkiyi gerektiren middleware üretir
+//
+// Yönetim rotaları rol yerine yetkiyle korunur; handler'lara
+// AuthMiddleware.RequirePermission verilir.
+type PermissionGuard func(permission string) fiber.Handler
+
+// RequirePermission kullanıcının rolünün verilen yetkiye sahip olmasını gerektirir
+//
+// Yetkiler veritabanındaki rol tanımlarından okunur. Sahiplik kuralları
+// (ör. yalnızca kendi taslağını düzenleme) servislerde uygulanır.
+func (m *AuthMiddleware) RequirePermission(permission string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if ok, err := m.authenticate(c); !ok {
+			return err
+		}
+
+		allowed := false
+		if m.permissions != nil {
+			var err error
+			allowed, err = m.permissions.HasPermission(GetActor(c), permission)
+			if err != nil {
+				return err
+			}
+		}
+
+		if !allowed {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": "Bu işlem için yetkiniz bulunmuyor",
+			})
+		}
+
+		return c.Next()
+	}
+}
+
+// authenticate isteği doğrular ve kimlik bilgilerini context'e ekler
+//
+// Doğrulama başarısız olursa hata yanıtı yazılır ve false döner.
+func (m *AuthMiddleware) authenticate(c *fiber.Ctx) (bool, error) {
+	// Makine istemcileri JWT yerine API anahtarı gönderir
+	if rawKey := c.Get(APIKeyHeader); rawKey != "" && m.apiKeys != nil {
+		scope, allowed := c.Locals("api_key_scope").(string)
+		if !allowed {
+			return false, c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": "Bu işlem API anahtarıyla yapılamaz",
+			})
+		}
+		return m.authenticateAPIKey(c, rawKey, scope)
+	}
+
+	// Token'ı al
+	token := m.extractToken(c)
+	if token == "" {
+		return false, c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "Kimlik doğrulama token'ı gereklidir",
+		})
+	}
+
+	// Token'ı doğrula
+	claims, err := m.jwtAuth.ParseAccessToken(token)
+	if err != nil {
+		return false, c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "Geçersiz veya süresi dolmuş token",
+		})
+	}
+
+	// İptal edilmiş token'ları reddet
+	if m.revocations != nil {
+		revoked, err := auth.IsClaimsRevoked(m.revocations, claims)
+		if err != nil {
+			return false, err
+		}
+		if revoked {
+			return false, c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": "Token iptal edilmiş",
+			})
+		}
+	}
+
+	// Kullanıcıyı context'e ekle
+	user := claims.User()
+	c.Locals("user", user)
+	c.Locals("user_id", user.ID)
+	c.Locals("user_role", user.Role)
+	c.Locals("user_status", user.Status)
+	c.Locals("token_claims", claims)
+
+	return true, nil
+}
+
+// authenticateAPIKey API anahtarını doğrular ve kapsamlarını context'e ekler
+//
+// Anahtarla yapılan işlemler anahtarı oluşturan kullanıcı adına kaydedilir;
+// anahtarın rolü yoktur, bu yüzden RequireRole ile korunan rotalara giremez.
+func (m *AuthMiddleware) authenticateAPIKey(c *fiber.Ctx, rawKey, scope string) (bool, error) {
+	key, err := m.apiKeys.AuthenticateAPIKey(rawKey, c.IP())
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrUnauthorized):
+			return false, c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case errors.Is(err, domain.ErrForbidden):
+			return false, c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return false, err
+	}
+
+	if !key.HasScope(scope) {
+		return false, insufficientScope(c, scope)
+	}
+
+	c.Locals("api_key", key)
+	c.Locals("api_scopes", key.Scopes)
+	c.Locals("user_id", key.CreatedByID)
+
+	return true, nil
+}
+
+// RequireVerifiedEmail e-posta adresini doğrulamamış kullanıcıların isteklerini engeller
+//
+// Protected ile korunan rotalarda, yorum ve yükleme gibi yazma işlemlerinin
+// önüne eklenir. Durum bilgisi token'dan okunur; doğrulamadan sonra token
+// yenilendiğinde kısıtlama kalkar.
+func RequireVerifiedEmail() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if status, _ := c.Locals("user_status").(string); status == domain.UserStatusUnverified {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": "Bu işlem için e-posta adresinizi doğrulamanız gerekiyor",
+				"code":  "EMAIL_NOT_VERIFIED",
+			})
+		}
+
+		return c.Next()
+	}
+}
+
+// AllowAPIKey rotanın verilen kapsama sahip API anahtarlarıyla çağrılabilmesini sağlar
+//
+// Protected varsayılan olarak API anahtarlarını reddeder; bu middleware
+// kimlik doğrulamadan önce eklenmelidir.
+func AllowAPIKey(scope string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Locals("api_key_scope", scope)
+		return c.Next()
+	}
+}
+
+// insufficientScope kapsamı yetersiz API anahtarı yanıtı
+func insufficientScope(c *fiber.Ctx, scope string) error {
+	return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+		"error": "API anahtarının bu işlem için yetkisi yok: " + scope,
+		"code":  "INSUFFICIENT_SCOPE",
+	})
+}
+
+// GetActor isteği yapan kimliği servislerin yetki kontrolü için döndürür
+//
+// API anahtarıyla yapılan isteklerde anahtarı oluşturan kullanıcının rolü
+// kullanılır; kapsam kontrolü kimlik doğrulama sırasında yapılmıştır.
+// Kimlik doğrulanmamışsa nil döner.
+func GetActor(c *fiber.Ctx) *domain.Actor {
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		return nil
+	}
+
+	actor := &domain.Actor{
+		UserID:    userID,
+		IP:        c.IP(),
+		RequestID: GetRequestID(c),
+	}
+	if key := GetAPIKey(c); key != nil {
+		actor.APIKeyID = key.ID
+		if key.CreatedBy != nil {
+			actor.Role = key.CreatedBy.Role
+		}
+		return actor
+	}
+
+	actor.Role, _ = c.Locals("user_role").(string)
+	return actor
+}
+
+// GetAPIKey istek API anahtarıyla yapıldıysa anahtarı döndürür
+func GetAPIKey(c *fiber.Ctx) *domain.APIKey {
+	key, _ := c.Locals("api_key").(*domain.APIKey)
+	return key
+}
+
+// HasScope isteğin verilen kapsama sahip bir API anahtarıyla yapılıp yapılmadığını döndürür
+func HasScope(c *fiber.Ctx, scope string) bool {
+	key := GetAPIKey(c)
+	return key != nil && key.HasScope(scope)
+}
+
 // extractToken istek header'ından token'ı çıkarır
 func (m *AuthMiddleware) extractToken(c *fiber.Ctx) string {
 	// Authorization header'ını al
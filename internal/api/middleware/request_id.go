@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"github.com/gofiber/fiber/v2/utils"
+)
+
+// requestIDMaxLength istemcinin gönderdiği istek kimliğinin kabul edilen en fazla uzunluğu
+const requestIDMaxLength = 64
+
+// NewRequestIDMiddleware her isteğe X-Request-ID başlığıyla bir kimlik atar
+//
+// İstemci ya da önündeki vekil sunucu kimlik gönderdiyse o kullanılır; kayıtlar
+// bu sayede servisler arasında eşleştirilebilir.
+func NewRequestIDMiddleware() fiber.Handler {
+	return requestid.New(requestid.Config{
+		Header:     fiber.HeaderXRequestID,
+		Generator:  utils.UUIDv4,
+		ContextKey: "request_id",
+	})
+}
+
+// GetRequestID isteğin kimliğini döndürür
+func GetRequestID(c *fiber.Ctx) string {
+	id, ok := c.Locals("request_id").(string)
+	if !ok {
+		id = c.Get(fiber.HeaderXRequestID)
+	}
+	if len(id) > requestIDMaxLength {
+		id = id[:requestIDMaxLength]
+	}
+	return id
+}
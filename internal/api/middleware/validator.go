@@ -38,6 +38,11 @@ type ValidationError struct {
 	Message string `json:"message"`
 }
 
+// Error hata mesajını döndürür
+func (e ValidationError) Error() string {
+	return e.Message
+}
+
 // getValidator validasyon instance'ını döndürür
 func getValidator() *validator.Validate {
 	validate := validator.New()
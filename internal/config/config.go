@@ -13,7 +13,11 @@ type Config struct {
 	Redis       RedisConfig
 	JWT         JWTConfig
 	MinIO       MinIOConfig
+	Storage     StorageConfig
+	Image       ImageConfig
+	Upload      UploadConfig
 	RateLimiter RateLimiterConfig
+	OIDC        OIDCConfig
 }
 
 // ServerConfig sunucu ayarları
@@ -22,7 +26,7 @@ type ServerConfig struct {
 	TemplateDir  string
 	StaticDir    string
 	UploadsDir   string
-	MaxUploadMB  int
+	MaxUploadMB  int // Tek bir yüklemenin üst sınırı; tür sınırları bunu aşamaz
 	Environment  string
 	AllowOrigins string
 }
@@ -50,6 +54,16 @@ type JWTConfig struct {
 	Secret          string
 	AccessTokenExp  int // dakika cinsinden
 	RefreshTokenExp int // saat cinsinden
+	// "Beni hatırla" ile yapılan girişlerde yenileme token'ı ömrü (saat cinsinden)
+	RememberRefreshTokenExp int
+	// İmza algoritması: HS256, RS256 veya EdDSA
+	Algorithm string
+	// Asimetrik imza anahtarlarının saklandığı dizin
+	KeysDir string
+	// İmza anahtarının döndürülme aralığı (saat cinsinden, 0 ise döndürülmez)
+	KeyRotationHours int
+	// Asimetrik moda geçişte eski HS256 token'larının kabul edilmesi
+	AcceptLegacyHS256 bool
 }
 
 // MinIOConfig MinIO nesne depolama ayarları
@@ -62,6 +76,74 @@ type MinIOConfig struct {
 	Location        string
 }
 
+// StorageConfig dosya depolama ayarları
+type StorageConfig struct {
+	// Medya dosyalarının tutulacağı sürücü: local veya minio (S3 uyumlu)
+	Driver string
+	// Dosyaların doğrudan sunulduğu kök adres (CDN veya herkese açık bucket).
+	// Boşsa local sürücüde dosyalar API üzerinden, minio sürücüsünde
+	// süreli imzalı adreslerle sunulur.
+	PublicURL string
+	// İmzalı indirme adreslerinin geçerlilik süresi (dakika cinsinden)
+	URLExpiryMinutes int
+	// Sahipsiz dosya taramasının kaç saatte bir çalışacağı; 0 taramayı kapatır
+	GCIntervalHours int
+	// true ise tarama sahipsiz dosyaları ve dosyası kaybolmuş kayıtları siler,
+	// false ise yalnızca raporlar
+	GCDelete bool
+	// Bu süreden (saat) yeni dosyalar sahipsiz sayılmaz; süren yüklemeleri korur
+	GCGraceHours int
+}
+
+// ImageConfig görsel türevi ayarları
+type ImageConfig struct {
+	// Yüklenen görsellerden üretilecek türevler: "ad:genişlik,genişlik;..."
+	// (ör. "thumbnail:150,300;card:480,960;hero:1280,1920"); boşsa türev üretilmez
+	Derivatives string
+	// JPEG türevlerinin kodlama kalitesi (1-100)
+	JPEGQuality int
+}
+
+// UploadConfig dosya türlerine göre yükleme sınırları
+type UploadConfig struct {
+	MaxImageMB    int // JPEG, PNG, GIF
+	MaxDocumentMB int // PDF, Word, Excel
+	MaxArchiveMB  int // ZIP
+	// Görsellerin en fazla piksel sayısı (milyon piksel); sıkıştırılmış
+	// boyutu küçük ama bellekte çok yer kaplayan görselleri engeller
+	MaxImageMegapixels int
+	// Yarıda kalan parçalı (tus) yüklemelerin son işlemden sonra saklanma süresi (saat)
+	ResumableExpiryHours int
+	// Doğrudan depoya yükleme adreslerinin geçerlilik süresi (dakika); bu
+	// sürede onaylanmayan dosyalar silinir
+	DirectExpiryMinutes int
+	// Toplu içe aktarılan ZIP arşivlerinin en fazla dosya sayısı ve açılmış
+	// toplam boyutu (MB)
+	ImportMaxEntries int
+	ImportMaxTotalMB int
+}
+
+// OIDCConfig OpenID Connect tek oturum açma ayarları
+type OIDCConfig struct {
+	Enabled      bool
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	// Boşlukla ayrılmış ek kapsamlar ("openid" her zaman istenir)
+	Scopes string
+	// Grup listesini taşıyan claim adı
+	GroupsClaim string
+	// "grup=rol" çiftleri, virgülle ayrılır; önce yazılan eşleşme geçerlidir
+	RoleMapping string
+	// Hiçbir gruba uymayan yeni kullanıcılara verilecek rol
+	DefaultRole string
+	// Sistemde bulunmayan kullanıcılar ilk girişte oluşturulsun mu
+	AutoProvision bool
+	// Virgülle ayrılmış izinli e-posta alan adları (boşsa hepsi)
+	AllowedDomains string
+}
+
 // RateLimiterConfig Rate Limiter ayarları
 type RateLimiterConfig struct {
 	Enabled        bool
@@ -78,7 +160,7 @@ func LoadConfig() *Config {
 			TemplateDir:  getEnv("TEMPLATE_DIR", "./web/templates"),
 			StaticDir:    getEnv("STATIC_DIR", "./web/static"),
 			UploadsDir:   getEnv("UPLOADS_DIR", "./web/uploads"),
-			MaxUploadMB:  getEnvAsInt("MAX_UPLOAD_MB", 10),
+			MaxUploadMB:  getEnvAsInt("MAX_UPLOAD_MB", 50),
 			Environment:  getEnv("ENVIRONMENT", "development"),
 			AllowOrigins: getEnv("ALLOW_ORIGINS", "*"),
 		},
@@ -97,9 +179,14 @@ func LoadConfig() *Config {
 			DB:       getEnvAsInt("REDIS_DB", 0),
 		},
 		JWT: JWTConfig{
-			Secret:          getEnv("JWT_SECRET", "change-this-secret-in-production"),
-			AccessTokenExp:  getEnvAsInt("JWT_ACCESS_TOKEN_EXP", 60),   // 60 dakika
-			RefreshTokenExp: getEnvAsInt("JWT_REFRESH_TOKEN_EXP", 168), // 7 gün
+			Secret:                  getEnv("JWT_SECRET", "change-this-secret-in-production"),
+			AccessTokenExp:          getEnvAsInt("JWT_ACCESS_TOKEN_EXP", 60),            // 60 dakika
+			RefreshTokenExp:         getEnvAsInt("JWT_REFRESH_TOKEN_EXP", 168),          // 7 gün
+			RememberRefreshTokenExp: getEnvAsInt("JWT_REMEMBER_REFRESH_TOKEN_EXP", 720), // 30 gün
+			Algorithm:               getEnv("JWT_ALGORITHM", "HS256"),
+			KeysDir:                 getEnv("JWT_KEYS_DIR", "./keys"),
+			KeyRotationHours:        getEnvAsInt("JWT_KEY_ROTATION_HOURS", 720), // 30 gün
+			AcceptLegacyHS256:       getEnvAsBool("JWT_ACCEPT_LEGACY_HS256", false),
 		},
 		MinIO: MinIOConfig{
 			Endpoint:        getEnv("MINIO_ENDPOINT", "localhost:9000"),
@@ -109,12 +196,47 @@ func LoadConfig() *Config {
 			BucketName:      getEnv("MINIO_BUCKET_NAME", "haber"),
 			Location:        getEnv("MINIO_LOCATION", "eu-west-1"),
 		},
+		Storage: StorageConfig{
+			Driver:           getEnv("STORAGE_DRIVER", "local"),
+			PublicURL:        getEnv("STORAGE_PUBLIC_URL", ""),
+			URLExpiryMinutes: getEnvAsInt("STORAGE_URL_EXPIRY_MINUTES", 60),
+			GCIntervalHours:  getEnvAsInt("STORAGE_GC_INTERVAL_HOURS", 24),
+			GCDelete:         getEnvAsBool("STORAGE_GC_DELETE", false),
+			GCGraceHours:     getEnvAsInt("STORAGE_GC_GRACE_HOURS", 24),
+		},
+		Image: ImageConfig{
+			Derivatives: getEnv("IMAGE_DERIVATIVES", "thumbnail:150,300;card:480,960;hero:1280,1920"),
+			JPEGQuality: getEnvAsInt("IMAGE_JPEG_QUALITY", 82),
+		},
+		Upload: UploadConfig{
+			MaxImageMB:           getEnvAsInt("UPLOAD_MAX_IMAGE_MB", 10),
+			MaxDocumentMB:        getEnvAsInt("UPLOAD_MAX_DOCUMENT_MB", 20),
+			MaxArchiveMB:         getEnvAsInt("UPLOAD_MAX_ARCHIVE_MB", 50),
+			MaxImageMegapixels:   getEnvAsInt("UPLOAD_MAX_IMAGE_MEGAPIXELS", 50),
+			ResumableExpiryHours: getEnvAsInt("UPLOAD_RESUMABLE_EXPIRY_HOURS", 24),
+			DirectExpiryMinutes:  getEnvAsInt("UPLOAD_DIRECT_EXPIRY_MINUTES", 15),
+			ImportMaxEntries:     getEnvAsInt("UPLOAD_IMPORT_MAX_ENTRIES", 1000),
+			ImportMaxTotalMB:     getEnvAsInt("UPLOAD_IMPORT_MAX_TOTAL_MB", 2048),
+		},
 		RateLimiter: RateLimiterConfig{
 			Enabled:        getEnvAsBool("RATE_LIMITER_ENABLED", true),
 			MaxRequests:    getEnvAsInt("RATE_LIMITER_MAX_REQUESTS", 100),
 			ExpireSeconds:  getEnvAsInt("RATE_LIMITER_EXPIRE_SECONDS", 60),
 			SkipSuccessful: getEnvAsBool("RATE_LIMITER_SKIP_SUCCESSFUL", false),
 		},
+		OIDC: OIDCConfig{
+			Enabled:        getEnvAsBool("OIDC_ENABLED", false),
+			IssuerURL:      getEnv("OIDC_ISSUER_URL", ""),
+			ClientID:       getEnv("OIDC_CLIENT_ID", ""),
+			ClientSecret:   getEnv("OIDC_CLIENT_SECRET", ""),
+			RedirectURL:    getEnv("OIDC_REDIRECT_URL", "http://localhost:3000/api/auth/oidc/callback"),
+			Scopes:         getEnv("OIDC_SCOPES", "profile email groups"),
+			GroupsClaim:    getEnv("OIDC_GROUPS_CLAIM", "groups"),
+			RoleMapping:    getEnv("OIDC_ROLE_MAPPING", ""),
+			DefaultRole:    getEnv("OIDC_DEFAULT_ROLE", "user"),
+			AutoProvision:  getEnvAsBool("OIDC_AUTO_PROVISION", true),
+			AllowedDomains: getEnv("OIDC_ALLOWED_DOMAINS", ""),
+		},
 	}
 }
 
@@ -174,10 +296,26 @@ func (c *Config) GetMinIO() IMinIOConfig {
 	return &c.MinIO
 }
 
+func (c *Config) GetStorage() IStorageConfig {
+	return &c.Storage
+}
+
+func (c *Config) GetImage() IImageConfig {
+	return &c.Image
+}
+
+func (c *Config) GetUpload() IUploadConfig {
+	return &c.Upload
+}
+
 func (c *Config) GetRateLimiter() IRateLimiterConfig {
 	return &c.RateLimiter
 }
 
+func (c *Config) GetOIDC() IOIDCConfig {
+	return &c.OIDC
+}
+
 // IServerConfig implentasyonu için getter metotları
 func (c *ServerConfig) GetPort() string {
 	return c.Port
@@ -262,6 +400,26 @@ func (c *JWTConfig) GetRefreshTokenExp() int {
 	return c.RefreshTokenExp
 }
 
+func (c *JWTConfig) GetRememberRefreshTokenExp() int {
+	return c.RememberRefreshTokenExp
+}
+
+func (c *JWTConfig) GetAlgorithm() string {
+	return c.Algorithm
+}
+
+func (c *JWTConfig) GetKeysDir() string {
+	return c.KeysDir
+}
+
+func (c *JWTConfig) GetKeyRotationHours() int {
+	return c.KeyRotationHours
+}
+
+func (c *JWTConfig) GetAcceptLegacyHS256() bool {
+	return c.AcceptLegacyHS256
+}
+
 // IMinIOConfig implentasyonu için getter metotları
 func (c *MinIOConfig) GetEndpoint() string {
 	return c.Endpoint
@@ -287,6 +445,73 @@ func (c *MinIOConfig) GetLocation() string {
 	return c.Location
 }
 
+// IStorageConfig implementasyonu için getter metotları
+func (c *StorageConfig) GetDriver() string {
+	return c.Driver
+}
+
+func (c *StorageConfig) GetPublicURL() string {
+	return c.PublicURL
+}
+
+func (c *StorageConfig) GetURLExpiryMinutes() int {
+	return c.URLExpiryMinutes
+}
+
+func (c *StorageConfig) GetGCIntervalHours() int {
+	return c.GCIntervalHours
+}
+
+func (c *StorageConfig) GetGCDelete() bool {
+	return c.GCDelete
+}
+
+func (c *StorageConfig) GetGCGraceHours() int {
+	return c.GCGraceHours
+}
+
+// IImageConfig implementasyonu için getter metotları
+func (c *ImageConfig) GetDerivatives() string {
+	return c.Derivatives
+}
+
+func (c *ImageConfig) GetJPEGQuality() int {
+	return c.JPEGQuality
+}
+
+// IUploadConfig implementasyonu için getter metotları
+func (c *UploadConfig) GetMaxImageMB() int {
+	return c.MaxImageMB
+}
+
+func (c *UploadConfig) GetMaxDocumentMB() int {
+	return c.MaxDocumentMB
+}
+
+func (c *UploadConfig) GetMaxArchiveMB() int {
+	return c.MaxArchiveMB
+}
+
+func (c *UploadConfig) GetMaxImageMegapixels() int {
+	return c.MaxImageMegapixels
+}
+
+func (c *UploadConfig) GetResumableExpiryHours() int {
+	return c.ResumableExpiryHours
+}
+
+func (c *UploadConfig) GetDirectExpiryMinutes() int {
+	return c.DirectExpiryMinutes
+}
+
+func (c *UploadConfig) GetImportMaxEntries() int {
+	return c.ImportMaxEntries
+}
+
+func (c *UploadConfig) GetImportMaxTotalMB() int {
+	return c.ImportMaxTotalMB
+}
+
 // IRateLimiterConfig implementasyonu için getter metotları
 func (c *RateLimiterConfig) GetEnabled() bool {
 	return c.Enabled
@@ -303,3 +528,48 @@ func (c *RateLimiterConfig) GetExpireSeconds() int {
 func (c *RateLimiterConfig) GetSkipSuccessful() bool {
 	return c.SkipSuccessful
 }
+
+// IOIDCConfig implementasyonu için getter metotları
+func (c *OIDCConfig) GetEnabled() bool {
+	return c.Enabled
+}
+
+func (c *OIDCConfig) GetIssuerURL() string {
+	return c.IssuerURL
+}
+
+func (c *OIDCConfig) GetClientID() string {
+	return c.ClientID
+}
+
+func (c *OIDCConfig) GetClientSecret() string {
+	return c.ClientSecret
+}
+
+func (c *OIDCConfig) GetRedirectURL() string {
+	return c.RedirectURL
+}
+
+func (c *OIDCConfig) GetScopes() string {
+	return c.Scopes
+}
+
+func (c *OIDCConfig) GetGroupsClaim() string {
+	return c.GroupsClaim
+}
+
+func (c *OIDCConfig) GetRoleMapping() string {
+	return c.RoleMapping
+}
+
+func (c *OIDCConfig) GetDefaultRole() string {
+	return c.DefaultRole
+}
+
+func (c *OIDCConfig) GetAutoProvision() bool {
+	return c.AutoProvision
+}
+
+func (c *OIDCConfig) GetAllowedDomains() string {
+	return c.AllowedDomains
+}
@@ -7,7 +7,11 @@ type IConfig interface {
 	GetRedis() IRedisConfig
 	GetJWT() IJWTConfig
 	GetMinIO() IMinIOConfig
+	GetStorage() IStorageConfig
+	GetImage() IImageConfig
+	GetUpload() IUploadConfig
 	GetRateLimiter() IRateLimiterConfig
+	GetOIDC() IOIDCConfig
 }
 
 // IServerConfig sunucu ayarları arayüzü
@@ -46,6 +50,11 @@ type IJWTConfig interface {
 	GetSecret() string
 	GetAccessTokenExp() int
 	GetRefreshTokenExp() int
+	GetRememberRefreshTokenExp() int
+	GetAlgorithm() string
+	GetKeysDir() string
+	GetKeyRotationHours() int
+	GetAcceptLegacyHS256() bool
 }
 
 // IMinIOConfig MinIO nesne depolama ayarları arayüzü
@@ -58,6 +67,34 @@ type IMinIOConfig interface {
 	GetLocation() string
 }
 
+// IStorageConfig dosya depolama ayarları arayüzü
+type IStorageConfig interface {
+	GetDriver() string
+	GetPublicURL() string
+	GetURLExpiryMinutes() int
+	GetGCIntervalHours() int
+	GetGCDelete() bool
+	GetGCGraceHours() int
+}
+
+// IImageConfig görsel türevi ayarları arayüzü
+type IImageConfig interface {
+	GetDerivatives() string
+	GetJPEGQuality() int
+}
+
+// IUploadConfig dosya yükleme sınırları arayüzü
+type IUploadConfig interface {
+	GetMaxImageMB() int
+	GetMaxDocumentMB() int
+	GetMaxArchiveMB() int
+	GetMaxImageMegapixels() int
+	GetResumableExpiryHours() int
+	GetDirectExpiryMinutes() int
+	GetImportMaxEntries() int
+	GetImportMaxTotalMB() int
+}
+
 // IRateLimiterConfig Rate Limiter ayarları arayüzü
 type IRateLimiterConfig interface {
 	GetEnabled() bool
@@ -65,3 +102,18 @@ type IRateLimiterConfig interface {
 	GetExpireSeconds() int
 	GetSkipSuccessful() bool
 }
+
+// IOIDCConfig OpenID Connect tek oturum açma ayarları arayüzü
+type IOIDCConfig interface {
+	GetEnabled() bool
+	GetIssuerURL() string
+	GetClientID() string
+	GetClientSecret() string
+	GetRedirectURL() string
+	GetScopes() string
+	GetGroupsClaim() string
+	GetRoleMapping() string
+	GetDefaultRole() string
+	GetAutoProvision() bool
+	GetAllowedDomains() string
+}
@@ -48,7 +48,7 @@ func MockConfig() IConfig {
 			TemplateDir:  "./web/templates",
 			StaticDir:    "./web/static",
 			UploadsDir:   "./web/uploads",
-			MaxUploadMB:  10,
+			MaxUploadMB:  50,
 			Environment:  "test",
 			AllowOrigins: "*",
 		},
@@ -67,9 +67,11 @@ func MockConfig() IConfig {
 			DB:       0,
 		},
 		JWT: JWTConfig{
-			Secret:          "test-secret",
-			AccessTokenExp:  60,
-			RefreshTokenExp: 168,
+			Secret:                  "test-secret",
+			AccessTokenExp:          60,
+			RefreshTokenExp:         168,
+			RememberRefreshTokenExp: 720,
+			Algorithm:               "HS256",
 		},
 		MinIO: MinIOConfig{
 			Endpoint:        "localhost:9000",
@@ -79,5 +81,24 @@ func MockConfig() IConfig {
 			BucketName:      "haber-test",
 			Location:        "eu-west-1",
 		},
+		Storage: StorageConfig{
+			Driver:           "local",
+			URLExpiryMinutes: 60,
+			GCGraceHours:     24,
+		},
+		Image: ImageConfig{
+			Derivatives: "thumbnail:150,300;card:480,960",
+			JPEGQuality: 82,
+		},
+		Upload: UploadConfig{
+			MaxImageMB:           10,
+			MaxDocumentMB:        20,
+			MaxArchiveMB:         50,
+			MaxImageMegapixels:   50,
+			ResumableExpiryHours: 24,
+			DirectExpiryMinutes:  15,
+			ImportMaxEntries:     1000,
+			ImportMaxTotalMB:     2048,
+		},
 	}
 }
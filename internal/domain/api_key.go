@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"net"
+	"time"
+)
+
+// API anahtarı kapsamları
+const (
+	ScopeArticlesRead    = "articles:read"
+	ScopeArticlesWrite   = "articles:write"
+	ScopeCategoriesWrite = "categories:write"
+	ScopeTagsWrite       = "tags:write"
+	ScopeMediaRead       = "media:read"
+	ScopeMediaWrite      = "media:write"
+)
+
+// APIKeyScopes tanımlı tüm kapsamlar
+var APIKeyScopes = []string{
+	ScopeArticlesRead,
+	ScopeArticlesWrite,
+	ScopeCategoriesWrite,
+	ScopeTagsWrite,
+	ScopeMediaRead,
+	ScopeMediaWrite,
+}
+
+// APIKey makine istemcileri için kapsamlı API anahtarı
+//
+// Anahtarın kendisi yalnızca oluşturulduğunda bir kez gösterilir,
+// veritabanında SHA-256 özeti ve tanıma amaçlı ön eki saklanır.
+type APIKey struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	Name        string     `gorm:"size:100;not null" json:"name"`
+	Prefix      string     `gorm:"size:20;not null;index" json:"prefix"`
+	KeyHash     string     `gorm:"size:64;uniqueIndex;not null" json:"-"`
+	Scopes      []string   `gorm:"type:text;serializer:json" json:"scopes"`
+	AllowedIPs  []string   `gorm:"type:text;serializer:json" json:"allowed_ips,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
+	LastUsedIP  string     `gorm:"size:45" json:"last_used_ip,omitempty"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+	CreatedByID uint       `gorm:"not null;index" json:"created_by_id"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+
+	// İlişkiler
+	CreatedBy *User `json:"created_by,omitempty" gorm:"foreignKey:CreatedByID"`
+}
+
+// IsActive anahtarın iptal edilmemiş ve süresinin dolmamış olup olmadığını döndürür
+func (k *APIKey) IsActive(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
+
+// HasScope anahtarın verilen kapsama sahip olup olmadığını döndürür
+func (k *APIKey) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsIP istemci adresinin izin listesinde olup olmadığını döndürür
+//
+// Liste boşsa tüm adreslere izin verilir. Girdiler tekil IP ya da CIDR olabilir.
+func (k *APIKey) AllowsIP(ip string) bool {
+	if len(k.AllowedIPs) == 0 {
+		return true
+	}
+
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return false
+	}
+
+	for _, entry := range k.AllowedIPs {
+		if _, network, err := net.ParseCIDR(entry); err == nil {
+			if network.Contains(addr) {
+				return true
+			}
+			continue
+		}
+		if allowed := net.ParseIP(entry); allowed != nil && allowed.Equal(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateAPIKeyRequest API anahtarı oluşturma isteği
+type CreateAPIKeyRequest struct {
+	Name       string     `json:"name" validate:"required,max=100"`
+	Scopes     []string   `json:"scopes" validate:"required,min=1,dive,oneof=articles:read articles:write categories:write tags:write media:read media:write"`
+	AllowedIPs []string   `json:"allowed_ips,omitempty" validate:"omitempty,dive,ip|cidr"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+}
+
+// APIKeyCreatedResponse yeni oluşturulan anahtarı içeren yanıt
+type APIKeyCreatedResponse struct {
+	APIKey *APIKey `json:"api_key"`
+	Key    string  `json:"key"`
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Denetim kaydı işlem adları
+const (
+	AuditActionCreate         = "create"
+	AuditActionUpdate         = "update"
+	AuditActionDelete         = "delete"
+	AuditActionUpload         = "upload"
+	AuditActionRoleChange     = "role_change"
+	AuditActionAssign         = "assign"
+	AuditActionRevoke         = "revoke"
+	AuditActionRevokeSessions = "revoke_sessions"
+	AuditActionVerifyEmail    = "verify_email"
+	AuditActionUnlock         = "unlock"
+)
+
+// AuditLog yönetim ve editörlük işlemlerinin değiştirilemez kaydı
+//
+// Kayıtlar yalnızca eklenir; veritabanı tetikleyicisi güncelleme ve silmeyi
+// engeller. ActorID boşsa işlem sistem tarafından yapılmıştır (ör. SSO rol
+// eşitlemesi).
+type AuditLog struct {
+	ID           uint            `gorm:"primaryKey" json:"id"`
+	ActorID      *uint           `gorm:"index" json:"actor_id,omitempty"`
+	ActorRole    string          `gorm:"size:50" json:"actor_role,omitempty"`
+	APIKeyID     *uint           `json:"api_key_id,omitempty"`
+	Action       string          `gorm:"size:50;not null;index" json:"action"`
+	ResourceType ResourceType    `gorm:"size:50;not null;index:idx_audit_resource" json:"resource_type"`
+	ResourceID   string          `gorm:"size:100;index:idx_audit_resource" json:"resource_id"`
+	Before       json.RawMessage `gorm:"type:jsonb" json:"before,omitempty" swaggertype:"object"`
+	After        json.RawMessage `gorm:"type:jsonb" json:"after,omitempty" swaggertype:"object"`
+	IP           string          `gorm:"size:45" json:"ip,omitempty"`
+	RequestID    string          `gorm:"size:64;index" json:"request_id,omitempty"`
+	CreatedAt    time.Time       `gorm:"not null;index" json:"created_at"`
+}
+
+// AuditLogFilter denetim kaydı sorgu filtreleri
+type AuditLogFilter struct {
+	ActorID      *uint
+	Action       string
+	ResourceType ResourceType
+	ResourceID   string
+	RequestID    string
+	From         *time.Time
+	To           *time.Time
+}
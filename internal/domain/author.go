@@ -0,0 +1,76 @@
+package domain
+
+import "time"
+
+// AuthorStats yazarın yayımlanmış makalelerine ait sayılar
+type AuthorStats struct {
+	AuthorID     uint  `json:"-"`
+	ArticleCount int64 `json:"article_count"`
+	TotalViews   int64 `json:"total_views"`
+}
+
+// AuthorArticle yazar sayfasında listelenen makale özeti
+type AuthorArticle struct {
+	ID            uint       `json:"id"`
+	Title         string     `json:"title"`
+	Slug          string     `json:"slug"`
+	Summary       string     `json:"summary"`
+	FeaturedImage string     `json:"featured_image,omitempty"`
+	CategoryID    uint       `json:"category_id"`
+	CategoryName  string     `json:"category_name,omitempty"`
+	ViewCount     uint       `json:"view_count"`
+	PublishedAt   *time.Time `json:"published_at,omitempty"`
+}
+
+// AuthorProfile herkese açık yazar profili
+//
+// Yalnızca okuyuculara gösterilebilecek alanları içerir; e-posta, durum ve
+// güvenlik bilgileri hiçbir zaman yer almaz.
+type AuthorProfile struct {
+	ID             uint             `json:"id"`
+	Username       string           `json:"username"`
+	FullName       string           `json:"full_name"`
+	Bio            string           `json:"bio,omitempty"`
+	ProfileImage   string           `json:"profile_image,omitempty"`
+	Role           string           `json:"role"`
+	JoinedAt       time.Time        `json:"joined_at"`
+	ArticleCount   int64            `json:"article_count"`
+	TotalViews     int64            `json:"total_views"`
+	RecentArticles []*AuthorArticle `json:"recent_articles,omitempty"`
+}
+
+// NewAuthorProfile kullanıcı ve istatistiklerinden herkese açık profil oluşturur
+func NewAuthorProfile(user *User, stats *AuthorStats) *AuthorProfile {
+	profile := &AuthorProfile{
+		ID:           user.ID,
+		Username:     user.Username,
+		FullName:     user.FullName,
+		Bio:          user.Bio,
+		ProfileImage: user.ProfileImage,
+		Role:         user.Role,
+		JoinedAt:     user.CreatedAt,
+	}
+	if stats != nil {
+		profile.ArticleCount = stats.ArticleCount
+		profile.TotalViews = stats.TotalViews
+	}
+	return profile
+}
+
+// NewAuthorArticle makaleden yazar sayfası özeti oluşturur
+func NewAuthorArticle(article *Article) *AuthorArticle {
+	summary := &AuthorArticle{
+		ID:            article.ID,
+		Title:         article.Title,
+		Slug:          article.Slug,
+		Summary:       article.Summary,
+		FeaturedImage: article.FeaturedImage,
+		CategoryID:    article.CategoryID,
+		ViewCount:     article.ViewCount,
+		PublishedAt:   article.PublishedAt,
+	}
+	if article.Category != nil {
+		summary.CategoryName = article.Category.Name
+	}
+	return summary
+}
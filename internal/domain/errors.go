@@ -20,6 +20,7 @@ var (
 	ErrServiceUnavailable = errors.New("servis kullanılamıyor")
 	ErrTooManyRequests    = errors.New("çok fazla istek")
 	ErrDatabaseError      = errors.New("veritabanı hatası")
+	ErrConflict           = errors.New("kaynak çakışması")
 )
 
 // ErrorCode özel hata kodları
@@ -38,6 +39,7 @@ const (
 	ErrorCodeUnavailable     ErrorCode = "SERVICE_UNAVAILABLE"
 	ErrorCodeTooManyRequests ErrorCode = "TOO_MANY_REQUESTS"
 	ErrorCodeDatabase        ErrorCode = "DATABASE_ERROR"
+	ErrorCodeConflict        ErrorCode = "CONFLICT"
 )
 
 // ResourceType kaynak türleri
@@ -53,6 +55,17 @@ const (
 	ResourceMedia    ResourceType = "Medya"
 	ResourceSetting  ResourceType = "Ayar"
 	ResourceAdSpace  ResourceType = "Reklam Alanı"
+
+	ResourceRefreshToken ResourceType = "Yenileme Token'ı"
+	ResourceToken        ResourceType = "Token"
+	ResourceLoginAttempt ResourceType = "Giriş Denemesi"
+	ResourceAPIKey       ResourceType = "API Anahtarı"
+	ResourceRole         ResourceType = "Rol"
+	ResourceSession      ResourceType = "Oturum"
+	ResourceIdentity     ResourceType = "Harici Kimlik"
+	ResourceOIDCState    ResourceType = "SSO Girişi"
+	ResourceAuditLog     ResourceType = "Denetim Kaydı"
+	ResourceUpload       ResourceType = "Yükleme"
 )
 
 // AppError uygulama genelinde kullanılan hata yapısı
@@ -123,6 +136,21 @@ func NewDuplicateError(resourceType ResourceType, field string, value interface{
 	return NewAppError(ErrDuplicateEntry, ErrorCodeDuplicate, msg, http.StatusConflict, nil)
 }
 
+// NewConflictError işlem kaynağın mevcut durumuyla çakıştığında hata oluşturur
+func NewConflictError(message string) *AppError {
+	return NewAppError(ErrConflict, ErrorCodeConflict, message, http.StatusConflict, nil)
+}
+
+// NewTooManyRequestsError istek sınırı aşıldığında hata oluşturur
+func NewTooManyRequestsError(message string) *AppError {
+	return NewAppError(ErrTooManyRequests, ErrorCodeTooManyRequests, message, http.StatusTooManyRequests, nil)
+}
+
+// NewServiceUnavailableError bağımlı bir servise ulaşılamadığında hata oluşturur
+func NewServiceUnavailableError(err error, message string) *AppError {
+	return NewAppError(err, ErrorCodeUnavailable, message, http.StatusServiceUnavailable, nil)
+}
+
 // NewInternalError yeni bir iç sunucu hatası oluşturur
 func NewInternalError(err error) *AppError {
 	return NewAppError(err, ErrorCodeInternal, "İç sunucu hatası", http.StatusInternalServerError, nil)
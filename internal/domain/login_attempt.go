@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"time"
+)
+
+// LoginAttempt hesap veya IP adresi bazında başarısız giriş sayacı
+//
+// Key "user:<id>", "name:<kullanıcı adı>" veya "ip:<adres>" biçimindedir.
+// Kayıtlı olmayan kullanıcı adları da sayıldığından kilitleme davranışı
+// hesabın var olup olmadığını belli etmez.
+type LoginAttempt struct {
+	Key           string     `gorm:"primaryKey;size:150" json:"key"`
+	Failures      int        `gorm:"not null;default:0" json:"failures"`
+	LastFailureAt time.Time  `gorm:"index" json:"last_failure_at"`
+	LockedUntil   *time.Time `json:"locked_until,omitempty"`
+}
+
+// IsLocked kaydın verilen anda kilitli olup olmadığını döndürür
+func (a *LoginAttempt) IsLocked(now time.Time) bool {
+	return a.LockedUntil != nil && now.Before(*a.LockedUntil)
+}
+
+// ClientInfo isteği yapan istemcinin bilgileri
+type ClientInfo struct {
+	IP        string
+	UserAgent string
+}
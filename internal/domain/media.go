@@ -1,31 +1,294 @@
 package domain
 
 import (
+	"fmt"
+	"sort"
+	"strings"
 	"time"
 )
 
 // Media medya modelimiz
 type Media struct {
+	ID          uint   `gorm:"primaryKey" json:"id"`
+	Filename    string `gorm:"size:255;not null" json:"filename"`
+	ObjectName  string `gorm:"size:255;not null;index" json:"object_name"` // Depodaki nesne anahtarı; aynı içerikli medyalarca paylaşılabilir
+	ContentType string `gorm:"size:100;not null" json:"content_type"`
+	Filesize    uint   `gorm:"not null" json:"filesize"`
+	ContentHash string `gorm:"size:64;index" json:"content_hash,omitempty"` // İçeriğin SHA-256 özeti (hex)
+	Width       int    `json:"width,omitempty"`                             // Yalnızca görsellerde
+	Height      int    `json:"height,omitempty"`                            // Yalnızca görsellerde
+	UserID      uint   `gorm:"not null" json:"user_id"`
+
+	// Künye bilgileri
+	AltText   string `gorm:"size:255" json:"alt_text"`
+	Caption   string `gorm:"type:text" json:"caption"`
+	Credit    string `gorm:"size:255" json:"credit"` // Fotoğrafçı veya muhabir
+	Source    string `gorm:"size:255" json:"source"` // Ajans veya kaynak
+	Copyright string `gorm:"size:255" json:"copyright"`
+
+	// Yüklemede EXIF'ten okunan bilgiler; konum bilgisi saklanmaz ve
+	// yayımlanan dosyadan silinir
+	CapturedAt  *time.Time `json:"captured_at,omitempty"`
+	CameraMake  string     `gorm:"size:100" json:"camera_make,omitempty"`
+	CameraModel string     `gorm:"size:100" json:"camera_model,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// İlişkiler
+	User        *User              `json:"-" gorm:"foreignKey:UserID"`
+	Derivatives []*MediaDerivative `json:"derivatives,omitempty" gorm:"foreignKey:MediaID;constraint:OnDelete:CASCADE"`
+	Tags        []*Tag             `json:"tags,omitempty" gorm:"many2many:media_tags;"`
+}
+
+// MediaDerivative bir görselden üretilmiş yeniden boyutlandırılmış kopya
+//
+// Türevler orijinal dosyanın yanında saklanır
+// (ör. general/2024/01/31/abc.jpg → general/2024/01/31/abc_card_480w.jpg).
+type MediaDerivative struct {
 	ID          uint      `gorm:"primaryKey" json:"id"`
-	Filename    string    `gorm:"size:255;not null" json:"filename"`
-	ObjectName  string    `gorm:"size:255;not null" json:"object_name"` // MinIO nesne adı
+	MediaID     uint      `gorm:"not null;uniqueIndex:idx_media_derivative" json:"media_id"`
+	Preset      string    `gorm:"size:50;not null;uniqueIndex:idx_media_derivative" json:"preset"`
+	Width       int       `gorm:"not null;uniqueIndex:idx_media_derivative" json:"width"`
+	Height      int       `gorm:"not null" json:"height"`
+	ObjectName  string    `gorm:"size:255;not null" json:"object_name"`
 	ContentType string    `gorm:"size:100;not null" json:"content_type"`
 	Filesize    uint      `gorm:"not null" json:"filesize"`
-	UserID      uint      `gorm:"not null" json:"user_id"`
 	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+}
 
-	// İlişkiler
-	User *User `json:"-" gorm:"foreignKey:UserID"`
+// MediaObject depodaki bir nesne ve onu kullanan medya kayıtlarının sayısı
+//
+// Aynı içerik birden fazla kez yüklendiğinde depoya tekrar yazılmaz; yeni
+// medya kaydı mevcut nesneyi gösterir ve RefCount artırılır. Nesne (ve
+// türevleri) depodan yalnızca son medya kaydı silindiğinde silinir.
+type MediaObject struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	ObjectName  string    `gorm:"size:255;not null;uniqueIndex" json:"object_name"`
+	ContentHash string    `gorm:"size:64;not null;index" json:"content_hash"`
+	ContentType string    `gorm:"size:100;not null" json:"content_type"`
+	Filesize    uint      `gorm:"not null" json:"filesize"`
+	RefCount    int       `gorm:"not null;default:1" json:"ref_count"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ResumableUpload tus protokolüyle parça parça yüklenen dosyanın durumu
+//
+// Parçalar tamamlanana kadar depoda ayrı nesneler olarak tutulur; dosya
+// tamamlandığında normal yükleme akışından geçirilip medya kaydına dönüşür.
+type ResumableUpload struct {
+	ID        string    `gorm:"primaryKey;size:64" json:"id"`
+	UserID    uint      `gorm:"not null;index" json:"user_id"`
+	Filename  string    `gorm:"size:255;not null" json:"filename"`
+	Folder    string    `gorm:"size:50;not null" json:"folder"`
+	Length    int64     `gorm:"not null" json:"length"`
+	Offset    int64     `gorm:"not null;default:0" json:"offset"`
+	Parts     int       `gorm:"not null;default:0" json:"parts"`
+	MediaID   *uint     `json:"media_id,omitempty"` // Tamamlandığında oluşturulan medya
+	ExpiresAt time.Time `gorm:"not null;index" json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Completed yüklemenin tamamlanıp medyaya dönüştürülüp dönüştürülmediğini belirtir
+func (u *ResumableUpload) Completed() bool {
+	return u.MediaID != nil
+}
+
+// DirectUpload istemcinin imzalı adresle doğrudan depoya yüklediği dosya
+//
+// Dosya onaylanana kadar geçici bir anahtarda durur; onaylandığında normal
+// yükleme akışından geçirilip medya kaydına dönüşür. Süresinde onaylanmayan
+// dosyalar silinir.
+type DirectUpload struct {
+	ID         string `gorm:"primaryKey;size:64" json:"id"`
+	UserID     uint   `gorm:"not null;index" json:"user_id"`
+	Filename   string `gorm:"size:255;not null" json:"filename"`
+	Folder     string `gorm:"size:50;not null" json:"folder"`
+	ObjectName string `gorm:"size:255;not null" json:"-"`
+	Size       int64  `gorm:"not null" json:"size"`
+	// İstemcinin bildirdiği SHA-256 özeti; boşsa özet karşılaştırılmaz
+	ContentHash string    `gorm:"size:64" json:"content_hash,omitempty"`
+	MediaID     *uint     `json:"media_id,omitempty"` // Onaylandığında oluşturulan medya
+	ExpiresAt   time.Time `gorm:"not null;index" json:"expires_at"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Completed yüklemenin onaylanıp medyaya dönüştürülüp dönüştürülmediğini belirtir
+func (u *DirectUpload) Completed() bool {
+	return u.MediaID != nil
+}
+
+// CreateDirectUploadRequest doğrudan yükleme adresi isteği
+type CreateDirectUploadRequest struct {
+	Filename string `json:"filename" validate:"required,max=255"`
+	Folder   string `json:"folder" validate:"omitempty,max=50"`
+	Size     int64  `json:"size" validate:"required,min=1"`
+	SHA256   string `json:"sha256" validate:"omitempty,len=64,hexadecimal"`
+}
+
+// DirectUploadResponse doğrudan yükleme adresi ve yüklenecek dosyanın sınırları
+type DirectUploadResponse struct {
+	ID string `json:"id"`
+	// Dosya bu adrese gövde olarak PUT ile gönderilir
+	UploadURL string `json:"upload_url"`
+	Method    string `json:"method"`
+	// Gönderilecek dosyanın bayt cinsinden boyutu; farklıysa onay reddedilir
+	Size         int64     `json:"size"`
+	MaxSize      int64     `json:"max_size"`
+	AllowedTypes []string  `json:"allowed_types"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// MediaContent API üzerinden sunulacak bir medya dosyası veya türevi
+//
+// Doğrulayıcılar (ETag, LastModified) veritabanı kaydından üretilir; koşullu
+// isteklere depoya gidilmeden yanıt verilebilir.
+type MediaContent struct {
+	ObjectName   string
+	ContentType  string
+	ETag         string // Tırnak içinde güçlü ETag
+	LastModified time.Time
+	// Aynı adreste içerik hiç değişmez; türevler yeniden üretilebildiğinden değişebilir
+	Immutable bool
+}
+
+// DuplicateCluster aynı içeriğe sahip olduğu halde depoda ayrı nesneler
+// olarak saklanan medyalar (tekilleştirme öncesi yüklemelerden kalanlar)
+type DuplicateCluster struct {
+	ContentHash string `json:"content_hash"`
+	MediaCount  int64  `json:"media_count"`
+	ObjectCount int64  `json:"object_count"`
+	Filesize    uint   `json:"filesize"`
+	// Kopyalar tek nesnede birleştirilirse kazanılacak alan
+	WastedBytes uint64           `json:"wasted_bytes"`
+	Media       []*MediaResponse `json:"media,omitempty" gorm:"-"`
 }
 
 // MediaResponse medya yanıtı
 type MediaResponse struct {
-	ID          uint      `json:"id"`
-	Filename    string    `json:"filename"`
-	ObjectName  string    `json:"object_name"`
-	ContentType string    `json:"content_type"`
-	Filesize    uint      `json:"filesize"`
-	URL         string    `json:"url"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID          uint                       `json:"id"`
+	Filename    string                     `json:"filename"`
+	ObjectName  string                     `json:"object_name"`
+	ContentType string                     `json:"content_type"`
+	Filesize    uint                       `json:"filesize"`
+	ContentHash string                     `json:"content_hash,omitempty"`
+	Width       int                        `json:"width,omitempty"`
+	Height      int                        `json:"height,omitempty"`
+	AltText     string                     `json:"alt_text"`
+	Caption     string                     `json:"caption,omitempty"`
+	Credit      string                     `json:"credit,omitempty"`
+	Source      string                     `json:"source,omitempty"`
+	Copyright   string                     `json:"copyright,omitempty"`
+	CapturedAt  *time.Time                 `json:"captured_at,omitempty"`
+	CameraMake  string                     `json:"camera_make,omitempty"`
+	CameraModel string                     `json:"camera_model,omitempty"`
+	Tags        []*Tag                     `json:"tags,omitempty"`
+	URL         string                     `json:"url"`
+	Derivatives []*MediaDerivativeResponse `json:"derivatives,omitempty"`
+	// Türev adına göre <img srcset> değeri (ör. "…_card_480w.jpg 480w, …_card_960w.jpg 960w")
+	SrcSet    map[string]string `json:"srcset,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// UpdateMediaRequest medya künye bilgilerini güncelleme isteği
+//
+// Gönderilmeyen alanlar değiştirilmez; tag_ids gönderilirse etiketlerin
+// tamamı verilen listeyle değiştirilir.
+type UpdateMediaRequest struct {
+	AltText   *string `json:"alt_text" validate:"omitempty,max=255"`
+	Caption   *string `json:"caption" validate:"omitempty,max=2000"`
+	Credit    *string `json:"credit" validate:"omitempty,max=255"`
+	Source    *string `json:"source" validate:"omitempty,max=255"`
+	Copyright *string `json:"copyright" validate:"omitempty,max=255"`
+	TagIDs    *[]uint `json:"tag_ids" validate:"omitempty,max=50"`
+}
+
+// MediaFilter medya kütüphanesi arama filtresi
+type MediaFilter struct {
+	// Dosya adı, alternatif metin, açıklama, künye ve telif bilgilerinde aranır
+	Query  string
+	UserID *uint
+	TagID  *uint
+	// Dosya grubu: image, document veya archive
+	Kind string
+	// Servis tarafından Kind'dan doldurulur
+	ContentTypes []string
+	// Yalnızca alternatif metni girilmemiş medyalar (erişilebilirlik kontrolü için)
+	MissingAltText bool
+}
+
+// MediaDerivativeResponse medya yanıtındaki türev bilgisi
+type MediaDerivativeResponse struct {
+	Preset      string `json:"preset"`
+	Width       int    `json:"width"`
+	Height      int    `json:"height"`
+	ContentType string `json:"content_type"`
+	URL         string `json:"url"`
+}
+
+// BuildSrcSet türevleri ada ve genişliğe göre sıralar ve her türev adı için
+// srcset değerini oluşturur
+func (r *MediaResponse) BuildSrcSet() {
+	sort.Slice(r.Derivatives, func(i, j int) bool {
+		if r.Derivatives[i].Preset != r.Derivatives[j].Preset {
+			return r.Derivatives[i].Preset < r.Derivatives[j].Preset
+		}
+		return r.Derivatives[i].Width < r.Derivatives[j].Width
+	})
+
+	if len(r.Derivatives) == 0 {
+		r.SrcSet = nil
+		return
+	}
+
+	candidates := make(map[string][]string)
+	for _, derivative := range r.Derivatives {
+		candidates[derivative.Preset] = append(candidates[derivative.Preset],
+			fmt.Sprintf("%s %dw", derivative.URL, derivative.Width))
+	}
+
+	r.SrcSet = make(map[string]string, len(candidates))
+	for preset, list := range candidates {
+		r.SrcSet[preset] = strings.Join(list, ", ")
+	}
+}
+
+// Arka plan medya işlerinin türleri
+const (
+	MediaJobDerivatives = "derivatives" // Görsel türevlerinin yeniden üretimi
+	MediaJobHashes      = "hashes"      // Eski yüklemelerin içerik özetlerinin hesaplanması
+	MediaJobUsages      = "usages"      // Medya kullanım kayıtlarının yeniden oluşturulması
+	MediaJobGC          = "gc"          // Sahipsiz dosya ve kayıt taraması
+	MediaJobImport      = "import"      // ZIP arşivinden toplu içe aktarma
+)
+
+// Arka plan medya işlerinin durumları
+const (
+	MediaJobRunning   = "running"
+	MediaJobCompleted = "completed"
+	MediaJobFailed    = "failed"
+)
+
+// MediaJob medyalar üzerinde toplu çalışan arka plan işinin durumu
+type MediaJob struct {
+	ID          string     `json:"id"`
+	Kind        string     `json:"kind"`
+	Status      string     `json:"status"`
+	Total       int64      `json:"total"`
+	Processed   int64      `json:"processed"`
+	Failed      int64      `json:"failed"`
+	LastError   string     `json:"last_error,omitempty"`
+	StartedByID *uint      `json:"started_by_id,omitempty"`
+	StartedAt   time.Time  `json:"started_at"`
+	FinishedAt  *time.Time `json:"finished_at,omitempty"`
+	// Dosya bazında sonuçlar; yalnızca içe aktarma işlerinde doldurulur
+	Items []*MediaJobItem `json:"items,omitempty"`
+}
+
+// MediaJobItem arka plan işinde işlenen tek bir dosyanın sonucu
+type MediaJobItem struct {
+	Name    string `json:"name"`
+	MediaID *uint  `json:"media_id,omitempty"`
+	Error   string `json:"error,omitempty"`
 }
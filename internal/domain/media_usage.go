@@ -0,0 +1,68 @@
+package domain
+
+import "time"
+
+// Medyayı kullanan kayıt türleri
+const (
+	MediaOwnerArticle = "article"
+	MediaOwnerUser    = "user"
+)
+
+// Medya referansı içeren alanlar
+const (
+	MediaFieldFeaturedImage = "featured_image"
+	MediaFieldContent       = "content"
+	MediaFieldProfileImage  = "profile_image"
+)
+
+// MediaUsage bir medyanın makale veya kullanıcı alanlarında kullanıldığını kaydeder
+//
+// Kayıtlar makale ve kullanıcılar kaydedilirken alanlardaki medya adresleri
+// çözümlenerek güncellenir; medya silinirken kullanımda olup olmadığı
+// buradan kontrol edilir.
+type MediaUsage struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	MediaID   uint      `gorm:"not null;uniqueIndex:idx_media_usage" json:"media_id"`
+	OwnerType string    `gorm:"size:20;not null;uniqueIndex:idx_media_usage;index:idx_media_usage_owner" json:"owner_type"`
+	OwnerID   uint      `gorm:"not null;uniqueIndex:idx_media_usage;index:idx_media_usage_owner" json:"owner_id"`
+	Field     string    `gorm:"size:50;not null;uniqueIndex:idx_media_usage" json:"field"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// İlişkiler
+	Media *Media `json:"-" gorm:"foreignKey:MediaID;constraint:OnDelete:CASCADE"`
+}
+
+// MediaGCReport depo ile veritabanı arasındaki tutarsızlık taramasının sonucu
+type MediaGCReport struct {
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	// Bulunanların silinip silinmediği; false ise yalnızca rapordur
+	Delete bool `json:"delete"`
+
+	// Depoda olup hiçbir kayıtla ilişkili olmayan dosyalar
+	OrphanObjects     []*OrphanObject `json:"orphan_objects"`
+	OrphanObjectCount int             `json:"orphan_object_count"`
+	OrphanBytes       int64           `json:"orphan_bytes"`
+
+	// Kaydı olup dosyası depoda bulunmayan medyalar
+	MissingObjects     []*MissingObject `json:"missing_objects"`
+	MissingObjectCount int              `json:"missing_object_count"`
+
+	RemovedObjects int `json:"removed_objects"`
+	RemovedMedia   int `json:"removed_media"`
+}
+
+// OrphanObject depoda kaydı olmayan dosya
+type OrphanObject struct {
+	Key          string    `json:"key"`
+	Size         int64     `json:"size"`
+	LastModified time.Time `json:"last_modified"`
+}
+
+// MissingObject dosyası depoda bulunmayan medya kaydı
+type MissingObject struct {
+	MediaID    uint   `json:"media_id"`
+	ObjectName string `json:"object_name"`
+	// Kullanımdaki kayıtlar silinmez, yalnızca raporlanır
+	InUse bool `json:"in_use"`
+}
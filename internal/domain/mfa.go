@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"time"
+)
+
+// RecoveryCode iki adımlı doğrulama için tek kullanımlık kurtarma kodu
+//
+// Kodların kendisi kullanıcıya yalnızca bir kez gösterilir, veritabanında
+// SHA-256 özeti saklanır.
+type RecoveryCode struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"not null;index" json:"user_id"`
+	CodeHash  string     `gorm:"size:64;not null;index" json:"-"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// MFAChallenge parola doğrulandıktan sonra ikinci adım için dönen yanıt
+type MFAChallenge struct {
+	ChallengeToken     string `json:"challenge_token"`
+	ExpiresIn          int    `json:"expires_in"`
+	EnrollmentRequired bool   `json:"enrollment_required"`
+}
+
+// MFAEnrollment TOTP kurulumu için kimlik doğrulayıcı uygulamaya verilecek bilgiler
+type MFAEnrollment struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+	QRPayload  string `json:"qr_payload"`
+}
+
+// MFAEnableResponse iki adımlı doğrulama etkinleştirildiğinde dönen yanıt
+type MFAEnableResponse struct {
+	RecoveryCodes []string       `json:"recovery_codes"`
+	Tokens        *TokenResponse `json:"tokens,omitempty"`
+}
+
+// MFACodeRequest TOTP veya kurtarma kodu içeren istek
+type MFACodeRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+// MFAChallengeRequest giriş sırasında ikinci adım doğrulama isteği
+type MFAChallengeRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+	Code           string `json:"code" validate:"required"`
+}
+
+// MFAChallengeEnrollRequest zorunlu kurulumda kurulumu başlatma isteği
+type MFAChallengeEnrollRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+}
+
+// DisableMFARequest iki adımlı doğrulamayı kapatma isteği
+type DisableMFARequest struct {
+	Password string `json:"password" validate:"required"`
+	Code     string `json:"code" validate:"required"`
+}
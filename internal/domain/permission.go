@@ -0,0 +1,181 @@
+package domain
+
+import "time"
+
+// Yetki adları
+//
+// "own" ile biten yetkiler yalnızca kullanıcının kendi içeriği için,
+// "assigned" ile bitenler kullanıcıya atanmış kategoriler için geçerlidir.
+const (
+	PermArticleCreate          = "article.create"
+	PermArticleEditOwn         = "article.edit.own"
+	PermArticleEditAssigned    = "article.edit.assigned"
+	PermArticleEditAny         = "article.edit.any"
+	PermArticlePublishAssigned = "article.publish.assigned"
+	PermArticlePublishAny      = "article.publish.any"
+	PermArticleDeleteOwn       = "article.delete.own"
+	PermArticleDeleteAny       = "article.delete.any"
+	PermCategoryManage         = "category.manage"
+	PermTagManage              = "tag.manage"
+	PermMediaUpload            = "media.upload"
+	PermMediaEditOwn           = "media.edit.own"
+	PermMediaEditAny           = "media.edit.any"
+	PermMediaDeleteOwn         = "media.delete.own"
+	PermMediaDeleteAny         = "media.delete.any"
+	PermMediaManage            = "media.manage"
+	PermUserManage             = "user.manage"
+	PermRoleManage             = "role.manage"
+	PermSettingsManage         = "settings.manage"
+	PermAPIKeyManage           = "apikey.manage"
+	PermAuditRead              = "audit.read"
+)
+
+// AllPermissions tanımlı tüm yetkiler
+var AllPermissions = []string{
+	PermArticleCreate,
+	PermArticleEditOwn,
+	PermArticleEditAssigned,
+	PermArticleEditAny,
+	PermArticlePublishAssigned,
+	PermArticlePublishAny,
+	PermArticleDeleteOwn,
+	PermArticleDeleteAny,
+	PermCategoryManage,
+	PermTagManage,
+	PermMediaUpload,
+	PermMediaEditOwn,
+	PermMediaEditAny,
+	PermMediaDeleteOwn,
+	PermMediaDeleteAny,
+	PermMediaManage,
+	PermUserManage,
+	PermRoleManage,
+	PermSettingsManage,
+	PermAPIKeyManage,
+	PermAuditRead,
+}
+
+// IsKnownPermission verilen adın tanımlı bir yetki olup olmadığını döndürür
+func IsKnownPermission(permission string) bool {
+	for _, p := range AllPermissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// Role rol ve sahip olduğu yetkiler
+//
+// Sistem rolleri silinemez ve adları değiştirilemez, yetkileri ise
+// yöneticiler tarafından düzenlenebilir.
+type Role struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	Name        string    `gorm:"size:50;uniqueIndex;not null" json:"name"`
+	Description string    `gorm:"size:255" json:"description"`
+	Permissions []string  `gorm:"type:text;serializer:json" json:"permissions"`
+	IsSystem    bool      `gorm:"not null;default:false" json:"is_system"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// HasPermission rolün verilen yetkiye sahip olup olmadığını döndürür
+func (r *Role) HasPermission(permission string) bool {
+	for _, p := range r.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultRoles ilk kurulumda oluşturulan sistem rolleri
+func DefaultRoles() []*Role {
+	return []*Role{
+		{
+			Name:        RoleAdmin,
+			Description: "Tüm yetkilere sahip yönetici",
+			Permissions: append([]string(nil), AllPermissions...),
+			IsSystem:    true,
+		},
+		{
+			Name:        RoleEditor,
+			Description: "Tüm kategorilerde içerik düzenleyip yayınlayabilen editör",
+			Permissions: []string{
+				PermArticleCreate, PermArticleEditAny, PermArticlePublishAny,
+				PermArticleDeleteAny, PermCategoryManage, PermTagManage,
+				PermMediaUpload, PermMediaEditAny, PermMediaDeleteAny,
+			},
+			IsSystem: true,
+		},
+		{
+			Name:        RoleSectionEditor,
+			Description: "Atandığı kategorilerde içerik düzenleyip yayınlayabilen bölüm editörü",
+			Permissions: []string{
+				PermArticleCreate, PermArticleEditOwn, PermArticleEditAssigned,
+				PermArticlePublishAssigned, PermArticleDeleteOwn, PermTagManage,
+				PermMediaUpload, PermMediaEditOwn, PermMediaDeleteOwn,
+			},
+			IsSystem: true,
+		},
+		{
+			Name:        RoleReporter,
+			Description: "Kendi taslaklarını yazıp düzenleyebilen muhabir",
+			Permissions: []string{
+				PermArticleCreate, PermArticleEditOwn, PermArticleDeleteOwn,
+				PermMediaUpload, PermMediaEditOwn, PermMediaDeleteOwn,
+			},
+			IsSystem: true,
+		},
+		{
+			Name:        RoleUser,
+			Description: "Kayıtlı okur",
+			Permissions: []string{PermMediaUpload, PermMediaEditOwn, PermMediaDeleteOwn},
+			IsSystem:    true,
+		},
+	}
+}
+
+// CategoryAssignment bölüm editörünün sorumlu olduğu kategori
+type CategoryAssignment struct {
+	UserID     uint      `gorm:"primaryKey" json:"user_id"`
+	CategoryID uint      `gorm:"primaryKey" json:"category_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Actor yetki kontrolü yapılan işlemi gerçekleştiren kimlik
+type Actor struct {
+	UserID uint
+	Role   string
+	// İşlem API anahtarıyla yapıldıysa anahtarın ID'si
+	APIKeyID uint
+	// Denetim kaydı için istek bilgileri
+	IP        string
+	RequestID string
+}
+
+// NewUserActor kullanıcı için Actor oluşturur
+func NewUserActor(user *User) *Actor {
+	return &Actor{
+		UserID: user.ID,
+		Role:   user.Role,
+	}
+}
+
+// CreateRoleRequest rol oluşturma isteği
+type CreateRoleRequest struct {
+	Name        string   `json:"name" validate:"required,min=2,max=50"`
+	Description string   `json:"description" validate:"max=255"`
+	Permissions []string `json:"permissions" validate:"dive,required"`
+}
+
+// UpdateRoleRequest rol güncelleme isteği
+type UpdateRoleRequest struct {
+	Description *string  `json:"description,omitempty" validate:"omitempty,max=255"`
+	Permissions []string `json:"permissions" validate:"dive,required"`
+}
+
+// AssignCategoriesRequest kullanıcıya kategori atama isteği
+type AssignCategoriesRequest struct {
+	CategoryIDs []uint `json:"category_ids" validate:"dive,required"`
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"time"
+)
+
+// RefreshToken sunucu tarafında saklanan yenileme token kaydı
+//
+// Token'ın kendisi saklanmaz, yalnızca SHA-256 özeti tutulur. Aynı girişten
+// türeyen tüm token'lar aynı FamilyID altında toplanır; bir token ikinci kez
+// kullanılırsa ailenin tamamı iptal edilir.
+type RefreshToken struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	UserID    uint      `gorm:"not null;index" json:"user_id"`
+	FamilyID  string    `gorm:"size:64;not null;index" json:"family_id"`
+	TokenHash string    `gorm:"size:64;uniqueIndex;not null" json:"-"`
+	ExpiresAt time.Time `gorm:"not null" json:"expires_at"`
+	// Giriş "beni hatırla" ile yapıldıysa yenilenen token'lar da uzun ömürlü olur
+	Remember  bool       `gorm:"not null;default:false" json:"remember"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+
+	// İlişkiler
+	User *User `json:"-" gorm:"foreignKey:UserID"`
+}
+
+// IsActive token'ın kullanılabilir durumda olup olmadığını döndürür
+func (t *RefreshToken) IsActive() bool {
+	return t.UsedAt == nil && t.RevokedAt == nil && time.Now().Before(t.ExpiresAt)
+}
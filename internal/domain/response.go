@@ -44,6 +44,25 @@ type AuthResponse struct {
 	ExpiresIn    int    `json:"expires_in"`
 }
 
+// LoginResponse giriş yanıtı
+//
+// İki adımlı doğrulama gerekiyorsa yalnızca MFA alanı doludur; token'lar
+// ikinci adım tamamlandıktan sonra verilir.
+type LoginResponse struct {
+	*AuthResponse
+	MFARequired bool          `json:"mfa_required"`
+	MFA         *MFAChallenge `json:"mfa,omitempty"`
+}
+
+// ProfileUpdateResponse profil güncelleme yanıtı
+//
+// E-posta adresi değiştiğinde eski token'lar iptal edildiği için yeni token
+// çifti de döner.
+type ProfileUpdateResponse struct {
+	*User
+	Tokens *TokenResponse `json:"tokens,omitempty"`
+}
+
 // TokenResponse token yanıtı
 type TokenResponse struct {
 	AccessToken  string `json:"access_token"`
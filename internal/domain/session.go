@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+// Session kullanıcının bir cihazdaki oturumu
+//
+// Her giriş yeni bir yenileme token ailesi başlatır ve oturum bu aileye
+// bağlıdır; oturum sonlandırıldığında ailedeki yenileme token'ları ve
+// oturuma ait erişim token'ları iptal edilir.
+type Session struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	UserID     uint       `gorm:"not null;index" json:"user_id"`
+	FamilyID   string     `gorm:"size:64;uniqueIndex;not null" json:"-"`
+	Device     string     `gorm:"size:100" json:"device"`
+	UserAgent  string     `gorm:"size:512" json:"user_agent"`
+	IP         string     `gorm:"size:45" json:"ip"`
+	LastSeenAt time.Time  `gorm:"not null" json:"last_seen_at"`
+	ExpiresAt  time.Time  `gorm:"not null" json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+
+	// İsteği yapan oturum mu (veritabanında saklanmaz)
+	Current bool `gorm:"-" json:"current"`
+}
+
+// IsActive oturumun sonlandırılmamış ve süresinin dolmamış olup olmadığını döndürür
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"time"
+)
+
+// Token tek kullanımlık işlem token'ı (şifre sıfırlama vb.)
+//
+// Token'ın kendisi kullanıcıya gönderilir, veritabanında yalnızca SHA-256
+// özeti saklanır.
+type Token struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"not null;index" json:"user_id"`
+	Type      string     `gorm:"size:30;not null;index" json:"type"`
+	TokenHash string     `gorm:"size:64;uniqueIndex;not null" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	Attempts  int        `gorm:"not null;default:0" json:"-"`
+	// İki adımlı doğrulama token'larında girişin "beni hatırla" seçimi
+	Remember  bool      `gorm:"not null;default:false" json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// İlişkiler
+	User *User `json:"-" gorm:"foreignKey:UserID"`
+}
+
+// TokenType tek kullanımlık token tipi sabitleri
+const (
+	TokenTypePasswordReset     = "reset-password"
+	TokenTypeEmailVerification = "verify-email"
+	TokenTypeMFAChallenge      = "mfa-challenge"
+	TokenTypeAccountUnlock     = "unlock-account"
+	TokenTypeOIDCHandoff       = "oidc-handoff"
+)
+
+// IsUsable token'ın kullanılabilir durumda olup olmadığını döndürür
+func (t *Token) IsUsable() bool {
+	return t.UsedAt == nil && time.Now().Before(t.ExpiresAt)
+}
@@ -8,25 +8,70 @@ import (
 
 // User kullanıcı modelimiz
 type User struct {
-	ID           uint           `gorm:"primaryKey" json:"id"`
-	Username     string         `gorm:"size:50;uniqueIndex;not null" json:"username"`
-	Email        string         `gorm:"size:100;uniqueIndex;not null" json:"email"`
-	PasswordHash string         `gorm:"size:255;not null" json:"-"`
-	FullName     string         `gorm:"size:100;not null" json:"full_name"`
-	Role         string         `gorm:"size:20;not null;default:user" json:"role"` // admin, editor, user
-	ProfileImage string         `gorm:"size:255" json:"profile_image,omitempty"`
-	CreatedAt    time.Time      `json:"created_at"`
-	UpdatedAt    time.Time      `json:"updated_at"`
-	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
+	ID              uint           `gorm:"primaryKey" json:"id"`
+	Username        string         `gorm:"size:50;uniqueIndex;not null" json:"username"`
+	Email           string         `gorm:"size:100;uniqueIndex;not null" json:"email"`
+	PasswordHash    string         `gorm:"size:255;not null" json:"-"`
+	FullName        string         `gorm:"size:100;not null" json:"full_name"`
+	Role            string         `gorm:"size:20;not null;default:user" json:"role"`     // admin, editor, section_editor, reporter, user
+	Status          string         `gorm:"size:20;not null;default:active" json:"status"` // active, unverified
+	ProfileImage    string         `gorm:"size:255" json:"profile_image,omitempty"`
+	Bio             string         `gorm:"type:text" json:"bio,omitempty"`
+	EmailVerifiedAt *time.Time     `json:"email_verified_at,omitempty"`
+	TOTPEnabled     bool           `gorm:"not null;default:false" json:"totp_enabled"`
+	TOTPSecret      string         `gorm:"size:64" json:"-"`
+	TOTPLastStep    int64          `gorm:"not null;default:0" json:"-"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
 // UserRole tanımlı kullanıcı rolleri
 const (
-	RoleAdmin  = "admin"
-	RoleEditor = "editor"
-	RoleUser   = "user"
+	RoleAdmin         = "admin"
+	RoleEditor        = "editor"
+	RoleSectionEditor = "section_editor"
+	RoleReporter      = "reporter"
+	RoleUser          = "user"
 )
 
+// UserStatus tanımlı hesap durumları
+const (
+	UserStatusActive     = "active"
+	UserStatusUnverified = "unverified"
+)
+
+// StaffRoles yayın ekibinde sayılan roller
+var StaffRoles = []string{RoleAdmin, RoleEditor, RoleSectionEditor, RoleReporter}
+
+// IsStaff kullanıcının yayın yetkisi olan bir personel hesabı olup olmadığını döndürür
+func (u *User) IsStaff() bool {
+	for _, role := range StaffRoles {
+		if u.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
+// MFARoles iki adımlı doğrulamanın zorunlu tutulabildiği roller
+var MFARoles = []string{RoleAdmin, RoleEditor}
+
+// RequiresMFA ayarla zorunlu kılındığında kullanıcının 2FA kullanması gerekip gerekmediğini döndürür
+func (u *User) RequiresMFA() bool {
+	for _, role := range MFARoles {
+		if u.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
+// IsEmailVerified kullanıcının e-posta adresini doğrulayıp doğrulamadığını döndürür
+func (u *User) IsEmailVerified() bool {
+	return u.Status != UserStatusUnverified
+}
+
 // RegisterUserRequest kullanıcı kaydı için gerekli alanlar
 type RegisterUserRequest struct {
 	Username        string `json:"username" validate:"required,min=3,max=50"`
@@ -38,6 +83,7 @@ type RegisterUserRequest struct {
 
 // LoginRequest kullanıcı girişi için gerekli alanlar
 type LoginRequest struct {
+	// Kullanıcı adı veya e-posta adresi
 	Username string `json:"username" validate:"required"`
 	Password string `json:"password" validate:"required"`
 	Remember bool   `json:"remember"`
@@ -50,7 +96,9 @@ type UserResponse struct {
 	Email        string    `json:"email"`
 	FullName     string    `json:"full_name"`
 	Role         string    `json:"role"`
+	Status       string    `json:"status"`
 	ProfileImage string    `json:"profile_image,omitempty"`
+	Bio          string    `json:"bio,omitempty"`
 	CreatedAt    time.Time `json:"created_at"`
 }
 
@@ -60,7 +108,9 @@ type UpdateUserRequest struct {
 	Email        string `json:"email,omitempty" validate:"omitempty,email"`
 	FullName     string `json:"full_name,omitempty"`
 	ProfileImage string `json:"profile_image,omitempty"`
-	Role         string `json:"role,omitempty"`
+	// Boş metin gönderilirse biyografi silinir
+	Bio  *string `json:"bio,omitempty" validate:"omitempty,max=2000"`
+	Role string  `json:"role,omitempty"`
 }
 
 // UpdatePasswordRequest şifre güncelleme isteği
@@ -77,5 +127,5 @@ type CreateUserRequest struct {
 	Password        string `json:"password" validate:"required,min=6"`
 	ConfirmPassword string `json:"confirm_password" validate:"required,eqfield=Password"`
 	FullName        string `json:"full_name" validate:"required"`
-	Role            string `json:"role" validate:"required,oneof=admin editor user"`
+	Role            string `json:"role" validate:"required,max=50"`
 }
@@ -0,0 +1,45 @@
+package domain
+
+import "time"
+
+// UserIdentity kullanıcının harici bir kimlik sağlayıcıdaki hesabı
+//
+// Sağlayıcı, OIDC issuer adresidir; sub değeri sağlayıcı içinde kalıcı ve
+// benzersiz olduğundan eşleştirme e-posta yerine bu ikiliyle yapılır.
+type UserIdentity struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	UserID      uint       `gorm:"not null;index" json:"user_id"`
+	Provider    string     `gorm:"size:255;not null;uniqueIndex:idx_identity_provider_subject" json:"provider"`
+	Subject     string     `gorm:"size:255;not null;uniqueIndex:idx_identity_provider_subject" json:"subject"`
+	Email       string     `gorm:"size:100" json:"email"`
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// OIDCLoginState kimlik sağlayıcıya yönlendirilen girişin bekleyen durumu
+//
+// State değeri yalnızca özet olarak saklanır ve geri dönüşte bir kez tüketilir.
+type OIDCLoginState struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	StateHash    string    `gorm:"size:64;uniqueIndex;not null" json:"-"`
+	Nonce        string    `gorm:"size:64;not null" json:"-"`
+	CodeVerifier string    `gorm:"size:128;not null" json:"-"`
+	ReturnTo     string    `gorm:"size:512" json:"return_to"`
+	ExpiresAt    time.Time `gorm:"not null;index" json:"expires_at"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// OIDCHandoff SSO dönüşünde tarayıcıya verilen tek kullanımlık giriş kodu
+//
+// Token'lar tarayıcı geçmişinde ve adres çubuğunda görünmesin diye dönüş
+// adresinde yalnızca bu kod taşınır; uygulama kodu token'larla değiştirir.
+type OIDCHandoff struct {
+	Code string
+	// Giriş başlatılırken istenen uygulama içi adres
+	ReturnTo string
+}
+
+// OIDCExchangeRequest SSO giriş kodunu token'larla değiştirme isteği
+type OIDCExchangeRequest struct {
+	Code string `json:"code" validate:"required"`
+}
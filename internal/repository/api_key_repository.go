@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/username/haber/internal/domain"
+	"gorm.io/gorm"
+)
+
+// IAPIKeyRepository API anahtarı işlemleri için repository arayüzü
+type IAPIKeyRepository interface {
+	Create(key *domain.APIKey) error
+	GetByID(id uint) (*domain.APIKey, error)
+	GetByHash(keyHash string) (*domain.APIKey, error)
+	List(offset, limit int) ([]*domain.APIKey, int64, error)
+	Revoke(id uint) error
+	TouchLastUsed(id uint, at time.Time, ip string) error
+}
+
+// APIKeyRepository API anahtarı repository implementasyonu
+type APIKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewAPIKeyRepository yeni bir APIKeyRepository oluşturur
+func NewAPIKeyRepository(db *Database) IAPIKeyRepository {
+	return &APIKeyRepository{
+		db: db.DB,
+	}
+}
+
+// Create yeni bir API anahtarı kaydı oluşturur
+func (r *APIKeyRepository) Create(key *domain.APIKey) error {
+	return r.db.Create(key).Error
+}
+
+// GetByID ID'ye göre API anahtarı getirir
+func (r *APIKeyRepository) GetByID(id uint) (*domain.APIKey, error) {
+	var key domain.APIKey
+	err := r.db.Preload("CreatedBy").First(&key, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &domain.NotFoundError{
+				ResourceType: domain.ResourceAPIKey,
+				ID:           id,
+			}
+		}
+		return nil, err
+	}
+	return &key, nil
+}
+
+// GetByHash anahtar özetine göre kayıt getirir
+func (r *APIKeyRepository) GetByHash(keyHash string) (*domain.APIKey, error) {
+	var key domain.APIKey
+	err := r.db.Preload("CreatedBy").Where("key_hash = ?", keyHash).First(&key).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &domain.NotFoundError{
+				ResourceType: domain.ResourceAPIKey,
+				ID:           "hash",
+			}
+		}
+		return nil, err
+	}
+	return &key, nil
+}
+
+// List API anahtarlarını sayfalı olarak listeler
+func (r *APIKeyRepository) List(offset, limit int) ([]*domain.APIKey, int64, error) {
+	var keys []*domain.APIKey
+	var total int64
+
+	if err := r.db.Model(&domain.APIKey{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := r.db.Preload("CreatedBy").
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&keys).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return keys, total, nil
+}
+
+// Revoke API anahtarını iptal eder
+func (r *APIKeyRepository) Revoke(id uint) error {
+	return r.db.Model(&domain.APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now()).Error
+}
+
+// TouchLastUsed anahtarın son kullanım bilgisini günceller
+func (r *APIKeyRepository) TouchLastUsed(id uint, at time.Time, ip string) error {
+	return r.db.Model(&domain.APIKey{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"last_used_at": at,
+			"last_used_ip": ip,
+		}).Error
+}
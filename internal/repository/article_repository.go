@@ -20,6 +20,7 @@ type IArticleRepository interface {
 	GetByCategory(categoryID uint, offset, limit int) ([]*domain.Article, int64, error)
 	GetByTag(tagID uint, offset, limit int) ([]*domain.Article, int64, error)
 	GetByAuthor(authorID uint, offset, limit int) ([]*domain.Article, int64, error)
+	GetAuthorStats(authorIDs []uint) (map[uint]*domain.AuthorStats, error)
 }
 
 // ArticleRepository ArticleRepository'nin GORM implementasyonu
@@ -212,3 +213,28 @@ func (r *ArticleRepository) GetByAuthor(authorID uint, offset, limit int) ([]*do
 
 	return articles, count, nil
 }
+
+// GetAuthorStats yazarların yayımlanmış makale sayısını ve toplam okunma sayısını getirir
+//
+// Yayımlanmış makalesi olmayan yazarlar sonuçta yer almaz.
+func (r *ArticleRepository) GetAuthorStats(authorIDs []uint) (map[uint]*domain.AuthorStats, error) {
+	result := make(map[uint]*domain.AuthorStats, len(authorIDs))
+	if len(authorIDs) == 0 {
+		return result, nil
+	}
+
+	var rows []*domain.AuthorStats
+	err := r.db.Model(&domain.Article{}).
+		Select("author_id, COUNT(*) AS article_count, COALESCE(SUM(view_count), 0) AS total_views").
+		Where("author_id IN ? AND status = ?", authorIDs, domain.ArticleStatusPublished).
+		Group("author_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		result[row.AuthorID] = row
+	}
+	return result, nil
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"github.com/username/haber/internal/domain"
+	"gorm.io/gorm"
+)
+
+// IAuditLogRepository denetim kaydı işlemleri için repository arayüzü
+//
+// Kayıtlar değiştirilemez olduğundan güncelleme ve silme metotları yoktur.
+type IAuditLogRepository interface {
+	Create(entry *domain.AuditLog) error
+	List(filter *domain.AuditLogFilter, offset, limit int) ([]*domain.AuditLog, int64, error)
+	Each(filter *domain.AuditLogFilter, batchSize int, fn func(entries []*domain.AuditLog) error) error
+}
+
+// AuditLogRepository denetim kaydı repository implementasyonu
+type AuditLogRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditLogRepository yeni bir AuditLogRepository oluşturur
+func NewAuditLogRepository(db *Database) IAuditLogRepository {
+	return &AuditLogRepository{
+		db: db.DB,
+	}
+}
+
+// Create yeni bir denetim kaydı ekler
+func (r *AuditLogRepository) Create(entry *domain.AuditLog) error {
+	return r.db.Create(entry).Error
+}
+
+// List filtreye uyan kayıtları en yeniden eskiye listeler
+func (r *AuditLogRepository) List(filter *domain.AuditLogFilter, offset, limit int) ([]*domain.AuditLog, int64, error) {
+	var entries []*domain.AuditLog
+	var count int64
+
+	query := r.filtered(filter)
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("id DESC").Offset(offset).Limit(limit).Find(&entries).Error
+	return entries, count, err
+}
+
+// Each filtreye uyan kayıtları eskiden yeniye gruplar halinde işler
+//
+// Dışa aktarma sırasında tüm kayıtların belleğe alınmasını önler.
+func (r *AuditLogRepository) Each(filter *domain.AuditLogFilter, batchSize int, fn func(entries []*domain.AuditLog) error) error {
+	var batch []*domain.AuditLog
+	return r.filtered(filter).FindInBatches(&batch, batchSize, func(tx *gorm.DB, _ int) error {
+		return fn(batch)
+	}).Error
+}
+
+// filtered filtre koşullarını sorguya ekler
+func (r *AuditLogRepository) filtered(filter *domain.AuditLogFilter) *gorm.DB {
+	query := r.db.Model(&domain.AuditLog{})
+	if filter == nil {
+		return query
+	}
+
+	if filter.ActorID != nil {
+		query = query.Where("actor_id = ?", *filter.ActorID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.ResourceType != "" {
+		query = query.Where("resource_type = ?", filter.ResourceType)
+	}
+	if filter.ResourceID != "" {
+		query = query.Where("resource_id = ?", filter.ResourceID)
+	}
+	if filter.RequestID != "" {
+		query = query.Where("request_id = ?", filter.RequestID)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+	return query
+}
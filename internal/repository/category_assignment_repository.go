@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/username/haber/internal/domain"
+	"gorm.io/gorm"
+)
+
+// ICategoryAssignmentRepository kullanıcı-kategori atamaları için repository arayüzü
+type ICategoryAssignmentRepository interface {
+	ListCategoryIDs(userID uint) ([]uint, error)
+	IsAssigned(userID, categoryID uint) (bool, error)
+	ReplaceForUser(userID uint, categoryIDs []uint) error
+}
+
+// CategoryAssignmentRepository kategori ataması repository implementasyonu
+type CategoryAssignmentRepository struct {
+	db *gorm.DB
+}
+
+// NewCategoryAssignmentRepository yeni bir CategoryAssignmentRepository oluşturur
+func NewCategoryAssignmentRepository(db *Database) ICategoryAssignmentRepository {
+	return &CategoryAssignmentRepository{
+		db: db.DB,
+	}
+}
+
+// ListCategoryIDs kullanıcıya atanmış kategori ID'lerini döndürür
+func (r *CategoryAssignmentRepository) ListCategoryIDs(userID uint) ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&domain.CategoryAssignment{}).
+		Where("user_id = ?", userID).
+		Order("category_id").
+		Pluck("category_id", &ids).Error
+	return ids, err
+}
+
+// IsAssigned kategorinin kullanıcıya atanıp atanmadığını döndürür
+func (r *CategoryAssignmentRepository) IsAssigned(userID, categoryID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&domain.CategoryAssignment{}).
+		Where("user_id = ? AND category_id = ?", userID, categoryID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// ReplaceForUser kullanıcının tüm kategori atamalarını verilenlerle değiştirir
+func (r *CategoryAssignmentRepository) ReplaceForUser(userID uint, categoryIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&domain.CategoryAssignment{}).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		assignments := make([]domain.CategoryAssignment, 0, len(categoryIDs))
+		for _, id := range categoryIDs {
+			assignments = append(assignments, domain.CategoryAssignment{
+				UserID:     userID,
+				CategoryID: id,
+				CreatedAt:  now,
+			})
+		}
+		if len(assignments) == 0 {
+			return nil
+		}
+		return tx.Create(&assignments).Error
+	})
+}
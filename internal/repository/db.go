@@ -41,16 +41,64 @@ func (d *Database) Close() error {
 
 // AutoMigrate veritabanı şemasını otomatik günceller
 func (d *Database) AutoMigrate() error {
-	return d.DB.AutoMigrate(
+	err := d.DB.AutoMigrate(
 		&domain.User{},
 		&domain.Category{},
 		&domain.Tag{},
 		&domain.Article{},
 		&domain.Comment{},
 		&domain.Media{},
+		&domain.MediaDerivative{},
+		&domain.MediaObject{},
+		&domain.ResumableUpload{},
+		&domain.DirectUpload{},
+		&domain.MediaUsage{},
 		&domain.Setting{},
 		&domain.AdSpace{},
+		&domain.RefreshToken{},
+		&domain.Token{},
+		&domain.RecoveryCode{},
+		&domain.LoginAttempt{},
+		&domain.APIKey{},
+		&domain.Role{},
+		&domain.CategoryAssignment{},
+		&domain.Session{},
+		&domain.UserIdentity{},
+		&domain.OIDCLoginState{},
+		&domain.AuditLog{},
 	)
+	if err != nil {
+		return err
+	}
+
+	// Denetim kayıtlarının değiştirilip silinmesini veritabanı düzeyinde engelle
+	if err := d.protectAuditLog(); err != nil {
+		return err
+	}
+
+	// Sistem rollerini varsayılan yetkileriyle oluştur
+	return NewRoleRepository(d).EnsureRoles(domain.DefaultRoles())
+}
+
+// protectAuditLog audit_logs tablosunu yalnızca ekleme yapılabilir hale getirir
+func (d *Database) protectAuditLog() error {
+	statements := []string{
+		`CREATE OR REPLACE FUNCTION audit_logs_append_only() RETURNS trigger AS $$
+		BEGIN
+			RAISE EXCEPTION 'audit_logs tablosu yalnızca ekleme yapılabilir';
+		END;
+		$$ LANGUAGE plpgsql`,
+		`DROP TRIGGER IF EXISTS audit_logs_append_only ON audit_logs`,
+		`CREATE TRIGGER audit_logs_append_only
+			BEFORE UPDATE OR DELETE OR TRUNCATE ON audit_logs
+			FOR EACH STATEMENT EXECUTE FUNCTION audit_logs_append_only()`,
+	}
+	for _, stmt := range statements {
+		if err := d.DB.Exec(stmt).Error; err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // WithTransaction transaction başlatır ve işler
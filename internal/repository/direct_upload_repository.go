@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/username/haber/internal/domain"
+	"gorm.io/gorm"
+)
+
+// IDirectUploadRepository doğrudan yüklemeler için repository arayüzü
+type IDirectUploadRepository interface {
+	Create(upload *domain.DirectUpload) error
+	Get(id string) (*domain.DirectUpload, error)
+	Update(upload *domain.DirectUpload) error
+	Delete(id string) error
+	ListExpired(before time.Time, limit int) ([]*domain.DirectUpload, error)
+}
+
+// DirectUploadRepository doğrudan yükleme repository implementasyonu
+type DirectUploadRepository struct {
+	db *gorm.DB
+}
+
+// NewDirectUploadRepository yeni bir DirectUploadRepository oluşturur
+func NewDirectUploadRepository(db *Database) IDirectUploadRepository {
+	return &DirectUploadRepository{
+		db: db.DB,
+	}
+}
+
+// Create yeni bir doğrudan yükleme kaydı oluşturur
+func (r *DirectUploadRepository) Create(upload *domain.DirectUpload) error {
+	return r.db.Create(upload).Error
+}
+
+// Get ID'ye göre doğrudan yüklemeyi getirir
+func (r *DirectUploadRepository) Get(id string) (*domain.DirectUpload, error) {
+	var upload domain.DirectUpload
+	err := r.db.Where("id = ?", id).First(&upload).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &domain.NotFoundError{
+				ResourceType: domain.ResourceUpload,
+				ID:           id,
+			}
+		}
+		return nil, err
+	}
+	return &upload, nil
+}
+
+// Update doğrudan yükleme kaydını günceller
+func (r *DirectUploadRepository) Update(upload *domain.DirectUpload) error {
+	return r.db.Save(upload).Error
+}
+
+// Delete doğrudan yükleme kaydını siler
+func (r *DirectUploadRepository) Delete(id string) error {
+	return r.db.Where("id = ?", id).Delete(&domain.DirectUpload{}).Error
+}
+
+// ListExpired verilen andan önce süresi dolmuş yüklemeleri getirir
+func (r *DirectUploadRepository) ListExpired(before time.Time, limit int) ([]*domain.DirectUpload, error) {
+	var uploads []*domain.DirectUpload
+	err := r.db.Where("expires_at < ?", before).
+		Order("expires_at ASC").
+		Limit(limit).
+		Find(&uploads).Error
+	return uploads, err
+}
@@ -6,13 +6,27 @@ import (
 
 // RepositoryFactory tüm repository'leri yönetir
 type RepositoryFactory struct {
-	db           *Database
-	userRepo     IUserRepository
-	articleRepo  IArticleRepository
-	categoryRepo ICategoryRepository
-	tagRepo      ITagRepository
-	mediaRepo    IMediaRepository
-	mu           sync.RWMutex
+	db                     *Database
+	userRepo               IUserRepository
+	articleRepo            IArticleRepository
+	categoryRepo           ICategoryRepository
+	tagRepo                ITagRepository
+	mediaRepo              IMediaRepository
+	refreshRepo            IRefreshTokenRepository
+	tokenRepo              ITokenRepository
+	recoveryRepo           IRecoveryCodeRepository
+	loginAttemptRepo       ILoginAttemptRepository
+	apiKeyRepo             IAPIKeyRepository
+	roleRepo               IRoleRepository
+	categoryAssignmentRepo ICategoryAssignmentRepository
+	sessionRepo            ISessionRepository
+	identityRepo           IUserIdentityRepository
+	oidcStateRepo          IOIDCStateRepository
+	auditLogRepo           IAuditLogRepository
+	resumableUploadRepo    IResumableUploadRepository
+	directUploadRepo       IDirectUploadRepository
+	mediaUsageRepo         IMediaUsageRepository
+	mu                     sync.RWMutex
 }
 
 // NewRepositoryFactory yeni bir factory oluşturur
@@ -107,6 +121,193 @@ func (f *RepositoryFactory) GetMediaRepository() IMediaRepository {
 	return f.mediaRepo
 }
 
+// GetRefreshTokenRepository RefreshTokenRepository döndürür
+func (f *RepositoryFactory) GetRefreshTokenRepository() IRefreshTokenRepository {
+	f.mu.RLock()
+	if f.refreshRepo != nil {
+		defer f.mu.RUnlock()
+		return f.refreshRepo
+	}
+	f.mu.RUnlock()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.refreshRepo == nil {
+		f.refreshRepo = NewRefreshTokenRepository(f.db)
+	}
+	return f.refreshRepo
+}
+
+// GetTokenRepository TokenRepository döndürür
+func (f *RepositoryFactory) GetTokenRepository() ITokenRepository {
+	f.mu.RLock()
+	if f.tokenRepo != nil {
+		defer f.mu.RUnlock()
+		return f.tokenRepo
+	}
+	f.mu.RUnlock()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.tokenRepo == nil {
+		f.tokenRepo = NewTokenRepository(f.db)
+	}
+	return f.tokenRepo
+}
+
+// GetRecoveryCodeRepository RecoveryCodeRepository döndürür
+func (f *RepositoryFactory) GetRecoveryCodeRepository() IRecoveryCodeRepository {
+	f.mu.RLock()
+	if f.recoveryRepo != nil {
+		defer f.mu.RUnlock()
+		return f.recoveryRepo
+	}
+	f.mu.RUnlock()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.recoveryRepo == nil {
+		f.recoveryRepo = NewRecoveryCodeRepository(f.db)
+	}
+	return f.recoveryRepo
+}
+
+// GetLoginAttemptRepository LoginAttemptRepository döndürür
+func (f *RepositoryFactory) GetLoginAttemptRepository() ILoginAttemptRepository {
+	f.mu.RLock()
+	if f.loginAttemptRepo != nil {
+		defer f.mu.RUnlock()
+		return f.loginAttemptRepo
+	}
+	f.mu.RUnlock()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.loginAttemptRepo == nil {
+		f.loginAttemptRepo = NewLoginAttemptRepository(f.db)
+	}
+	return f.loginAttemptRepo
+}
+
+// GetAPIKeyRepository APIKeyRepository döndürür
+func (f *RepositoryFactory) GetAPIKeyRepository() IAPIKeyRepository {
+	f.mu.RLock()
+	if f.apiKeyRepo != nil {
+		defer f.mu.RUnlock()
+		return f.apiKeyRepo
+	}
+	f.mu.RUnlock()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.apiKeyRepo == nil {
+		f.apiKeyRepo = NewAPIKeyRepository(f.db)
+	}
+	return f.apiKeyRepo
+}
+
+// GetRoleRepository RoleRepository döndürür
+func (f *RepositoryFactory) GetRoleRepository() IRoleRepository {
+	f.mu.RLock()
+	if f.roleRepo != nil {
+		defer f.mu.RUnlock()
+		return f.roleRepo
+	}
+	f.mu.RUnlock()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.roleRepo == nil {
+		f.roleRepo = NewRoleRepository(f.db)
+	}
+	return f.roleRepo
+}
+
+// GetCategoryAssignmentRepository CategoryAssignmentRepository döndürür
+func (f *RepositoryFactory) GetCategoryAssignmentRepository() ICategoryAssignmentRepository {
+	f.mu.RLock()
+	if f.categoryAssignmentRepo != nil {
+		defer f.mu.RUnlock()
+		return f.categoryAssignmentRepo
+	}
+	f.mu.RUnlock()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.categoryAssignmentRepo == nil {
+		f.categoryAssignmentRepo = NewCategoryAssignmentRepository(f.db)
+	}
+	return f.categoryAssignmentRepo
+}
+
+// GetSessionRepository SessionRepository döndürür
+func (f *RepositoryFactory) GetSessionRepository() ISessionRepository {
+	f.mu.RLock()
+	if f.sessionRepo != nil {
+		defer f.mu.RUnlock()
+		return f.sessionRepo
+	}
+	f.mu.RUnlock()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.sessionRepo == nil {
+		f.sessionRepo = NewSessionRepository(f.db)
+	}
+	return f.sessionRepo
+}
+
+// GetUserIdentityRepository UserIdentityRepository döndürür
+func (f *RepositoryFactory) GetUserIdentityRepository() IUserIdentityRepository {
+	f.mu.RLock()
+	if f.identityRepo != nil {
+		defer f.mu.RUnlock()
+		return f.identityRepo
+	}
+	f.mu.RUnlock()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.identityRepo == nil {
+		f.identityRepo = NewUserIdentityRepository(f.db)
+	}
+	return f.identityRepo
+}
+
+// GetOIDCStateRepository OIDCStateRepository döndürür
+func (f *RepositoryFactory) GetOIDCStateRepository() IOIDCStateRepository {
+	f.mu.RLock()
+	if f.oidcStateRepo != nil {
+		defer f.mu.RUnlock()
+		return f.oidcStateRepo
+	}
+	f.mu.RUnlock()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.oidcStateRepo == nil {
+		f.oidcStateRepo = NewOIDCStateRepository(f.db)
+	}
+	return f.oidcStateRepo
+}
+
+// GetAuditLogRepository AuditLogRepository döndürür
+func (f *RepositoryFactory) GetAuditLogRepository() IAuditLogRepository {
+	f.mu.RLock()
+	if f.auditLogRepo != nil {
+		defer f.mu.RUnlock()
+		return f.auditLogRepo
+	}
+	f.mu.RUnlock()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.auditLogRepo == nil {
+		f.auditLogRepo = NewAuditLogRepository(f.db)
+	}
+	return f.auditLogRepo
+}
+
 // SetUserRepository test için UserRepository'yi değiştirir
 func (f *RepositoryFactory) SetUserRepository(repo IUserRepository) {
 	f.mu.Lock()
@@ -141,3 +342,152 @@ func (f *RepositoryFactory) SetMediaRepository(repo IMediaRepository) {
 	defer f.mu.Unlock()
 	f.mediaRepo = repo
 }
+
+// SetRefreshTokenRepository test için RefreshTokenRepository'yi değiştirir
+func (f *RepositoryFactory) SetRefreshTokenRepository(repo IRefreshTokenRepository) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.refreshRepo = repo
+}
+
+// SetTokenRepository test için TokenRepository'yi değiştirir
+func (f *RepositoryFactory) SetTokenRepository(repo ITokenRepository) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.tokenRepo = repo
+}
+
+// SetRecoveryCodeRepository test için RecoveryCodeRepository'yi değiştirir
+func (f *RepositoryFactory) SetRecoveryCodeRepository(repo IRecoveryCodeRepository) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.recoveryRepo = repo
+}
+
+// SetLoginAttemptRepository test için LoginAttemptRepository'yi değiştirir
+func (f *RepositoryFactory) SetLoginAttemptRepository(repo ILoginAttemptRepository) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.loginAttemptRepo = repo
+}
+
+// SetAPIKeyRepository test için APIKeyRepository'yi değiştirir
+func (f *RepositoryFactory) SetAPIKeyRepository(repo IAPIKeyRepository) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.apiKeyRepo = repo
+}
+
+// SetRoleRepository test için RoleRepository'yi değiştirir
+func (f *RepositoryFactory) SetRoleRepository(repo IRoleRepository) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.roleRepo = repo
+}
+
+// SetCategoryAssignmentRepository test için CategoryAssignmentRepository'yi değiştirir
+func (f *RepositoryFactory) SetCategoryAssignmentRepository(repo ICategoryAssignmentRepository) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.categoryAssignmentRepo = repo
+}
+
+// SetSessionRepository test için SessionRepository'yi değiştirir
+func (f *RepositoryFactory) SetSessionRepository(repo ISessionRepository) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sessionRepo = repo
+}
+
+// SetUserIdentityRepository test için UserIdentityRepository'yi değiştirir
+func (f *RepositoryFactory) SetUserIdentityRepository(repo IUserIdentityRepository) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.identityRepo = repo
+}
+
+// SetOIDCStateRepository test için OIDCStateRepository'yi değiştirir
+func (f *RepositoryFactory) SetOIDCStateRepository(repo IOIDCStateRepository) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.oidcStateRepo = repo
+}
+
+// SetAuditLogRepository test için AuditLogRepository'yi değiştirir
+func (f *RepositoryFactory) SetAuditLogRepository(repo IAuditLogRepository) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.auditLogRepo = repo
+}
+
+// GetResumableUploadRepository ResumableUploadRepository döndürür
+func (f *RepositoryFactory) GetResumableUploadRepository() IResumableUploadRepository {
+	f.mu.RLock()
+	if f.resumableUploadRepo != nil {
+		defer f.mu.RUnlock()
+		return f.resumableUploadRepo
+	}
+	f.mu.RUnlock()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.resumableUploadRepo == nil {
+		f.resumableUploadRepo = NewResumableUploadRepository(f.db)
+	}
+	return f.resumableUploadRepo
+}
+
+// SetResumableUploadRepository test için ResumableUploadRepository'yi değiştirir
+func (f *RepositoryFactory) SetResumableUploadRepository(repo IResumableUploadRepository) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.resumableUploadRepo = repo
+}
+
+// GetDirectUploadRepository DirectUploadRepository döndürür
+func (f *RepositoryFactory) GetDirectUploadRepository() IDirectUploadRepository {
+	f.mu.RLock()
+	if f.directUploadRepo != nil {
+		defer f.mu.RUnlock()
+		return f.directUploadRepo
+	}
+	f.mu.RUnlock()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.directUploadRepo == nil {
+		f.directUploadRepo = NewDirectUploadRepository(f.db)
+	}
+	return f.directUploadRepo
+}
+
+// SetDirectUploadRepository test için DirectUploadRepository'yi değiştirir
+func (f *RepositoryFactory) SetDirectUploadRepository(repo IDirectUploadRepository) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.directUploadRepo = repo
+}
+
+// GetMediaUsageRepository MediaUsageRepository döndürür
+func (f *RepositoryFactory) GetMediaUsageRepository() IMediaUsageRepository {
+	f.mu.RLock()
+	if f.mediaUsageRepo != nil {
+		defer f.mu.RUnlock()
+		return f.mediaUsageRepo
+	}
+	f.mu.RUnlock()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.mediaUsageRepo == nil {
+		f.mediaUsageRepo = NewMediaUsageRepository(f.db)
+	}
+	return f.mediaUsageRepo
+}
+
+// SetMediaUsageRepository test için MediaUsageRepository'yi değiştirir
+func (f *RepositoryFactory) SetMediaUsageRepository(repo IMediaUsageRepository) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.mediaUsageRepo = repo
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/username/haber/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ILoginAttemptRepository başarısız giriş sayaçları için repository arayüzü
+type ILoginAttemptRepository interface {
+	Get(key string) (*domain.LoginAttempt, error)
+	RecordFailure(key string, at time.Time, window time.Duration) (*domain.LoginAttempt, error)
+	Lock(key string, until time.Time) error
+	Reset(key string) error
+	DeleteStale(before time.Time) error
+}
+
+// LoginAttemptRepository başarısız giriş sayacı repository implementasyonu
+type LoginAttemptRepository struct {
+	db *gorm.DB
+}
+
+// NewLoginAttemptRepository yeni bir LoginAttemptRepository oluşturur
+func NewLoginAttemptRepository(db *Database) ILoginAttemptRepository {
+	return &LoginAttemptRepository{
+		db: db.DB,
+	}
+}
+
+// Get anahtara ait sayacı getirir
+func (r *LoginAttemptRepository) Get(key string) (*domain.LoginAttempt, error) {
+	var attempt domain.LoginAttempt
+	err := r.db.Where("key = ?", key).First(&attempt).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &domain.NotFoundError{
+				ResourceType: domain.ResourceLoginAttempt,
+				ID:           key,
+			}
+		}
+		return nil, err
+	}
+	return &attempt, nil
+}
+
+// RecordFailure başarısız denemeyi sayar ve güncel sayacı döndürür
+//
+// Son hatalı denemenin üzerinden window kadar süre geçmişse sayaç sıfırdan
+// başlar. Satır kilitlendiği için eşzamanlı denemeler kaybolmaz.
+func (r *LoginAttemptRepository) RecordFailure(key string, at time.Time, window time.Duration) (*domain.LoginAttempt, error) {
+	var attempt domain.LoginAttempt
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("key = ?", key).First(&attempt).Error
+		if err != nil {
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			attempt = domain.LoginAttempt{Key: key}
+		}
+
+		if attempt.LastFailureAt.Before(at.Add(-window)) {
+			attempt.Failures = 0
+		}
+		attempt.Failures++
+		attempt.LastFailureAt = at
+
+		return tx.Save(&attempt).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &attempt, nil
+}
+
+// Lock anahtarı verilen zamana kadar kilitler
+func (r *LoginAttemptRepository) Lock(key string, until time.Time) error {
+	return r.db.Model(&domain.LoginAttempt{}).
+		Where("key = ?", key).
+		Update("locked_until", until).Error
+}
+
+// Reset anahtarın sayacını ve kilidini kaldırır
+func (r *LoginAttemptRepository) Reset(key string) error {
+	return r.db.Where("key = ?", key).Delete(&domain.LoginAttempt{}).Error
+}
+
+// DeleteStale verilen tarihten önce son hatası olan ve kilitli olmayan sayaçları siler
+func (r *LoginAttemptRepository) DeleteStale(before time.Time) error {
+	return r.db.
+		Where("last_failure_at < ? AND (locked_until IS NULL OR locked_until < ?)", before, time.Now()).
+		Delete(&domain.LoginAttempt{}).Error
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/username/haber/internal/domain"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // IMediaRepository medya işlemleri için repository arayüzü
@@ -14,6 +15,32 @@ type IMediaRepository interface {
 	Delete(id uint) error
 	List(offset, limit int) ([]*domain.Media, int64, error)
 	GetByUser(userID uint, offset, limit int) ([]*domain.Media, int64, error)
+	Update(media *domain.Media) error
+	ReplaceTags(media *domain.Media, tags []*domain.Tag) error
+	Search(filter *domain.MediaFilter, offset, limit int) ([]*domain.Media, int64, error)
+	ReplaceDerivatives(mediaID uint, derivatives []*domain.MediaDerivative) error
+	CountByContentTypes(contentTypes []string) (int64, error)
+	ListByContentTypes(contentTypes []string, afterID uint, limit int) ([]*domain.Media, error)
+
+	// Depo nesneleri ve referans sayıları
+	AcquireObject(contentHash string) (*domain.MediaObject, error)
+	CreateObject(object *domain.MediaObject) error
+	EnsureObject(object *domain.MediaObject) error
+	ReleaseObject(objectName string) (int, error)
+	FindByObjectName(objectName string) (*domain.Media, error)
+
+	// İçerik özeti ve kopya raporu
+	SetContentHash(id uint, contentHash string) error
+	CountWithoutHash() (int64, error)
+	ListWithoutHash(afterID uint, limit int) ([]*domain.Media, error)
+	ListDuplicates(offset, limit int) ([]*domain.DuplicateCluster, int64, error)
+	ListByContentHash(contentHash string) ([]*domain.Media, error)
+
+	// Referans çözümleme ve depo taraması
+	FindExistingIDs(ids []uint) ([]uint, error)
+	FindIDsByObjectNames(objectNames []string) (map[string]uint, error)
+	ListObjectNames() ([]string, error)
+	ListAfter(afterID uint, limit int) ([]*domain.Media, error)
 }
 
 // MediaRepository medya repository implementasyonu
@@ -36,7 +63,7 @@ func (r *MediaRepository) Create(media *domain.Media) error {
 // Get ID'ye göre medya getirir
 func (r *MediaRepository) Get(id uint) (*domain.Media, error) {
 	var media domain.Media
-	err := r.db.First(&media, id).Error
+	err := r.db.Preload("Derivatives").Preload("Tags").First(&media, id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, &domain.NotFoundError{
@@ -50,8 +77,16 @@ func (r *MediaRepository) Get(id uint) (*domain.Media, error) {
 }
 
 // Delete medya kaydını siler
+//
+// Etiket bağlantıları da aynı işlemde silinir; media_tags tablosunun yabancı
+// anahtarları silmeyi kendiliğinden yaymaz.
 func (r *MediaRepository) Delete(id uint) error {
-	return r.db.Delete(&domain.Media{}, id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.Media{ID: id}).Association("Tags").Clear(); err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Media{}, id).Error
+	})
 }
 
 // List medya kayıtlarını listeler
@@ -64,7 +99,7 @@ func (r *MediaRepository) List(offset, limit int) ([]*domain.Media, int64, error
 		return nil, 0, err
 	}
 
-	err = r.db.Offset(offset).Limit(limit).Order("created_at DESC").Find(&media).Error
+	err = r.db.Preload("Derivatives").Preload("Tags").Offset(offset).Limit(limit).Order("created_at DESC").Find(&media).Error
 	if err != nil {
 		return nil, 0, err
 	}
@@ -82,10 +117,322 @@ func (r *MediaRepository) GetByUser(userID uint, offset, limit int) ([]*domain.M
 		return nil, 0, err
 	}
 
-	err = r.db.Where("user_id = ?", userID).Offset(offset).Limit(limit).Order("created_at DESC").Find(&media).Error
+	err = r.db.Preload("Derivatives").Preload("Tags").Where("user_id = ?", userID).Offset(offset).Limit(limit).Order("created_at DESC").Find(&media).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return media, count, nil
+}
+
+// Update medya kaydını günceller
+func (r *MediaRepository) Update(media *domain.Media) error {
+	return r.db.Omit("Derivatives", "Tags").Save(media).Error
+}
+
+// ReplaceTags medyanın etiketlerini verilen listeyle değiştirir
+func (r *MediaRepository) ReplaceTags(media *domain.Media, tags []*domain.Tag) error {
+	if err := r.db.Model(media).Association("Tags").Replace(tags); err != nil {
+		return err
+	}
+	media.Tags = tags
+	return nil
+}
+
+// Search medya kütüphanesinde filtreye uyan kayıtları en yeniden eskiye getirir
+func (r *MediaRepository) Search(filter *domain.MediaFilter, offset, limit int) ([]*domain.Media, int64, error) {
+	var media []*domain.Media
+	var count int64
+
+	query := r.db.Model(&domain.Media{})
+	if filter.Query != "" {
+		like := "%" + filter.Query + "%"
+		query = query.Where(
+			"filename ILIKE ? OR alt_text ILIKE ? OR caption ILIKE ? OR credit ILIKE ? OR source ILIKE ? OR copyright ILIKE ?",
+			like, like, like, like, like, like,
+		)
+	}
+	if filter.UserID != nil {
+		query = query.Where("user_id = ?", *filter.UserID)
+	}
+	if filter.TagID != nil {
+		query = query.Where("id IN (?)", r.db.Table("media_tags").Select("media_id").Where("tag_id = ?", *filter.TagID))
+	}
+	if len(filter.ContentTypes) > 0 {
+		query = query.Where("content_type IN ?", filter.ContentTypes)
+	}
+	if filter.MissingAltText {
+		query = query.Where("COALESCE(alt_text, '') = ''")
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("Derivatives").Preload("Tags").
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&media).Error
 	if err != nil {
 		return nil, 0, err
 	}
 
 	return media, count, nil
 }
+
+// ReplaceDerivatives medyanın türev kayıtlarını verilen listeyle değiştirir
+func (r *MediaRepository) ReplaceDerivatives(mediaID uint, derivatives []*domain.MediaDerivative) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("media_id = ?", mediaID).Delete(&domain.MediaDerivative{}).Error; err != nil {
+			return err
+		}
+		if len(derivatives) == 0 {
+			return nil
+		}
+		for _, derivative := range derivatives {
+			derivative.ID = 0
+			derivative.MediaID = mediaID
+		}
+		return tx.Create(&derivatives).Error
+	})
+}
+
+// CountByContentTypes verilen içerik türlerindeki medya sayısını döner
+func (r *MediaRepository) CountByContentTypes(contentTypes []string) (int64, error) {
+	var count int64
+	err := r.db.Model(&domain.Media{}).Where("content_type IN ?", contentTypes).Count(&count).Error
+	return count, err
+}
+
+// ListByContentTypes verilen içerik türlerindeki medyaları ID sırasıyla,
+// afterID'den sonrasını getirir (toplu işlemlerde sayfalama için)
+func (r *MediaRepository) ListByContentTypes(contentTypes []string, afterID uint, limit int) ([]*domain.Media, error) {
+	var media []*domain.Media
+	err := r.db.Preload("Derivatives").Preload("Tags").
+		Where("content_type IN ? AND id > ?", contentTypes, afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&media).Error
+	return media, err
+}
+
+// AcquireObject verilen özetteki depo nesnesinin referans sayısını artırıp
+// nesneyi döner; nesne yoksa NotFoundError döner
+func (r *MediaRepository) AcquireObject(contentHash string) (*domain.MediaObject, error) {
+	var object domain.MediaObject
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("content_hash = ?", contentHash).
+			Order("id ASC").
+			First(&object).Error
+		if err != nil {
+			return err
+		}
+		object.RefCount++
+		return tx.Model(&object).UpdateColumn("ref_count", gorm.Expr("ref_count + 1")).Error
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &domain.NotFoundError{
+				ResourceType: domain.ResourceMedia,
+				ID:           contentHash,
+			}
+		}
+		return nil, err
+	}
+	return &object, nil
+}
+
+// CreateObject yeni yazılan depo nesnesini kaydeder
+func (r *MediaRepository) CreateObject(object *domain.MediaObject) error {
+	return r.db.Create(object).Error
+}
+
+// EnsureObject nesne için kayıt yoksa oluşturur (eski yüklemeler için)
+func (r *MediaRepository) EnsureObject(object *domain.MediaObject) error {
+	return r.db.Where("object_name = ?", object.ObjectName).FirstOrCreate(object).Error
+}
+
+// ReleaseObject nesnenin referans sayısını azaltır ve kalan referans sayısını
+// döner; sayı sıfıra inerse kayıt silinir
+//
+// Kaydı olmayan nesneler (tekilleştirme öncesi yüklemeler) tek referanslı
+// kabul edilir ve 0 döner.
+func (r *MediaRepository) ReleaseObject(objectName string) (int, error) {
+	remaining := 0
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var object domain.MediaObject
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("object_name = ?", objectName).
+			First(&object).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		remaining = object.RefCount - 1
+		if remaining <= 0 {
+			remaining = 0
+			return tx.Delete(&object).Error
+		}
+		return tx.Model(&object).UpdateColumn("ref_count", remaining).Error
+	})
+	return remaining, err
+}
+
+// FindByObjectName verilen depo nesnesini kullanan ilk medyayı getirir
+func (r *MediaRepository) FindByObjectName(objectName string) (*domain.Media, error) {
+	var media domain.Media
+	err := r.db.Preload("Derivatives").Where("object_name = ?", objectName).Order("id ASC").First(&media).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &domain.NotFoundError{
+				ResourceType: domain.ResourceMedia,
+				ID:           objectName,
+			}
+		}
+		return nil, err
+	}
+	return &media, nil
+}
+
+// SetContentHash medyanın içerik özetini kaydeder
+func (r *MediaRepository) SetContentHash(id uint, contentHash string) error {
+	return r.db.Model(&domain.Media{}).Where("id = ?", id).UpdateColumn("content_hash", contentHash).Error
+}
+
+// CountWithoutHash içerik özeti hesaplanmamış medya sayısını döner
+func (r *MediaRepository) CountWithoutHash() (int64, error) {
+	var count int64
+	err := r.db.Model(&domain.Media{}).Where("COALESCE(content_hash, '') = ''").Count(&count).Error
+	return count, err
+}
+
+// ListWithoutHash içerik özeti hesaplanmamış medyaları ID sırasıyla,
+// afterID'den sonrasını getirir
+func (r *MediaRepository) ListWithoutHash(afterID uint, limit int) ([]*domain.Media, error) {
+	var media []*domain.Media
+	err := r.db.Where("COALESCE(content_hash, '') = '' AND id > ?", afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&media).Error
+	return media, err
+}
+
+// ListDuplicates aynı içeriği farklı depo nesnelerinde saklayan medya
+// gruplarını boşa harcanan alana göre büyükten küçüğe getirir
+func (r *MediaRepository) ListDuplicates(offset, limit int) ([]*domain.DuplicateCluster, int64, error) {
+	var clusters []*domain.DuplicateCluster
+	var count int64
+
+	groups := r.db.Model(&domain.Media{}).
+		Select("content_hash, COUNT(*) AS media_count, COUNT(DISTINCT object_name) AS object_count, MAX(filesize) AS filesize").
+		Where("COALESCE(content_hash, '') <> ''").
+		Group("content_hash").
+		Having("COUNT(DISTINCT object_name) > 1")
+
+	if err := r.db.Table("(?) AS duplicates", groups).Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := r.db.Table("(?) AS duplicates", groups).
+		Select("*, (object_count - 1) * filesize AS wasted_bytes").
+		Order("wasted_bytes DESC, content_hash ASC").
+		Offset(offset).
+		Limit(limit).
+		Scan(&clusters).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return clusters, count, nil
+}
+
+// ListByContentHash verilen içerik özetine sahip medyaları getirir
+func (r *MediaRepository) ListByContentHash(contentHash string) ([]*domain.Media, error) {
+	var media []*domain.Media
+	err := r.db.Preload("Derivatives").Preload("Tags").
+		Where("content_hash = ?", contentHash).
+		Order("id ASC").
+		Find(&media).Error
+	return media, err
+}
+
+// FindExistingIDs verilen ID'lerden var olanları döner
+func (r *MediaRepository) FindExistingIDs(ids []uint) ([]uint, error) {
+	var existing []uint
+	if len(ids) == 0 {
+		return existing, nil
+	}
+	err := r.db.Model(&domain.Media{}).Where("id IN ?", ids).Pluck("id", &existing).Error
+	return existing, err
+}
+
+// FindIDsByObjectNames depo anahtarlarını (orijinal veya türev) kullanan
+// medyalara eşler
+//
+// Aynı nesneyi paylaşan medyalardan en eskisi döner.
+func (r *MediaRepository) FindIDsByObjectNames(objectNames []string) (map[string]uint, error) {
+	ids := make(map[string]uint)
+	if len(objectNames) == 0 {
+		return ids, nil
+	}
+
+	var rows []struct {
+		ObjectName string
+		MediaID    uint
+	}
+	err := r.db.Model(&domain.Media{}).
+		Select("object_name, MIN(id) AS media_id").
+		Where("object_name IN ?", objectNames).
+		Group("object_name").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		ids[row.ObjectName] = row.MediaID
+	}
+
+	rows = nil
+	err = r.db.Model(&domain.MediaDerivative{}).
+		Select("object_name, MIN(media_id) AS media_id").
+		Where("object_name IN ?", objectNames).
+		Group("object_name").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		if _, ok := ids[row.ObjectName]; !ok {
+			ids[row.ObjectName] = row.MediaID
+		}
+	}
+
+	return ids, nil
+}
+
+// ListObjectNames medya, türev ve nesne kayıtlarının işaret ettiği tüm depo
+// anahtarlarını döner
+func (r *MediaRepository) ListObjectNames() ([]string, error) {
+	var names []string
+	err := r.db.Raw(`SELECT object_name FROM media
+		UNION SELECT object_name FROM media_derivatives
+		UNION SELECT object_name FROM media_objects`).
+		Scan(&names).Error
+	return names, err
+}
+
+// ListAfter medyaları ID sırasıyla, afterID'den sonrasını getirir
+func (r *MediaRepository) ListAfter(afterID uint, limit int) ([]*domain.Media, error) {
+	var media []*domain.Media
+	err := r.db.Preload("Derivatives").
+		Where("id > ?", afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&media).Error
+	return media, err
+}
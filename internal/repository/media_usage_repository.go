@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"github.com/username/haber/internal/domain"
+	"gorm.io/gorm"
+)
+
+// IMediaUsageRepository medya kullanım kayıtları için repository arayüzü
+type IMediaUsageRepository interface {
+	ReplaceForOwner(ownerType string, ownerID uint, usages []*domain.MediaUsage) error
+	DeleteForOwner(ownerType string, ownerID uint) error
+	ListByMedia(mediaID uint) ([]*domain.MediaUsage, error)
+	CountByMedia(mediaID uint) (int64, error)
+	ListInUse(mediaIDs []uint) ([]uint, error)
+
+	// Kullanım kayıtlarını baştan oluşturmak için medya referansı içeren alanlar
+	CountOwners() (int64, error)
+	ListArticlesAfter(afterID uint, limit int) ([]*domain.Article, error)
+	ListUsersAfter(afterID uint, limit int) ([]*domain.User, error)
+}
+
+// MediaUsageRepository medya kullanım repository implementasyonu
+type MediaUsageRepository struct {
+	db *gorm.DB
+}
+
+// NewMediaUsageRepository yeni bir MediaUsageRepository oluşturur
+func NewMediaUsageRepository(db *Database) IMediaUsageRepository {
+	return &MediaUsageRepository{
+		db: db.DB,
+	}
+}
+
+// ReplaceForOwner kaydın medya kullanımlarını verilen listeyle değiştirir
+func (r *MediaUsageRepository) ReplaceForOwner(ownerType string, ownerID uint, usages []*domain.MediaUsage) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("owner_type = ? AND owner_id = ?", ownerType, ownerID).
+			Delete(&domain.MediaUsage{}).Error
+		if err != nil {
+			return err
+		}
+		if len(usages) == 0 {
+			return nil
+		}
+		for _, usage := range usages {
+			usage.ID = 0
+			usage.OwnerType = ownerType
+			usage.OwnerID = ownerID
+		}
+		return tx.Create(&usages).Error
+	})
+}
+
+// DeleteForOwner kaydın tüm medya kullanımlarını siler
+func (r *MediaUsageRepository) DeleteForOwner(ownerType string, ownerID uint) error {
+	return r.db.Where("owner_type = ? AND owner_id = ?", ownerType, ownerID).
+		Delete(&domain.MediaUsage{}).Error
+}
+
+// ListByMedia medyanın kullanıldığı yerleri getirir
+func (r *MediaUsageRepository) ListByMedia(mediaID uint) ([]*domain.MediaUsage, error) {
+	var usages []*domain.MediaUsage
+	err := r.db.Where("media_id = ?", mediaID).
+		Order("owner_type ASC, owner_id ASC, field ASC").
+		Find(&usages).Error
+	return usages, err
+}
+
+// CountByMedia medyanın kaç yerde kullanıldığını döner
+func (r *MediaUsageRepository) CountByMedia(mediaID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&domain.MediaUsage{}).Where("media_id = ?", mediaID).Count(&count).Error
+	return count, err
+}
+
+// ListInUse verilen medyalardan kullanımda olanların ID'lerini döner
+func (r *MediaUsageRepository) ListInUse(mediaIDs []uint) ([]uint, error) {
+	var ids []uint
+	if len(mediaIDs) == 0 {
+		return ids, nil
+	}
+	err := r.db.Model(&domain.MediaUsage{}).
+		Distinct("media_id").
+		Where("media_id IN ?", mediaIDs).
+		Pluck("media_id", &ids).Error
+	return ids, err
+}
+
+// CountOwners medya referansı içerebilecek makale ve kullanıcı sayısını döner
+func (r *MediaUsageRepository) CountOwners() (int64, error) {
+	var articles, users int64
+	if err := r.db.Model(&domain.Article{}).Count(&articles).Error; err != nil {
+		return 0, err
+	}
+	if err := r.db.Model(&domain.User{}).Count(&users).Error; err != nil {
+		return 0, err
+	}
+	return articles + users, nil
+}
+
+// ListArticlesAfter makalelerin medya alanlarını ID sırasıyla, afterID'den
+// sonrasını getirir
+func (r *MediaUsageRepository) ListArticlesAfter(afterID uint, limit int) ([]*domain.Article, error) {
+	var articles []*domain.Article
+	err := r.db.Select("id", "featured_image", "content").
+		Where("id > ?", afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&articles).Error
+	return articles, err
+}
+
+// ListUsersAfter kullanıcıların medya alanlarını ID sırasıyla, afterID'den
+// sonrasını getirir
+func (r *MediaUsageRepository) ListUsersAfter(afterID uint, limit int) ([]*domain.User, error) {
+	var users []*domain.User
+	err := r.db.Select("id", "profile_image").
+		Where("id > ?", afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&users).Error
+	return users, err
+}
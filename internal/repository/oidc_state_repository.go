@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/username/haber/internal/domain"
+	"gorm.io/gorm"
+)
+
+// IOIDCStateRepository bekleyen SSO girişleri için repository arayüzü
+type IOIDCStateRepository interface {
+	Create(state *domain.OIDCLoginState) error
+	Consume(stateHash string) (*domain.OIDCLoginState, error)
+	DeleteExpired() error
+}
+
+// OIDCStateRepository bekleyen SSO girişleri repository implementasyonu
+type OIDCStateRepository struct {
+	db *gorm.DB
+}
+
+// NewOIDCStateRepository yeni bir OIDCStateRepository oluşturur
+func NewOIDCStateRepository(db *Database) IOIDCStateRepository {
+	return &OIDCStateRepository{
+		db: db.DB,
+	}
+}
+
+// Create yeni bir giriş durumu kaydeder
+func (r *OIDCStateRepository) Create(state *domain.OIDCLoginState) error {
+	return r.db.Create(state).Error
+}
+
+// Consume giriş durumunu getirir ve siler
+//
+// Silme işlemini yalnızca bir istek başarabilir; aynı state ikinci kez
+// kullanılamaz. Süresi dolmuş kayıtlar bulunamadı olarak döner.
+func (r *OIDCStateRepository) Consume(stateHash string) (*domain.OIDCLoginState, error) {
+	notFound := &domain.NotFoundError{
+		ResourceType: domain.ResourceOIDCState,
+		ID:           stateHash,
+	}
+
+	var state domain.OIDCLoginState
+	err := r.db.Where("state_hash = ?", stateHash).First(&state).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, notFound
+		}
+		return nil, err
+	}
+
+	result := r.db.Delete(&domain.OIDCLoginState{}, state.ID)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected != 1 || !time.Now().Before(state.ExpiresAt) {
+		return nil, notFound
+	}
+
+	return &state, nil
+}
+
+// DeleteExpired süresi dolmuş giriş durumlarını siler
+func (r *OIDCStateRepository) DeleteExpired() error {
+	return r.db.Where("expires_at < ?", time.Now()).Delete(&domain.OIDCLoginState{}).Error
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/username/haber/internal/domain"
+	"gorm.io/gorm"
+)
+
+// IRecoveryCodeRepository iki adımlı doğrulama kurtarma kodları için repository arayüzü
+type IRecoveryCodeRepository interface {
+	ReplaceForUser(userID uint, codeHashes []string) error
+	Consume(userID uint, codeHash string) (bool, error)
+	CountUnused(userID uint) (int64, error)
+	DeleteByUser(userID uint) error
+}
+
+// RecoveryCodeRepository kurtarma kodu repository implementasyonu
+type RecoveryCodeRepository struct {
+	db *gorm.DB
+}
+
+// NewRecoveryCodeRepository yeni bir RecoveryCodeRepository oluşturur
+func NewRecoveryCodeRepository(db *Database) IRecoveryCodeRepository {
+	return &RecoveryCodeRepository{
+		db: db.DB,
+	}
+}
+
+// ReplaceForUser kullanıcının tüm kurtarma kodlarını yenileriyle değiştirir
+func (r *RecoveryCodeRepository) ReplaceForUser(userID uint, codeHashes []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&domain.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		codes := make([]domain.RecoveryCode, 0, len(codeHashes))
+		for _, hash := range codeHashes {
+			codes = append(codes, domain.RecoveryCode{
+				UserID:    userID,
+				CodeHash:  hash,
+				CreatedAt: now,
+			})
+		}
+		if len(codes) == 0 {
+			return nil
+		}
+		return tx.Create(&codes).Error
+	})
+}
+
+// Consume kullanılmamış kodu kullanıldı olarak işaretler
+//
+// Güncelleme tek sorguda yapılır; aynı kod eşzamanlı iki istekte kullanılamaz.
+func (r *RecoveryCodeRepository) Consume(userID uint, codeHash string) (bool, error) {
+	result := r.db.Model(&domain.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// CountUnused kullanıcının kalan kurtarma kodu sayısını döndürür
+func (r *RecoveryCodeRepository) CountUnused(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&domain.RecoveryCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+// DeleteByUser kullanıcının tüm kurtarma kodlarını siler
+func (r *RecoveryCodeRepository) DeleteByUser(userID uint) error {
+	return r.db.Where("user_id = ?", userID).Delete(&domain.RecoveryCode{}).Error
+}
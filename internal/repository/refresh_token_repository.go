@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/username/haber/internal/domain"
+	"gorm.io/gorm"
+)
+
+// IRefreshTokenRepository yenileme token işlemleri için repository arayüzü
+type IRefreshTokenRepository interface {
+	Create(token *domain.RefreshToken) error
+	GetByHash(tokenHash string) (*domain.RefreshToken, error)
+	MarkUsed(id uint) (bool, error)
+	RevokeFamily(familyID string) error
+	RevokeByUser(userID uint) error
+	DeleteExpired() error
+}
+
+// RefreshTokenRepository yenileme token repository implementasyonu
+type RefreshTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewRefreshTokenRepository yeni bir RefreshTokenRepository oluşturur
+func NewRefreshTokenRepository(db *Database) IRefreshTokenRepository {
+	return &RefreshTokenRepository{
+		db: db.DB,
+	}
+}
+
+// Create yeni bir yenileme token kaydı oluşturur
+func (r *RefreshTokenRepository) Create(token *domain.RefreshToken) error {
+	return r.db.Create(token).Error
+}
+
+// GetByHash token özetine göre kayıt getirir
+func (r *RefreshTokenRepository) GetByHash(tokenHash string) (*domain.RefreshToken, error) {
+	var token domain.RefreshToken
+	err := r.db.Where("token_hash = ?", tokenHash).First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &domain.NotFoundError{
+				ResourceType: domain.ResourceRefreshToken,
+				ID:           tokenHash,
+			}
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// MarkUsed token'ı kullanıldı olarak işaretler
+//
+// Güncelleme yalnızca token daha önce kullanılmamışsa yapılır; eşzamanlı iki
+// istekten sadece biri true alır.
+func (r *RefreshTokenRepository) MarkUsed(id uint) (bool, error) {
+	result := r.db.Model(&domain.RefreshToken{}).
+		Where("id = ? AND used_at IS NULL AND revoked_at IS NULL", id).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// RevokeFamily aileye ait tüm token'ları iptal eder
+func (r *RefreshTokenRepository) RevokeFamily(familyID string) error {
+	return r.db.Model(&domain.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// RevokeByUser kullanıcıya ait tüm token'ları iptal eder
+func (r *RefreshTokenRepository) RevokeByUser(userID uint) error {
+	return r.db.Model(&domain.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// DeleteExpired süresi dolmuş token kayıtlarını siler
+func (r *RefreshTokenRepository) DeleteExpired() error {
+	return r.db.Where("expires_at < ?", time.Now()).Delete(&domain.RefreshToken{}).Error
+}
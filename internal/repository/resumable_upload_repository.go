@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/username/haber/internal/domain"
+	"gorm.io/gorm"
+)
+
+// IResumableUploadRepository parçalı yüklemeler için repository arayüzü
+type IResumableUploadRepository interface {
+	Create(upload *domain.ResumableUpload) error
+	Get(id string) (*domain.ResumableUpload, error)
+	Update(upload *domain.ResumableUpload) error
+	Delete(id string) error
+	ListExpired(before time.Time, limit int) ([]*domain.ResumableUpload, error)
+}
+
+// ResumableUploadRepository parçalı yükleme repository implementasyonu
+type ResumableUploadRepository struct {
+	db *gorm.DB
+}
+
+// NewResumableUploadRepository yeni bir ResumableUploadRepository oluşturur
+func NewResumableUploadRepository(db *Database) IResumableUploadRepository {
+	return &ResumableUploadRepository{
+		db: db.DB,
+	}
+}
+
+// Create yeni bir parçalı yükleme kaydı oluşturur
+func (r *ResumableUploadRepository) Create(upload *domain.ResumableUpload) error {
+	return r.db.Create(upload).Error
+}
+
+// Get ID'ye göre parçalı yüklemeyi getirir
+func (r *ResumableUploadRepository) Get(id string) (*domain.ResumableUpload, error) {
+	var upload domain.ResumableUpload
+	err := r.db.Where("id = ?", id).First(&upload).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &domain.NotFoundError{
+				ResourceType: domain.ResourceUpload,
+				ID:           id,
+			}
+		}
+		return nil, err
+	}
+	return &upload, nil
+}
+
+// Update parçalı yükleme kaydını günceller
+func (r *ResumableUploadRepository) Update(upload *domain.ResumableUpload) error {
+	return r.db.Save(upload).Error
+}
+
+// Delete parçalı yükleme kaydını siler
+func (r *ResumableUploadRepository) Delete(id string) error {
+	return r.db.Where("id = ?", id).Delete(&domain.ResumableUpload{}).Error
+}
+
+// ListExpired verilen andan önce süresi dolmuş yüklemeleri getirir
+func (r *ResumableUploadRepository) ListExpired(before time.Time, limit int) ([]*domain.ResumableUpload, error) {
+	var uploads []*domain.ResumableUpload
+	err := r.db.Where("expires_at < ?", before).
+		Order("expires_at ASC").
+		Limit(limit).
+		Find(&uploads).Error
+	return uploads, err
+}
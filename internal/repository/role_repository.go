@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/username/haber/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IRoleRepository rol işlemleri için repository arayüzü
+type IRoleRepository interface {
+	Create(role *domain.Role) error
+	GetByName(name string) (*domain.Role, error)
+	List() ([]*domain.Role, error)
+	Update(role *domain.Role) error
+	Delete(name string) error
+	CountUsers(name string) (int64, error)
+	EnsureRoles(roles []*domain.Role) error
+}
+
+// RoleRepository rol repository implementasyonu
+type RoleRepository struct {
+	db *gorm.DB
+}
+
+// NewRoleRepository yeni bir RoleRepository oluşturur
+func NewRoleRepository(db *Database) IRoleRepository {
+	return &RoleRepository{
+		db: db.DB,
+	}
+}
+
+// Create yeni bir rol oluşturur
+func (r *RoleRepository) Create(role *domain.Role) error {
+	return r.db.Create(role).Error
+}
+
+// GetByName ada göre rol getirir
+func (r *RoleRepository) GetByName(name string) (*domain.Role, error) {
+	var role domain.Role
+	err := r.db.Where("name = ?", name).First(&role).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &domain.NotFoundError{
+				ResourceType: domain.ResourceRole,
+				Slug:         name,
+			}
+		}
+		return nil, err
+	}
+	return &role, nil
+}
+
+// List tüm rolleri listeler
+func (r *RoleRepository) List() ([]*domain.Role, error) {
+	var roles []*domain.Role
+	err := r.db.Order("is_system DESC, name ASC").Find(&roles).Error
+	return roles, err
+}
+
+// Update rolü günceller
+func (r *RoleRepository) Update(role *domain.Role) error {
+	return r.db.Save(role).Error
+}
+
+// Delete rolü siler
+func (r *RoleRepository) Delete(name string) error {
+	return r.db.Where("name = ?", name).Delete(&domain.Role{}).Error
+}
+
+// CountUsers role sahip kullanıcı sayısını döndürür
+func (r *RoleRepository) CountUsers(name string) (int64, error) {
+	var count int64
+	err := r.db.Model(&domain.User{}).Where("role = ?", name).Count(&count).Error
+	return count, err
+}
+
+// EnsureRoles eksik rolleri oluşturur, mevcut rollere dokunmaz
+//
+// Yöneticinin düzenlediği yetkiler yeniden başlatmada ezilmez.
+func (r *RoleRepository) EnsureRoles(roles []*domain.Role) error {
+	if len(roles) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoNothing: true,
+	}).Create(&roles).Error
+}
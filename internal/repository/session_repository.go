@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/username/haber/internal/domain"
+	"gorm.io/gorm"
+)
+
+// ISessionRepository oturum işlemleri için repository arayüzü
+type ISessionRepository interface {
+	Create(session *domain.Session) error
+	GetByID(id uint) (*domain.Session, error)
+	GetByFamily(familyID string) (*domain.Session, error)
+	ListActiveByUser(userID uint, now time.Time) ([]*domain.Session, error)
+	Touch(familyID, ip, userAgent string, lastSeenAt, expiresAt time.Time) error
+	RevokeByFamily(familyID string) error
+	RevokeByUser(userID uint) error
+	DeleteExpired(before time.Time) error
+}
+
+// SessionRepository oturum repository implementasyonu
+type SessionRepository struct {
+	db *gorm.DB
+}
+
+// NewSessionRepository yeni bir SessionRepository oluşturur
+func NewSessionRepository(db *Database) ISessionRepository {
+	return &SessionRepository{
+		db: db.DB,
+	}
+}
+
+// Create yeni bir oturum kaydı oluşturur
+func (r *SessionRepository) Create(session *domain.Session) error {
+	return r.db.Create(session).Error
+}
+
+// GetByID ID'ye göre oturum getirir
+func (r *SessionRepository) GetByID(id uint) (*domain.Session, error) {
+	var session domain.Session
+	err := r.db.First(&session, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &domain.NotFoundError{
+				ResourceType: domain.ResourceSession,
+				ID:           id,
+			}
+		}
+		return nil, err
+	}
+	return &session, nil
+}
+
+// GetByFamily yenileme token ailesine göre oturum getirir
+func (r *SessionRepository) GetByFamily(familyID string) (*domain.Session, error) {
+	var session domain.Session
+	err := r.db.Where("family_id = ?", familyID).First(&session).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &domain.NotFoundError{
+				ResourceType: domain.ResourceSession,
+				ID:           familyID,
+			}
+		}
+		return nil, err
+	}
+	return &session, nil
+}
+
+// ListActiveByUser kullanıcının açık oturumlarını son görülme zamanına göre listeler
+func (r *SessionRepository) ListActiveByUser(userID uint, now time.Time) ([]*domain.Session, error) {
+	var sessions []*domain.Session
+	err := r.db.Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now).
+		Order("last_seen_at DESC").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+// Touch oturumun son görülme bilgisini ve bitiş zamanını günceller
+func (r *SessionRepository) Touch(familyID, ip, userAgent string, lastSeenAt, expiresAt time.Time) error {
+	return r.db.Model(&domain.Session{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Updates(map[string]interface{}{
+			"ip":           ip,
+			"user_agent":   userAgent,
+			"last_seen_at": lastSeenAt,
+			"expires_at":   expiresAt,
+		}).Error
+}
+
+// RevokeByFamily oturumu sonlandırılmış olarak işaretler
+func (r *SessionRepository) RevokeByFamily(familyID string) error {
+	return r.db.Model(&domain.Session{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// RevokeByUser kullanıcının tüm oturumlarını sonlandırılmış olarak işaretler
+func (r *SessionRepository) RevokeByUser(userID uint) error {
+	return r.db.Model(&domain.Session{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// DeleteExpired verilen andan önce sona ermiş oturum kayıtlarını siler
+func (r *SessionRepository) DeleteExpired(before time.Time) error {
+	return r.db.Where("expires_at < ?", before).Delete(&domain.Session{}).Error
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/username/haber/internal/domain"
+	"gorm.io/gorm"
+)
+
+// ITokenRepository tek kullanımlık token işlemleri için repository arayüzü
+type ITokenRepository interface {
+	Create(token *domain.Token) error
+	GetByHash(tokenHash, tokenType string) (*domain.Token, error)
+	MarkUsed(id uint) (bool, error)
+	IncrementAttempts(id uint) (int, error)
+	InvalidateUserTokens(userID uint, tokenType string) error
+	CountCreatedSince(userID uint, tokenType string, since time.Time) (int64, error)
+	DeleteExpired() error
+}
+
+// TokenRepository tek kullanımlık token repository implementasyonu
+type TokenRepository struct {
+	db *gorm.DB
+}
+
+// NewTokenRepository yeni bir TokenRepository oluşturur
+func NewTokenRepository(db *Database) ITokenRepository {
+	return &TokenRepository{
+		db: db.DB,
+	}
+}
+
+// Create yeni bir token kaydı oluşturur
+func (r *TokenRepository) Create(token *domain.Token) error {
+	return r.db.Create(token).Error
+}
+
+// GetByHash token özeti ve tipine göre kayıt getirir
+func (r *TokenRepository) GetByHash(tokenHash, tokenType string) (*domain.Token, error) {
+	var token domain.Token
+	err := r.db.Where("token_hash = ? AND type = ?", tokenHash, tokenType).First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &domain.NotFoundError{
+				ResourceType: domain.ResourceToken,
+				ID:           tokenType,
+			}
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// MarkUsed token'ı kullanıldı olarak işaretler
+//
+// Güncelleme yalnızca token daha önce kullanılmamışsa yapılır; eşzamanlı iki
+// istekten sadece biri true alır.
+func (r *TokenRepository) MarkUsed(id uint) (bool, error) {
+	result := r.db.Model(&domain.Token{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// IncrementAttempts token için başarısız deneme sayısını artırır ve yeni değeri döndürür
+func (r *TokenRepository) IncrementAttempts(id uint) (int, error) {
+	err := r.db.Model(&domain.Token{}).
+		Where("id = ?", id).
+		Update("attempts", gorm.Expr("attempts + 1")).Error
+	if err != nil {
+		return 0, err
+	}
+
+	var token domain.Token
+	if err := r.db.Select("attempts").First(&token, id).Error; err != nil {
+		return 0, err
+	}
+	return token.Attempts, nil
+}
+
+// InvalidateUserTokens kullanıcının verilen tipteki kullanılmamış token'larını geçersiz kılar
+func (r *TokenRepository) InvalidateUserTokens(userID uint, tokenType string) error {
+	return r.db.Model(&domain.Token{}).
+		Where("user_id = ? AND type = ? AND used_at IS NULL", userID, tokenType).
+		Update("used_at", time.Now()).Error
+}
+
+// CountCreatedSince kullanıcı için verilen tarihten sonra üretilen token sayısını döndürür
+func (r *TokenRepository) CountCreatedSince(userID uint, tokenType string, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&domain.Token{}).
+		Where("user_id = ? AND type = ? AND created_at > ?", userID, tokenType, since).
+		Count(&count).Error
+	return count, err
+}
+
+// DeleteExpired süresi dolmuş token kayıtlarını siler
+func (r *TokenRepository) DeleteExpired() error {
+	return r.db.Where("expires_at < ?", time.Now()).Delete(&domain.Token{}).Error
+}
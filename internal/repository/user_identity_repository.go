@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/username/haber/internal/domain"
+	"gorm.io/gorm"
+)
+
+// IUserIdentityRepository harici kimlik işlemleri için repository arayüzü
+type IUserIdentityRepository interface {
+	Create(identity *domain.UserIdentity) error
+	GetBySubject(provider, subject string) (*domain.UserIdentity, error)
+	ListByUser(userID uint) ([]*domain.UserIdentity, error)
+	TouchLogin(id uint, email string, at time.Time) error
+}
+
+// UserIdentityRepository harici kimlik repository implementasyonu
+type UserIdentityRepository struct {
+	db *gorm.DB
+}
+
+// NewUserIdentityRepository yeni bir UserIdentityRepository oluşturur
+func NewUserIdentityRepository(db *Database) IUserIdentityRepository {
+	return &UserIdentityRepository{
+		db: db.DB,
+	}
+}
+
+// Create yeni bir harici kimlik kaydı oluşturur
+func (r *UserIdentityRepository) Create(identity *domain.UserIdentity) error {
+	return r.db.Create(identity).Error
+}
+
+// GetBySubject sağlayıcı ve sub değerine göre kayıt getirir
+func (r *UserIdentityRepository) GetBySubject(provider, subject string) (*domain.UserIdentity, error) {
+	var identity domain.UserIdentity
+	err := r.db.Where("provider = ? AND subject = ?", provider, subject).First(&identity).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &domain.NotFoundError{
+				ResourceType: domain.ResourceIdentity,
+				ID:           subject,
+			}
+		}
+		return nil, err
+	}
+	return &identity, nil
+}
+
+// ListByUser kullanıcıya bağlı harici kimlikleri listeler
+func (r *UserIdentityRepository) ListByUser(userID uint) ([]*domain.UserIdentity, error) {
+	var identities []*domain.UserIdentity
+	err := r.db.Where("user_id = ?", userID).Order("created_at").Find(&identities).Error
+	return identities, err
+}
+
+// TouchLogin son giriş zamanını ve sağlayıcıdaki e-posta adresini günceller
+func (r *UserIdentityRepository) TouchLogin(id uint, email string, at time.Time) error {
+	return r.db.Model(&domain.UserIdentity{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"email":         email,
+			"last_login_at": at,
+		}).Error
+}
@@ -16,8 +16,9 @@ type IUserRepository interface {
 	Update(user *domain.User) error
 	Delete(id uint) error
 	List(offset, limit int, filters map[string]interface{}) ([]*domain.User, int64, error)
-	FindByResetToken(token string) (*domain.User, error)
 	Search(query string, offset, limit int) ([]*domain.User, int64, error)
+	ListAuthors(offset, limit int) ([]*domain.User, int64, error)
+	IsAuthor(userID uint) (bool, error)
 }
 
 // UserRepository UserRepository'nin GORM implementasyonu
@@ -118,22 +119,6 @@ func (r *UserRepository) List(offset, limit int, filters map[string]interface{})
 	return users, count, nil
 }
 
-// FindByResetToken reset token'a göre kullanıcı bulur
-func (r *UserRepository) FindByResetToken(token string) (*domain.User, error) {
-	var user domain.User
-	err := r.db.Where("reset_token = ? AND reset_token_expires_at > NOW()", token).First(&user).Error
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, &domain.NotFoundError{
-				ResourceType: domain.ResourceUser,
-				ID:           token,
-			}
-		}
-		return nil, err
-	}
-	return &user, nil
-}
-
 // Search kullanıcıları arar
 func (r *UserRepository) Search(query string, offset, limit int) ([]*domain.User, int64, error) {
 	var users []*domain.User
@@ -152,3 +137,47 @@ func (r *UserRepository) Search(query string, offset, limit int) ([]*domain.User
 
 	return users, count, nil
 }
+
+// ListAuthors yazar sayfası olan kullanıcıları listeler
+//
+// Yazar; etkin hesabı olan ve yayın ekibinde yer alan ya da en az bir makalesi
+// yayımlanmış kullanıcıdır.
+func (r *UserRepository) ListAuthors(offset, limit int) ([]*domain.User, int64, error) {
+	var users []*domain.User
+	var count int64
+
+	query := r.authors()
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := r.authors().
+		Order("full_name ASC").Order("id ASC").
+		Offset(offset).Limit(limit).
+		Find(&users).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return users, count, nil
+}
+
+// IsAuthor kullanıcının yazar sayfası olup olmadığını döndürür
+func (r *UserRepository) IsAuthor(userID uint) (bool, error) {
+	var count int64
+	if err := r.authors().Where("id = ?", userID).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// authors yazar sayılan kullanıcıları seçen sorguyu oluşturur
+func (r *UserRepository) authors() *gorm.DB {
+	published := r.db.Model(&domain.Article{}).
+		Select("author_id").
+		Where("status = ?", domain.ArticleStatusPublished)
+
+	return r.db.Model(&domain.User{}).
+		Where("status = ?", domain.UserStatusActive).
+		Where("role IN ? OR id IN (?)", domain.StaffRoles, published)
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/username/haber/internal/domain"
+	"github.com/username/haber/internal/repository"
+	"github.com/username/haber/pkg/auth"
+)
+
+// apiKeyPrefix anahtarların kolay tanınması ve sızıntı taramaları için ön eki
+const apiKeyPrefix = "hbr_"
+
+// apiKeyTouchInterval son kullanım bilgisinin en sık güncellenme aralığı
+const apiKeyTouchInterval = time.Minute
+
+// errInvalidAPIKey geçersiz, iptal edilmiş veya süresi dolmuş anahtar hatası
+var errInvalidAPIKey = &domain.AuthError{
+	Message: "Geçersiz veya süresi dolmuş API anahtarı",
+}
+
+// IAPIKeyService API anahtarı işlemleri için service interface
+type IAPIKeyService interface {
+	CreateKey(req *domain.CreateAPIKeyRequest, actor *domain.Actor) (*domain.APIKeyCreatedResponse, error)
+	GetKey(id uint) (*domain.APIKey, error)
+	ListKeys(offset, limit int) ([]*domain.APIKey, int64, error)
+	RevokeKey(id uint, actor *domain.Actor) error
+	AuthenticateAPIKey(rawKey, clientIP string) (*domain.APIKey, error)
+}
+
+// APIKeyService API anahtarı servisinin implementasyonu
+type APIKeyService struct {
+	apiKeyRepo repository.IAPIKeyRepository
+	audit      IAuditService
+}
+
+// NewAPIKeyService yeni bir APIKeyService oluşturur
+func NewAPIKeyService(apiKeyRepo repository.IAPIKeyRepository, audit IAuditService) IAPIKeyService {
+	return &APIKeyService{
+		apiKeyRepo: apiKeyRepo,
+		audit:      audit,
+	}
+}
+
+// CreateKey yeni bir API anahtarı üretir; anahtarın kendisi yalnızca bu yanıtta döner
+func (s *APIKeyService) CreateKey(req *domain.CreateAPIKeyRequest, actor *domain.Actor) (*domain.APIKeyCreatedResponse, error) {
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, &domain.ValidationError{
+			Field:   "expires_at",
+			Message: "Son kullanma tarihi gelecekte olmalıdır",
+		}
+	}
+
+	secret, err := auth.GenerateRandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	rawKey := apiKeyPrefix + secret
+
+	now := time.Now()
+	key := &domain.APIKey{
+		Name:        req.Name,
+		Prefix:      rawKey[:len(apiKeyPrefix)+8],
+		KeyHash:     auth.HashToken(rawKey),
+		Scopes:      req.Scopes,
+		AllowedIPs:  req.AllowedIPs,
+		ExpiresAt:   req.ExpiresAt,
+		CreatedByID: actor.UserID,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := s.apiKeyRepo.Create(key); err != nil {
+		return nil, err
+	}
+
+	s.audit.Record(actor, domain.AuditActionCreate, domain.ResourceAPIKey, key.ID, nil, key)
+
+	return &domain.APIKeyCreatedResponse{
+		APIKey: key,
+		Key:    rawKey,
+	}, nil
+}
+
+// GetKey ID'ye göre API anahtarını getirir
+func (s *APIKeyService) GetKey(id uint) (*domain.APIKey, error) {
+	return s.apiKeyRepo.GetByID(id)
+}
+
+// ListKeys API anahtarlarını listeler
+func (s *APIKeyService) ListKeys(offset, limit int) ([]*domain.APIKey, int64, error) {
+	return s.apiKeyRepo.List(offset, limit)
+}
+
+// RevokeKey API anahtarını iptal eder
+func (s *APIKeyService) RevokeKey(id uint, actor *domain.Actor) error {
+	key, err := s.apiKeyRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.apiKeyRepo.Revoke(id); err != nil {
+		return err
+	}
+
+	s.audit.Record(actor, domain.AuditActionRevoke, domain.ResourceAPIKey, id, key, nil)
+	return nil
+}
+
+// AuthenticateAPIKey istekteki anahtarı doğrular ve son kullanım bilgisini günceller
+func (s *APIKeyService) AuthenticateAPIKey(rawKey, clientIP string) (*domain.APIKey, error) {
+	key, err := s.apiKeyRepo.GetByHash(auth.HashToken(rawKey))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, errInvalidAPIKey
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	if !key.IsActive(now) {
+		return nil, errInvalidAPIKey
+	}
+
+	if !key.AllowsIP(clientIP) {
+		return nil, domain.NewForbiddenError("Bu API anahtarı bu IP adresinden kullanılamaz")
+	}
+
+	// Her istekte yazmamak için güncellemeyi seyrekleştir
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= apiKeyTouchInterval || key.LastUsedIP != clientIP {
+		if err := s.apiKeyRepo.TouchLastUsed(key.ID, now, clientIP); err != nil {
+			return nil, err
+		}
+		key.LastUsedAt = &now
+		key.LastUsedIP = clientIP
+	}
+
+	return key, nil
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"log"
 	"strings"
 	"time"
 
@@ -11,11 +12,11 @@ import (
 
 // IArticleService makale işlemleri için service interface
 type IArticleService interface {
-	CreateArticle(article *domain.CreateArticleRequest, authorID uint) (*domain.Article, error)
-	UpdateArticle(id uint, article *domain.UpdateArticleRequest) (*domain.Article, error)
+	CreateArticle(article *domain.CreateArticleRequest, actor *domain.Actor) (*domain.Article, error)
+	UpdateArticle(id uint, article *domain.UpdateArticleRequest, actor *domain.Actor) (*domain.Article, error)
 	GetArticleByID(id uint) (*domain.Article, error)
 	GetArticleBySlug(slug string) (*domain.Article, error)
-	DeleteArticle(id uint) error
+	DeleteArticle(id uint, actor *domain.Actor) error
 	ListArticles(offset, limit int, filters map[string]interface{}) ([]*domain.Article, int64, error)
 	GetFeaturedArticles(limit int) ([]*domain.Article, error)
 	GetArticlesByCategory(categoryID uint, offset, limit int) ([]*domain.Article, int64, error)
@@ -27,18 +28,28 @@ type IArticleService interface {
 type ArticleService struct {
 	articleRepo repository.IArticleRepository
 	tagRepo     repository.ITagRepository
+	policy      IPolicyService
+	audit       IAuditService
+	usage       IMediaUsageService
 }
 
 // NewArticleService yeni bir ArticleService oluşturur
-func NewArticleService(articleRepo repository.IArticleRepository, tagRepo repository.ITagRepository) IArticleService {
+func NewArticleService(articleRepo repository.IArticleRepository, tagRepo repository.ITagRepository, policy IPolicyService, audit IAuditService, usage IMediaUsageService) IArticleService {
 	return &ArticleService{
 		articleRepo: articleRepo,
 		tagRepo:     tagRepo,
+		policy:      policy,
+		audit:       audit,
+		usage:       usage,
 	}
 }
 
 // CreateArticle yeni bir makale oluşturur
-func (s *ArticleService) CreateArticle(req *domain.CreateArticleRequest, authorID uint) (*domain.Article, error) {
+func (s *ArticleService) CreateArticle(req *domain.CreateArticleRequest, actor *domain.Actor) (*domain.Article, error) {
+	if err := s.policy.AuthorizeArticleCreate(actor, req); err != nil {
+		return nil, err
+	}
+
 	// Slug oluştur
 	slugText := slug.Make(req.Title)
 
@@ -68,7 +79,7 @@ func (s *ArticleService) CreateArticle(req *domain.CreateArticleRequest, authorI
 		Content:       req.Content,
 		Summary:       summary,
 		FeaturedImage: req.FeaturedImage,
-		AuthorID:      authorID,
+		AuthorID:      actor.UserID,
 		CategoryID:    req.CategoryID,
 		Status:        req.Status,
 		IsFeatured:    req.IsFeatured,
@@ -85,17 +96,25 @@ func (s *ArticleService) CreateArticle(req *domain.CreateArticleRequest, authorI
 
 	// TODO: tagRepo tanımlanınca etiketleri ekle
 
+	s.syncMediaUsage(article)
+
+	s.audit.Record(actor, domain.AuditActionCreate, domain.ResourceArticle, article.ID, nil, article)
 	return article, nil
 }
 
 // UpdateArticle makaleyi günceller
-func (s *ArticleService) UpdateArticle(id uint, req *domain.UpdateArticleRequest) (*domain.Article, error) {
+func (s *ArticleService) UpdateArticle(id uint, req *domain.UpdateArticleRequest, actor *domain.Actor) (*domain.Article, error) {
 	// Makaleyi bul
 	article, err := s.articleRepo.GetByID(id)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := s.policy.AuthorizeArticleUpdate(actor, article, req); err != nil {
+		return nil, err
+	}
+	before := auditSnapshot(article)
+
 	// Alanları güncelle
 	if req.Title != "" {
 		article.Title = req.Title
@@ -144,6 +163,9 @@ func (s *ArticleService) UpdateArticle(id uint, req *domain.UpdateArticleRequest
 
 	// TODO: tagRepo tanımlanınca etiketleri güncelle
 
+	s.syncMediaUsage(article)
+
+	s.audit.Record(actor, domain.AuditActionUpdate, domain.ResourceArticle, article.ID, before, article)
 	return article, nil
 }
 
@@ -174,8 +196,26 @@ func (s *ArticleService) GetArticleBySlug(slug string) (*domain.Article, error)
 }
 
 // DeleteArticle makaleyi siler
-func (s *ArticleService) DeleteArticle(id uint) error {
-	return s.articleRepo.Delete(id)
+func (s *ArticleService) DeleteArticle(id uint, actor *domain.Actor) error {
+	article, err := s.articleRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.policy.AuthorizeArticleDelete(actor, article); err != nil {
+		return err
+	}
+
+	if err := s.articleRepo.Delete(id); err != nil {
+		return err
+	}
+
+	if err := s.usage.RemoveOwner(domain.MediaOwnerArticle, id); err != nil {
+		log.Printf("Makale %d medya kullanımları silinemedi: %v", id, err)
+	}
+
+	s.audit.Record(actor, domain.AuditActionDelete, domain.ResourceArticle, id, article, nil)
+	return nil
 }
 
 // ListArticles makaleleri listeler
@@ -202,3 +242,12 @@ func (s *ArticleService) GetArticlesByTag(tagID uint, offset, limit int) ([]*dom
 func (s *ArticleService) GetArticlesByAuthor(authorID uint, offset, limit int) ([]*domain.Article, int64, error) {
 	return s.articleRepo.GetByAuthor(authorID, offset, limit)
 }
+
+// syncMediaUsage makalenin kullandığı medyaları kaydeder
+//
+// Makale kaydedildikten sonra çağrılır; hata kaydı geri almaz, loglanır.
+func (s *ArticleService) syncMediaUsage(article *domain.Article) {
+	if err := s.usage.SyncArticle(article); err != nil {
+		log.Printf("Makale %d medya kullanımları güncellenemedi: %v", article.ID, err)
+	}
+}
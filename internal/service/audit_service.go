@@ -0,0 +1,207 @@
+package service
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/username/haber/internal/domain"
+	"github.com/username/haber/internal/repository"
+)
+
+// Denetim kaydı dışa aktarma biçimleri
+const (
+	AuditExportCSV  = "csv"
+	AuditExportJSON = "json"
+)
+
+// auditExportBatchSize dışa aktarmada veritabanından bir seferde okunan kayıt sayısı
+const auditExportBatchSize = 500
+
+// IAuditService denetim kaydı için service interface
+type IAuditService interface {
+	Record(actor *domain.Actor, action string, resourceType domain.ResourceType, resourceID interface{}, before, after interface{})
+	List(filter *domain.AuditLogFilter, offset, limit int) ([]*domain.AuditLog, int64, error)
+	Export(filter *domain.AuditLogFilter, format string, w io.Writer) error
+}
+
+// AuditService denetim kaydı servisinin implementasyonu
+type AuditService struct {
+	auditRepo repository.IAuditLogRepository
+}
+
+// NewAuditService yeni bir AuditService oluşturur
+func NewAuditService(auditRepo repository.IAuditLogRepository) IAuditService {
+	return &AuditService{
+		auditRepo: auditRepo,
+	}
+}
+
+// Record başarıyla tamamlanan bir işlemi kaydeder
+//
+// before ve after kaynağın işlem öncesi ve sonrası halidir; nesne işlem
+// sırasında değiştirilecekse önceki hal auditSnapshot ile alınmalıdır.
+// Değişiklik zaten uygulandığından kayıt hatası işlemi geri almaz, loglanır.
+func (s *AuditService) Record(actor *domain.Actor, action string, resourceType domain.ResourceType, resourceID interface{}, before, after interface{}) {
+	entry := &domain.AuditLog{
+		Action:       action,
+		ResourceType: resourceType,
+		ResourceID:   fmt.Sprint(resourceID),
+		Before:       auditSnapshot(before),
+		After:        auditSnapshot(after),
+		CreatedAt:    time.Now(),
+	}
+
+	if actor != nil {
+		if actor.UserID != 0 {
+			actorID := actor.UserID
+			entry.ActorID = &actorID
+		}
+		if actor.APIKeyID != 0 {
+			keyID := actor.APIKeyID
+			entry.APIKeyID = &keyID
+		}
+		entry.ActorRole = actor.Role
+		entry.IP = actor.IP
+		entry.RequestID = actor.RequestID
+	}
+
+	if err := s.auditRepo.Create(entry); err != nil {
+		log.Printf("Denetim kaydı yazılamadı (%s %s %s): %v", action, resourceType, entry.ResourceID, err)
+	}
+}
+
+// List filtreye uyan denetim kayıtlarını listeler
+func (s *AuditService) List(filter *domain.AuditLogFilter, offset, limit int) ([]*domain.AuditLog, int64, error) {
+	return s.auditRepo.List(filter, offset, limit)
+}
+
+// Export filtreye uyan kayıtları CSV ya da JSON olarak yazar
+func (s *AuditService) Export(filter *domain.AuditLogFilter, format string, w io.Writer) error {
+	switch format {
+	case AuditExportCSV:
+		return s.exportCSV(filter, w)
+	case AuditExportJSON:
+		return s.exportJSON(filter, w)
+	default:
+		return &domain.ValidationError{
+			Field:   "format",
+			Message: "Desteklenen biçimler: csv, json",
+		}
+	}
+}
+
+// exportCSV kayıtları CSV olarak yazar
+func (s *AuditService) exportCSV(filter *domain.AuditLogFilter, w io.Writer) error {
+	writer := csv.NewWriter(w)
+	header := []string{
+		"id", "created_at", "actor_id", "actor_role", "api_key_id", "action",
+		"resource_type", "resource_id", "ip", "request_id", "before", "after",
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	err := s.auditRepo.Each(filter, auditExportBatchSize, func(entries []*domain.AuditLog) error {
+		for _, e := range entries {
+			record := []string{
+				strconv.FormatUint(uint64(e.ID), 10),
+				e.CreatedAt.UTC().Format(time.RFC3339),
+				optionalID(e.ActorID),
+				e.ActorRole,
+				optionalID(e.APIKeyID),
+				e.Action,
+				string(e.ResourceType),
+				e.ResourceID,
+				e.IP,
+				e.RequestID,
+				string(e.Before),
+				string(e.After),
+			}
+			for i := range record {
+				record[i] = csvSafe(record[i])
+			}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+	})
+	if err != nil {
+		return err
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// exportJSON kayıtları tek bir JSON dizisi olarak yazar
+func (s *AuditService) exportJSON(filter *domain.AuditLogFilter, w io.Writer) error {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+
+	first := true
+	err := s.auditRepo.Each(filter, auditExportBatchSize, func(entries []*domain.AuditLog) error {
+		for _, e := range entries {
+			data, err := json.Marshal(e)
+			if err != nil {
+				return err
+			}
+			if !first {
+				if _, err := io.WriteString(w, ","); err != nil {
+					return err
+				}
+			}
+			first = false
+			if _, err := w.Write(data); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "]")
+	return err
+}
+
+// auditSnapshot değeri denetim kaydında saklanacak JSON'a çevirir
+func auditSnapshot(v interface{}) json.RawMessage {
+	if v == nil {
+		return nil
+	}
+	if raw, ok := v.(json.RawMessage); ok {
+		return raw
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil || string(data) == "null" {
+		return nil
+	}
+	return data
+}
+
+// optionalID boş olabilen ID'yi metne çevirir
+func optionalID(id *uint) string {
+	if id == nil {
+		return ""
+	}
+	return strconv.FormatUint(uint64(*id), 10)
+}
+
+// csvSafe hücrenin tablolama programlarında formül olarak çalıştırılmasını engeller
+func csvSafe(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/username/haber/internal/domain"
+)
+
+// loginThrottlePolicy bir sayaç türü için gecikme ve kilitleme eşikleri
+type loginThrottlePolicy struct {
+	// Bu sayıdan sonra her hatalı denemede bekleme süresi ikiye katlanır
+	backoffAfter int
+	backoffBase  time.Duration
+	backoffMax   time.Duration
+	// Bu sayıya ulaşıldığında anahtar lockDuration boyunca kilitlenir
+	lockAfter    int
+	lockDuration time.Duration
+}
+
+// Giriş denemesi sınırları
+var (
+	accountThrottlePolicy = loginThrottlePolicy{
+		backoffAfter: 3,
+		backoffBase:  time.Second,
+		backoffMax:   5 * time.Minute,
+		lockAfter:    10,
+		lockDuration: 15 * time.Minute,
+	}
+	ipThrottlePolicy = loginThrottlePolicy{
+		backoffAfter: 20,
+		backoffBase:  time.Second,
+		backoffMax:   5 * time.Minute,
+		lockAfter:    100,
+		lockDuration: 30 * time.Minute,
+	}
+)
+
+const (
+	// Son hatalı denemeden bu kadar süre sonra sayaç sıfırlanır
+	loginAttemptWindow = time.Hour
+	// Kilit açma bağlantısının geçerlilik süresi
+	accountUnlockTokenTTL = 24 * time.Hour
+)
+
+// errInvalidCredentials kullanıcı adı, e-posta veya şifre hatalı olduğunda dönen ortak hata
+var errInvalidCredentials = &domain.AuthError{
+	Message: "Kullanıcı adı, e-posta veya şifre hatalı",
+}
+
+// UnlockAccount e-postadaki kilit açma bağlantısı ile hesabın kilidini kaldırır
+func (s *AuthService) UnlockAccount(token string) error {
+	record, err := consumeOneTimeToken(s.tokenRepo, token, domain.TokenTypeAccountUnlock)
+	if err != nil {
+		return err
+	}
+
+	return s.loginAttemptRepo.Reset(accountAttemptKey(record.UserID))
+}
+
+// AdminUnlockAccount yönetici olarak kullanıcının giriş kilidini kaldırır
+func (s *AuthService) AdminUnlockAccount(userID uint, actor *domain.Actor) error {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.tokenRepo.InvalidateUserTokens(user.ID, domain.TokenTypeAccountUnlock); err != nil {
+		return err
+	}
+
+	if err := s.loginAttemptRepo.Reset(accountAttemptKey(user.ID)); err != nil {
+		return err
+	}
+
+	s.audit.Record(actor, domain.AuditActionUnlock, domain.ResourceUser, user.ID, nil, nil)
+	return nil
+}
+
+// checkLoginThrottle anahtarın kilitli veya bekleme süresinde olup olmadığını kontrol eder
+func (s *AuthService) checkLoginThrottle(key string, policy loginThrottlePolicy, now time.Time) error {
+	attempt, err := s.loginAttemptRepo.Get(key)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if attempt.IsLocked(now) {
+		return loginThrottledError(attempt.LockedUntil.Sub(now))
+	}
+
+	// Kilit süresi dolduysa sayaç baştan başlar
+	if attempt.LockedUntil != nil {
+		return s.loginAttemptRepo.Reset(key)
+	}
+
+	if wait := policy.backoff(attempt.Failures) - now.Sub(attempt.LastFailureAt); wait > 0 {
+		return loginThrottledError(wait)
+	}
+
+	return nil
+}
+
+// recordLoginFailure hatalı denemeyi sayar ve eşik aşıldıysa anahtarı kilitler
+//
+// Anahtar kilitlendiyse true döner.
+func (s *AuthService) recordLoginFailure(key string, policy loginThrottlePolicy, now time.Time) (bool, error) {
+	attempt, err := s.loginAttemptRepo.RecordFailure(key, now, loginAttemptWindow)
+	if err != nil {
+		return false, err
+	}
+
+	if attempt.Failures < policy.lockAfter {
+		return false, nil
+	}
+
+	return true, s.loginAttemptRepo.Lock(key, now.Add(policy.lockDuration))
+}
+
+// sendUnlockEmail kilitlenen hesabın sahibine kilit açma bağlantısı gönderir
+func (s *AuthService) sendUnlockEmail(user *domain.User) {
+	token, err := createOneTimeToken(s.tokenRepo, user.ID, domain.TokenTypeAccountUnlock, accountUnlockTokenTTL)
+	if err == nil {
+		err = s.email.SendAccountLocked(user, token, accountThrottlePolicy.lockDuration)
+	}
+	if err != nil {
+		log.Printf("Kilit açma e-postası gönderilemedi (kullanıcı %d): %v", user.ID, err)
+	}
+}
+
+// backoff hatalı deneme sayısına göre bir sonraki denemeden önce beklenecek süreyi döndürür
+func (p loginThrottlePolicy) backoff(failures int) time.Duration {
+	if failures < p.backoffAfter {
+		return 0
+	}
+
+	delay := time.Duration(float64(p.backoffBase) * math.Pow(2, float64(failures-p.backoffAfter)))
+	if delay <= 0 || delay > p.backoffMax {
+		return p.backoffMax
+	}
+	return delay
+}
+
+// loginThrottledError bekleme süresini içeren ortak hata
+func loginThrottledError(wait time.Duration) error {
+	seconds := int(math.Ceil(wait.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	return domain.NewTooManyRequestsError(
+		fmt.Sprintf("Çok fazla hatalı giriş denemesi, lütfen %d saniye sonra tekrar deneyin", seconds),
+	)
+}
+
+// accountAttemptKey kayıtlı hesap için sayaç anahtarı
+func accountAttemptKey(userID uint) string {
+	return fmt.Sprintf("user:%d", userID)
+}
+
+// unknownAccountAttemptKey kayıtlı olmayan kullanıcı adı için sayaç anahtarı
+func unknownAccountAttemptKey(username string) string {
+	return "name:" + strings.ToLower(strings.TrimSpace(username))
+}
+
+// ipAttemptKey IP adresi için sayaç anahtarı
+func ipAttemptKey(ip string) string {
+	return "ip:" + ip
+}
@@ -0,0 +1,402 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/username/haber/internal/domain"
+	"github.com/username/haber/pkg/auth"
+)
+
+// İki adımlı doğrulama ayarları
+const (
+	mfaChallengeTTL = 5 * time.Minute
+	// Bir doğrulama token'ı ile yapılabilecek en fazla hatalı deneme
+	mfaChallengeMaxAttempts = 5
+	recoveryCodeCount       = 10
+)
+
+// mfaRequiredSettingKey "security" grubunda yönetici ve editörler için 2FA zorunluluğu ayarı
+const mfaRequiredSettingKey = "require_2fa_staff"
+
+// errInvalidMFACode hatalı TOTP veya kurtarma kodu hatası
+var errInvalidMFACode = &domain.ValidationError{
+	Field:   "code",
+	Message: "Doğrulama kodu hatalı",
+}
+
+// VerifyMFAChallenge giriş sırasındaki ikinci adımı doğrular ve token'ları üretir
+//
+// Kod, kimlik doğrulayıcı uygulamadaki TOTP kodu ya da kullanılmamış bir
+// kurtarma kodu olabilir.
+func (s *AuthService) VerifyMFAChallenge(challengeToken, code string, client *domain.ClientInfo) (*domain.AuthResponse, error) {
+	record, user, err := s.getMFAChallenge(challengeToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.TOTPEnabled {
+		return nil, &domain.ValidationError{
+			Field:   "challenge_token",
+			Message: "İki adımlı doğrulama kurulumu tamamlanmalı",
+		}
+	}
+
+	ok, err := s.checkMFACode(user, code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, s.recordFailedMFAAttempt(record)
+	}
+
+	// Doğrulama token'ı tek kullanımlıktır
+	if err := s.consumeMFAChallenge(record); err != nil {
+		return nil, err
+	}
+
+	return s.authResponse(user, client, record.Remember)
+}
+
+// StartMFAEnrollment giriş yapmış kullanıcı için yeni bir TOTP anahtarı üretir
+//
+// Anahtar, ConfirmMFAEnrollment ile geçerli bir kod gönderilene kadar etkin
+// olmaz; böylece kurulum yarıda kalırsa hesap kilitlenmez.
+func (s *AuthService) StartMFAEnrollment(userID uint) (*domain.MFAEnrollment, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TOTPEnabled {
+		return nil, &domain.ValidationError{
+			Field:   "totp",
+			Message: "İki adımlı doğrulama zaten etkin",
+		}
+	}
+
+	return s.startEnrollment(user)
+}
+
+// ConfirmMFAEnrollment bekleyen TOTP anahtarını doğrular ve etkinleştirir
+func (s *AuthService) ConfirmMFAEnrollment(userID uint, code string) (*domain.MFAEnableResponse, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	codes, err := s.enableMFA(user, code)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.MFAEnableResponse{RecoveryCodes: codes}, nil
+}
+
+// StartChallengeEnrollment 2FA zorunlu olan personelin giriş sırasında kuruluma başlamasını sağlar
+func (s *AuthService) StartChallengeEnrollment(challengeToken string) (*domain.MFAEnrollment, error) {
+	_, user, err := s.getMFAChallenge(challengeToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TOTPEnabled {
+		return nil, &domain.ValidationError{
+			Field:   "totp",
+			Message: "İki adımlı doğrulama zaten etkin",
+		}
+	}
+
+	return s.startEnrollment(user)
+}
+
+// ConfirmChallengeEnrollment giriş sırasındaki kurulumu tamamlar ve token'ları üretir
+func (s *AuthService) ConfirmChallengeEnrollment(challengeToken, code string, client *domain.ClientInfo) (*domain.MFAEnableResponse, error) {
+	record, user, err := s.getMFAChallenge(challengeToken)
+	if err != nil {
+		return nil, err
+	}
+
+	codes, err := s.enableMFA(user, code)
+	if err != nil {
+		if errors.Is(err, errInvalidMFACode) {
+			return nil, s.recordFailedMFAAttempt(record)
+		}
+		return nil, err
+	}
+
+	if err := s.consumeMFAChallenge(record); err != nil {
+		return nil, err
+	}
+
+	tokens, err := s.IssueTokens(user, client, record.Remember)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.MFAEnableResponse{
+		RecoveryCodes: codes,
+		Tokens:        tokens,
+	}, nil
+}
+
+// RegenerateRecoveryCodes geçerli bir kod karşılığında kurtarma kodlarını yeniler
+func (s *AuthService) RegenerateRecoveryCodes(userID uint, code string) ([]string, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.TOTPEnabled {
+		return nil, &domain.ValidationError{
+			Field:   "totp",
+			Message: "İki adımlı doğrulama etkin değil",
+		}
+	}
+
+	ok, err := s.checkMFACode(user, code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errInvalidMFACode
+	}
+
+	return s.generateRecoveryCodes(user.ID)
+}
+
+// DisableMFA parola ve geçerli bir kod ile iki adımlı doğrulamayı kapatır
+func (s *AuthService) DisableMFA(userID uint, password, code string) error {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if !user.TOTPEnabled {
+		return nil
+	}
+
+	if s.isMFARequired(user) {
+		return domain.NewForbiddenError("İki adımlı doğrulama bu hesap için zorunludur")
+	}
+
+	if !auth.CheckPassword(user.PasswordHash, password) {
+		return &domain.ValidationError{
+			Field:   "password",
+			Message: "Şifre hatalı",
+		}
+	}
+
+	ok, err := s.checkMFACode(user, code)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errInvalidMFACode
+	}
+
+	user.TOTPEnabled = false
+	user.TOTPSecret = ""
+	user.TOTPLastStep = 0
+	user.UpdatedAt = time.Now()
+	if err := s.userRepo.Update(user); err != nil {
+		return err
+	}
+
+	return s.recoveryRepo.DeleteByUser(user.ID)
+}
+
+// isMFARequired ayarlara göre kullanıcı için 2FA'nın zorunlu olup olmadığını döndürür
+func (s *AuthService) isMFARequired(user *domain.User) bool {
+	if !user.RequiresMFA() || s.settings == nil {
+		return false
+	}
+
+	security, err := s.settings.GetSettingsByGroup("security")
+	if err != nil {
+		return false
+	}
+	return getBoolOrDefault(security, mfaRequiredSettingKey, false)
+}
+
+// createMFAChallenge parola doğrulandıktan sonra ikinci adım için token üretir
+func (s *AuthService) createMFAChallenge(user *domain.User, remember bool) (*domain.MFAChallenge, error) {
+	token, err := storeOneTimeToken(s.tokenRepo, &domain.Token{
+		UserID:   user.ID,
+		Type:     domain.TokenTypeMFAChallenge,
+		Remember: remember,
+	}, mfaChallengeTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.MFAChallenge{
+		ChallengeToken:     token,
+		ExpiresIn:          int(mfaChallengeTTL.Seconds()),
+		EnrollmentRequired: !user.TOTPEnabled,
+	}, nil
+}
+
+// getMFAChallenge doğrulama token'ını tüketmeden kontrol eder ve kullanıcıyı getirir
+func (s *AuthService) getMFAChallenge(challengeToken string) (*domain.Token, *domain.User, error) {
+	record, err := s.tokenRepo.GetByHash(auth.HashToken(challengeToken), domain.TokenTypeMFAChallenge)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, nil, errInvalidOneTimeToken
+		}
+		return nil, nil, err
+	}
+
+	if !record.IsUsable() {
+		return nil, nil, errInvalidOneTimeToken
+	}
+
+	user, err := s.userRepo.GetByID(record.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return record, user, nil
+}
+
+// consumeMFAChallenge doğrulama token'ını kullanıldı olarak işaretler
+func (s *AuthService) consumeMFAChallenge(record *domain.Token) error {
+	marked, err := s.tokenRepo.MarkUsed(record.ID)
+	if err != nil {
+		return err
+	}
+	if !marked {
+		return errInvalidOneTimeToken
+	}
+	return nil
+}
+
+// recordFailedMFAAttempt hatalı denemeyi sayar, sınır aşılırsa token'ı geçersiz kılar
+func (s *AuthService) recordFailedMFAAttempt(record *domain.Token) error {
+	attempts, err := s.tokenRepo.IncrementAttempts(record.ID)
+	if err != nil {
+		return err
+	}
+
+	if attempts >= mfaChallengeMaxAttempts {
+		if _, err := s.tokenRepo.MarkUsed(record.ID); err != nil {
+			return err
+		}
+		return &domain.AuthError{
+			Message: "Çok fazla hatalı deneme, lütfen tekrar giriş yapın",
+		}
+	}
+
+	return errInvalidMFACode
+}
+
+// checkMFACode TOTP kodunu ya da kurtarma kodunu doğrular
+func (s *AuthService) checkMFACode(user *domain.User, code string) (bool, error) {
+	if step, ok := auth.ValidateTOTP(user.TOTPSecret, code, time.Now(), user.TOTPLastStep); ok {
+		// Aynı kodun tekrar kullanılmaması için adımı sakla
+		user.TOTPLastStep = step
+		user.UpdatedAt = time.Now()
+		return true, s.userRepo.Update(user)
+	}
+
+	return s.recoveryRepo.Consume(user.ID, auth.HashToken(auth.NormalizeRecoveryCode(code)))
+}
+
+// startEnrollment yeni anahtar üretir ve etkinleştirilmeden saklar
+func (s *AuthService) startEnrollment(user *domain.User) (*domain.MFAEnrollment, error) {
+	secret, err := auth.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	user.TOTPSecret = secret
+	user.TOTPLastStep = 0
+	user.UpdatedAt = time.Now()
+	if err := s.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+
+	uri := auth.TOTPURI(secret, s.mfaIssuer(), user.Email)
+	return &domain.MFAEnrollment{
+		Secret:     secret,
+		OTPAuthURI: uri,
+		QRPayload:  uri,
+	}, nil
+}
+
+// enableMFA bekleyen anahtarı ilk kodla doğrular ve kurtarma kodlarını üretir
+func (s *AuthService) enableMFA(user *domain.User, code string) ([]string, error) {
+	if user.TOTPEnabled {
+		return nil, &domain.ValidationError{
+			Field:   "totp",
+			Message: "İki adımlı doğrulama zaten etkin",
+		}
+	}
+	if user.TOTPSecret == "" {
+		return nil, &domain.ValidationError{
+			Field:   "totp",
+			Message: "Önce iki adımlı doğrulama kurulumunu başlatın",
+		}
+	}
+
+	step, ok := auth.ValidateTOTP(user.TOTPSecret, code, time.Now(), user.TOTPLastStep)
+	if !ok {
+		return nil, errInvalidMFACode
+	}
+
+	user.TOTPEnabled = true
+	user.TOTPLastStep = step
+	user.UpdatedAt = time.Now()
+	if err := s.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+
+	return s.generateRecoveryCodes(user.ID)
+}
+
+// generateRecoveryCodes kullanıcının kurtarma kodlarını yeniden üretir
+func (s *AuthService) generateRecoveryCodes(userID uint) ([]string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		code, err := auth.GenerateRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+		hashes = append(hashes, auth.HashToken(code))
+	}
+
+	if err := s.recoveryRepo.ReplaceForUser(userID, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// mfaIssuer kimlik doğrulayıcı uygulamada görünecek site adını döndürür
+func (s *AuthService) mfaIssuer() string {
+	if s.settings == nil {
+		return "Haber"
+	}
+	general, err := s.settings.GetSettingsByGroup("general")
+	if err != nil {
+		return "Haber"
+	}
+	return getOrDefault(general, "site_name", "Haber")
+}
+
+// authResponse kullanıcı için token üretip giriş yanıtını oluşturur
+func (s *AuthService) authResponse(user *domain.User, client *domain.ClientInfo, remember bool) (*domain.AuthResponse, error) {
+	tokens, err := s.IssueTokens(user, client, remember)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.AuthResponse{
+		User:         user,
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		TokenType:    tokens.TokenType,
+		ExpiresIn:    tokens.ExpiresIn,
+	}, nil
+}
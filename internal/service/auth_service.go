@@ -1,8 +1,9 @@
 package service
 
 import (
-	"crypto/rand"
-	"encoding/hex"
+	"errors"
+	"log"
+	"strings"
 	"time"
 
 	"github.com/username/haber/internal/domain"
@@ -12,128 +13,270 @@ import (
 
 // IAuthService auth işlemleri için service interface
 type IAuthService interface {
-	Register(req *domain.RegisterUserRequest) (*domain.User, string, error)
-	Authenticate(username, password string) (*domain.User, error)
-	Login(username, password string, remember bool) (*domain.UserResponse, string, error)
+	Register(req *domain.RegisterUserRequest, client *domain.ClientInfo) (*domain.AuthResponse, error)
+	Authenticate(username, password, clientIP string) (*domain.User, error)
+	Login(username, password string, remember bool, client *domain.ClientInfo) (*domain.LoginResponse, error)
 	ResetPassword(email string) (string, error)
 	ConfirmResetPassword(token, newPassword string) error
 	ForgotPassword(email string) error
 	ChangePassword(userID uint, currentPassword, newPassword string) error
 	GetUserByID(id uint) (*domain.User, error)
+	IssueTokens(user *domain.User, client *domain.ClientInfo, remember bool) (*domain.TokenResponse, error)
+	StartLogin(user *domain.User, remember bool, client *domain.ClientInfo) (*domain.LoginResponse, error)
+	RefreshTokens(refreshToken string, client *domain.ClientInfo) (*domain.TokenResponse, error)
+	Logout(claims *auth.JWTCustomClaims, refreshToken string) error
+	RevokeUserTokens(userID uint, actor *domain.Actor) error
+	ResetLocalCredentials(user *domain.User, actor *domain.Actor) error
+	VerifyEmail(token string) (*domain.User, error)
+	ResendVerificationEmail(userID uint) error
+	RestartEmailVerification(user *domain.User, actor *domain.Actor) error
+	MarkEmailVerified(userID uint, actor *domain.Actor) (*domain.User, error)
+	VerifyMFAChallenge(challengeToken, code string, client *domain.ClientInfo) (*domain.AuthResponse, error)
+	StartMFAEnrollment(userID uint) (*domain.MFAEnrollment, error)
+	ConfirmMFAEnrollment(userID uint, code string) (*domain.MFAEnableResponse, error)
+	StartChallengeEnrollment(challengeToken string) (*domain.MFAEnrollment, error)
+	ConfirmChallengeEnrollment(challengeToken, code string, client *domain.ClientInfo) (*domain.MFAEnableResponse, error)
+	RegenerateRecoveryCodes(userID uint, code string) ([]string, error)
+	DisableMFA(userID uint, password, code string) error
+	UnlockAccount(token string) error
+	AdminUnlockAccount(userID uint, actor *domain.Actor) error
 }
 
+// passwordResetTokenTTL şifre sıfırlama bağlantısının geçerlilik süresi
+const passwordResetTokenTTL = time.Hour
+
+// E-posta doğrulama bağlantısı ayarları
+const (
+	emailVerificationTokenTTL = 48 * time.Hour
+	// Aynı kullanıcıya iki doğrulama e-postası arasında beklenecek süre
+	emailVerificationCooldown = time.Minute
+	// Bir saat içinde gönderilebilecek en fazla doğrulama e-postası
+	emailVerificationHourlyLimit = 5
+)
+
 // AuthService auth servisinin implementasyonu
 type AuthService struct {
-	userRepo repository.IUserRepository
+	userRepo         repository.IUserRepository
+	refreshRepo      repository.IRefreshTokenRepository
+	tokenRepo        repository.ITokenRepository
+	recoveryRepo     repository.IRecoveryCodeRepository
+	loginAttemptRepo repository.ILoginAttemptRepository
+	jwtAuth          *auth.JWTAuth
+	revocations      auth.RevocationStore
+	sessions         ISessionService
+	email            IEmailService
+	settings         ISettingsService
+	audit            IAuditService
 }
 
 // NewAuthService yeni bir AuthService oluşturur
-func NewAuthService(userRepo repository.IUserRepository) IAuthService {
+func NewAuthService(
+	userRepo repository.IUserRepository,
+	refreshRepo repository.IRefreshTokenRepository,
+	tokenRepo repository.ITokenRepository,
+	recoveryRepo repository.IRecoveryCodeRepository,
+	loginAttemptRepo repository.ILoginAttemptRepository,
+	jwtAuth *auth.JWTAuth,
+	revocations auth.RevocationStore,
+	sessions ISessionService,
+	email IEmailService,
+	settings ISettingsService,
+	audit IAuditService,
+) IAuthService {
 	return &AuthService{
-		userRepo: userRepo,
+		userRepo:         userRepo,
+		refreshRepo:      refreshRepo,
+		tokenRepo:        tokenRepo,
+		recoveryRepo:     recoveryRepo,
+		loginAttemptRepo: loginAttemptRepo,
+		jwtAuth:          jwtAuth,
+		revocations:      revocations,
+		sessions:         sessions,
+		email:            email,
+		settings:         settings,
+		audit:            audit,
 	}
 }
 
-// Register yeni kullanıcı kaydı yapar
-func (s *AuthService) Register(req *domain.RegisterUserRequest) (*domain.User, string, error) {
-	// E-posta adresi zaten kayıtlı mı?
-	existingUser, err := s.userRepo.GetByEmail(req.Email)
-	if err == nil && existingUser != nil {
-		return nil, "", domain.ErrDuplicateEntry
-	}
-
-	// Kullanıcı adı zaten kayıtlı mı?
-	existingUser, err = s.userRepo.GetByUsername(req.Username)
-	if err == nil && existingUser != nil {
-		return nil, "", domain.ErrDuplicateEntry
-	}
-
+// Register yeni kullanıcı kaydı yapar ve oturum açar
+//
+// Hesap e-posta doğrulanana kadar "unverified" durumundadır; verilen token'lar
+// okuma için kullanılabilir, yazma işlemleri doğrulamadan sonra açılır.
+func (s *AuthService) Register(req *domain.RegisterUserRequest, client *domain.ClientInfo) (*domain.AuthResponse, error) {
 	// Şifreleri eşleşiyor mu?
 	if req.Password != req.ConfirmPassword {
-		return nil, "", &domain.ValidationError{
+		return nil, &domain.ValidationError{
 			Field:   "confirm_password",
 			Message: "Şifreler eşleşmiyor",
 		}
 	}
 
+	// Giriş e-posta ile de yapılabildiğinden kullanıcı adı e-posta gibi görünemez
+	if strings.Contains(req.Username, "@") {
+		return nil, &domain.ValidationError{
+			Field:   "username",
+			Message: "Kullanıcı adı @ karakteri içeremez",
+		}
+	}
+
+	// E-posta adresi zaten kayıtlı mı?
+	if _, err := s.userRepo.GetByEmail(req.Email); err == nil {
+		return nil, domain.NewDuplicateError(domain.ResourceUser, "email", req.Email)
+	} else if !errors.Is(err, domain.ErrNotFound) {
+		return nil, err
+	}
+
+	// Kullanıcı adı zaten kayıtlı mı?
+	if _, err := s.userRepo.GetByUsername(req.Username); err == nil {
+		return nil, domain.NewDuplicateError(domain.ResourceUser, "username", req.Username)
+	} else if !errors.Is(err, domain.ErrNotFound) {
+		return nil, err
+	}
+
 	// Şifreyi hashle
 	passwordHash, err := auth.HashPassword(req.Password)
 	if err != nil {
-		return nil, "", err
+		return nil, err
 	}
 
 	// Yeni kullanıcı oluştur
+	now := time.Now()
 	user := &domain.User{
 		Username:     req.Username,
 		Email:        req.Email,
 		PasswordHash: passwordHash,
 		FullName:     req.FullName,
 		Role:         domain.RoleUser, // Varsayılan olarak normal kullanıcı
-		CreatedAt:    time.Now(),
-		UpdatedAt:    time.Now(),
+		Status:       domain.UserStatusUnverified,
+		CreatedAt:    now,
+		UpdatedAt:    now,
 	}
 
 	// Kullanıcıyı kaydet
-	err = s.userRepo.Create(user)
-	if err != nil {
-		return nil, "", err
+	if err := s.userRepo.Create(user); err != nil {
+		return nil, err
 	}
 
-	// Burada gerçek uygulamada token üretilecek
-	token := "sample-access-token-for-" + user.Username
+	// Doğrulama e-postası gönderilemese de kayıt tamamlanır, kullanıcı tekrar isteyebilir
+	if err := s.sendVerificationEmail(user); err != nil {
+		log.Printf("Doğrulama e-postası gönderilemedi (kullanıcı %d): %v", user.ID, err)
+	}
 
-	return user, token, nil
+	return s.authResponse(user, client, false)
 }
 
 // Login kullanıcıyı giriş yapar ve token döndürür
-func (s *AuthService) Login(username, password string, remember bool) (*domain.UserResponse, string, error) {
+//
+// Kullanıcı adı veya e-posta adresiyle giriş yapılabilir. remember seçilirse
+// yenileme token'ı daha uzun ömürlü olur. İki adımlı doğrulama açık olan ya da
+// zorunlu tutulan hesaplar için token yerine kısa ömürlü bir doğrulama
+// token'ı döner.
+func (s *AuthService) Login(username, password string, remember bool, client *domain.ClientInfo) (*domain.LoginResponse, error) {
+	if client == nil {
+		client = &domain.ClientInfo{}
+	}
+
 	// Önce kullanıcıyı doğrula
-	user, err := s.Authenticate(username, password)
+	user, err := s.Authenticate(username, password, client.IP)
 	if err != nil {
-		return nil, "", err
+		return nil, err
 	}
 
-	// Kullanıcı yanıtı oluştur
-	userResponse := &domain.UserResponse{
-		ID:           user.ID,
-		Username:     user.Username,
-		Email:        user.Email,
-		FullName:     user.FullName,
-		Role:         user.Role,
-		ProfileImage: user.ProfileImage,
-		CreatedAt:    user.CreatedAt,
+	return s.StartLogin(user, remember, client)
+}
+
+// StartLogin kimliği doğrulanmış kullanıcı için girişi başlatır
+//
+// Şifreyle ya da SSO ile yapılan girişler buradan geçer. İki adımlı doğrulama
+// açık olan ya da zorunlu tutulan hesaplar için token yerine doğrulama
+// token'ı döner.
+func (s *AuthService) StartLogin(user *domain.User, remember bool, client *domain.ClientInfo) (*domain.LoginResponse, error) {
+	// İkinci adım gerekiyorsa token vermeden önce doğrulama iste
+	if user.TOTPEnabled || s.isMFARequired(user) {
+		challenge, err := s.createMFAChallenge(user, remember)
+		if err != nil {
+			return nil, err
+		}
+		return &domain.LoginResponse{
+			MFARequired: true,
+			MFA:         challenge,
+		}, nil
 	}
 
-	// Burada gerçek uygulamada token üretilecek
-	// Hatırla seçeneği varsa daha uzun süreli token verilebilir
-	var token string
-	if remember {
-		token = "sample-long-term-token-for-" + user.Username
-	} else {
-		token = "sample-access-token-for-" + user.Username
+	result, err := s.authResponse(user, client, remember)
+	if err != nil {
+		return nil, err
 	}
 
-	return userResponse, token, nil
+	return &domain.LoginResponse{
+		AuthResponse: result,
+	}, nil
 }
 
 // Authenticate kullanıcı girişini doğrular
-func (s *AuthService) Authenticate(username, password string) (*domain.User, error) {
-	// Kullanıcıyı bul
-	user, err := s.userRepo.GetByUsername(username)
+//
+// Hatalı denemeler hesap ve IP adresi bazında sayılır, eşik aşıldığında
+// bekleme süresi uygulanır ve hesap geçici olarak kilitlenir. Kayıtlı
+// olmayan kullanıcı adları da aynı yanıtı aynı sürede alır.
+func (s *AuthService) Authenticate(username, password, clientIP string) (*domain.User, error) {
+	now := time.Now()
+
+	// Kullanıcıyı kullanıcı adına ya da e-posta adresine göre bul
+	var user *domain.User
+	var err error
+	if strings.Contains(username, "@") {
+		user, err = s.userRepo.GetByEmail(username)
+	} else {
+		user, err = s.userRepo.GetByUsername(username)
+	}
 	if err != nil {
-		return nil, &domain.AuthError{
-			Message: "Kullanıcı adı veya şifre hatalı",
+		if !errors.Is(err, domain.ErrNotFound) {
+			return nil, err
 		}
+		user = nil
 	}
 
-	// Şifreyi kontrol et
-	if !auth.CheckPassword(user.PasswordHash, password) {
-		return nil, &domain.AuthError{
-			Message: "Kullanıcı adı veya şifre hatalı",
+	accountKey := unknownAccountAttemptKey(username)
+	if user != nil {
+		accountKey = accountAttemptKey(user.ID)
+	}
+
+	// Kilitli veya bekleme süresindeki denemeleri şifreye bakmadan reddet
+	if clientIP != "" {
+		if err := s.checkLoginThrottle(ipAttemptKey(clientIP), ipThrottlePolicy, now); err != nil {
+			return nil, err
 		}
 	}
+	if err := s.checkLoginThrottle(accountKey, accountThrottlePolicy, now); err != nil {
+		return nil, err
+	}
 
-	return user, nil
+	// Şifreyi kontrol et; kullanıcı yoksa aynı süreyi harca
+	if user == nil {
+		auth.SimulatePasswordCheck(password)
+	} else if auth.CheckPassword(user.PasswordHash, password) {
+		// IP sayacı ortak ağlardaki diğer denemeler için korunur
+		if err := s.loginAttemptRepo.Reset(accountKey); err != nil {
+			return nil, err
+		}
+		return user, nil
+	}
+
+	// Hatalı denemeyi say
+	if clientIP != "" {
+		if _, err := s.recordLoginFailure(ipAttemptKey(clientIP), ipThrottlePolicy, now); err != nil {
+			return nil, err
+		}
+	}
+	locked, err := s.recordLoginFailure(accountKey, accountThrottlePolicy, now)
+	if err != nil {
+		return nil, err
+	}
+	if locked && user != nil {
+		s.sendUnlockEmail(user)
+	}
+
+	return nil, errInvalidCredentials
 }
 
 // ForgotPassword şifre sıfırlama e-postası gönderir
@@ -151,13 +294,7 @@ func (s *AuthService) ForgotPassword(email string) error {
 		return err
 	}
 
-	// TODO: E-posta gönderme işlemi burada yapılacak
-	// Örnek: emailService.SendPasswordResetEmail(user.Email, token)
-
-	// Şimdilik sadece logla
-	println("Şifre sıfırlama token'ı oluşturuldu: " + token + " kullanıcı: " + user.Email)
-
-	return nil
+	return s.email.SendPasswordReset(user, token, passwordResetTokenTTL)
 }
 
 // ChangePassword kullanıcının şifresini değiştirir
@@ -192,56 +329,364 @@ func (s *AuthService) ChangePassword(userID uint, currentPassword, newPassword s
 // ResetPassword şifre sıfırlama işlemini başlatır
 func (s *AuthService) ResetPassword(email string) (string, error) {
 	// Kullanıcıyı e-posta adresine göre bul
-	_, err := s.userRepo.GetByEmail(email)
+	user, err := s.userRepo.GetByEmail(email)
 	if err != nil {
 		return "", err
 	}
 
-	// Sıfırlama token'ı oluştur
-	token := generateResetToken()
-
-	// TODO: Bu kısım kullanıcı modelinde reset_token ve expires_at eklendiğinde kullanılacak
-	// user.ResetToken = token
-	// user.ResetTokenExpiresAt = time.Now().Add(24 * time.Hour)
-	// err = s.userRepo.Update(user)
-	// if err != nil {
-	// 	return "", err
-	// }
-
-	return token, nil
+	// Tek kullanımlık sıfırlama token'ı oluştur
+	return createOneTimeToken(s.tokenRepo, user.ID, domain.TokenTypePasswordReset, passwordResetTokenTTL)
 }
 
 // ConfirmResetPassword şifre sıfırlama işlemini tamamlar
 func (s *AuthService) ConfirmResetPassword(token, newPassword string) error {
-	// TODO: Bu kısım kullanıcı modelinde reset_token ve expires_at eklendiğinde kullanılacak
-	// user, err := s.userRepo.FindByResetToken(token)
-	// if err != nil {
-	// 	return err
-	// }
+	// Token'ı doğrula ve tüket
+	record, err := consumeOneTimeToken(s.tokenRepo, token, domain.TokenTypePasswordReset)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.GetByID(record.UserID)
+	if err != nil {
+		return err
+	}
 
 	// Şifreyi hashle
-	// passwordHash, err := auth.HashPassword(newPassword)
-	// if err != nil {
-	// 	return err
-	// }
+	passwordHash, err := auth.HashPassword(newPassword)
+	if err != nil {
+		return err
+	}
 
-	// Kullanıcı şifresini güncelle ve token'ı temizle
-	// user.PasswordHash = passwordHash
-	// user.ResetToken = ""
-	// user.ResetTokenExpiresAt = time.Time{}
-	// return s.userRepo.Update(user)
+	// Kullanıcı şifresini güncelle
+	user.PasswordHash = passwordHash
+	user.UpdatedAt = time.Now()
+	if err := s.userRepo.Update(user); err != nil {
+		return err
+	}
+
+	// Şifre değiştiği için açık tüm oturumları sonlandır
+	return s.RevokeUserTokens(user.ID, domain.NewUserActor(user))
+}
+
+// VerifyEmail doğrulama token'ını tüketir ve kullanıcının hesabını etkinleştirir
+func (s *AuthService) VerifyEmail(token string) (*domain.User, error) {
+	record, err := consumeOneTimeToken(s.tokenRepo, token, domain.TokenTypeEmailVerification)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(record.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.markVerified(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// ResendVerificationEmail doğrulama e-postasını sınırlı sıklıkta yeniden gönderir
+func (s *AuthService) ResendVerificationEmail(userID uint) error {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if user.IsEmailVerified() {
+		return &domain.ValidationError{
+			Field:   "email",
+			Message: "E-posta adresi zaten doğrulanmış",
+		}
+	}
+
+	now := time.Now()
+	recent, err := s.tokenRepo.CountCreatedSince(user.ID, domain.TokenTypeEmailVerification, now.Add(-emailVerificationCooldown))
+	if err != nil {
+		return err
+	}
+	if recent > 0 {
+		return domain.NewTooManyRequestsError("Yeni bir doğrulama e-postası istemeden önce lütfen biraz bekleyin")
+	}
+
+	hourly, err := s.tokenRepo.CountCreatedSince(user.ID, domain.TokenTypeEmailVerification, now.Add(-time.Hour))
+	if err != nil {
+		return err
+	}
+	if hourly >= emailVerificationHourlyLimit {
+		return domain.NewTooManyRequestsError("Çok fazla doğrulama e-postası istendi, lütfen daha sonra tekrar deneyin")
+	}
+
+	return s.sendVerificationEmail(user)
+}
+
+// RestartEmailVerification e-posta adresi değişen kullanıcıyı yeniden doğrulamaya alır
+//
+// Kullanıcı kaydı çağıran tarafından doğrulanmamış olarak güncellenmiş olmalıdır.
+// Eski adresle alınmış tüm oturumlar sonlandırılır ve yeni adrese doğrulama
+// e-postası gönderilir. Değişikliği kullanıcının kendisi yaptıysa çağıran yeni
+// token'ları IssueTokens ile üretir.
+func (s *AuthService) RestartEmailVerification(user *domain.User, actor *domain.Actor) error {
+	if err := s.sessions.RevokeAllSessions(user.ID, actor); err != nil {
+		return err
+	}
+
+	// Doğrulama e-postası gönderilemese de kullanıcı tekrar isteyebilir
+	if err := s.sendVerificationEmail(user); err != nil {
+		log.Printf("Doğrulama e-postası gönderilemedi (kullanıcı %d): %v", user.ID, err)
+	}
 
 	return nil
 }
 
+// MarkEmailVerified kullanıcının e-posta adresini yönetici olarak doğrulanmış işaretler
+func (s *AuthService) MarkEmailVerified(userID uint, actor *domain.Actor) (*domain.User, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsEmailVerified() {
+		return user, nil
+	}
+
+	// Bekleyen doğrulama bağlantıları artık gereksiz
+	if err := s.tokenRepo.InvalidateUserTokens(user.ID, domain.TokenTypeEmailVerification); err != nil {
+		return nil, err
+	}
+
+	before := auditSnapshot(user)
+	if err := s.markVerified(user); err != nil {
+		return nil, err
+	}
+
+	s.audit.Record(actor, domain.AuditActionVerifyEmail, domain.ResourceUser, user.ID, before, user)
+	return user, nil
+}
+
 // GetUserByID kullanıcıyı ID'ye göre getirir
 func (s *AuthService) GetUserByID(id uint) (*domain.User, error) {
 	return s.userRepo.GetByID(id)
 }
 
-// generateResetToken token oluşturur
-func generateResetToken() string {
-	b := make([]byte, 16)
-	rand.Read(b)
-	return hex.EncodeToString(b)
+// IssueTokens kullanıcı için yeni bir oturum başlatır ve token çifti üretir
+//
+// Her oturum ayrı bir yenileme token ailesidir. remember seçilirse yenileme
+// token'ı ve oturum daha uzun süre geçerli kalır.
+func (s *AuthService) IssueTokens(user *domain.User, client *domain.ClientInfo, remember bool) (*domain.TokenResponse, error) {
+	familyID, err := auth.GenerateRandomToken(16)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, expiresAt, err := s.issueTokens(user, familyID, remember)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.sessions.StartSession(user.ID, familyID, client, expiresAt); err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// RefreshTokens yenileme token'ını döndürerek yeni bir token çifti üretir
+//
+// Her kullanımda token döndürülür (rotation). Daha önce kullanılmış bir token
+// tekrar gelirse token çalınmış kabul edilir ve ailenin tamamı iptal edilir.
+func (s *AuthService) RefreshTokens(refreshToken string, client *domain.ClientInfo) (*domain.TokenResponse, error) {
+	invalidErr := &domain.AuthError{
+		Message: "Geçersiz veya süresi dolmuş yenileme token'ı",
+	}
+
+	// İmza, süre ve token tipini doğrula
+	if _, err := s.jwtAuth.ValidateRefreshToken(refreshToken); err != nil {
+		return nil, invalidErr
+	}
+
+	// Sunucu tarafındaki kaydı bul
+	stored, err := s.refreshRepo.GetByHash(auth.HashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, invalidErr
+		}
+		return nil, err
+	}
+
+	if stored.RevokedAt != nil {
+		return nil, invalidErr
+	}
+
+	// Kullanılmış token tekrar geldi: aileyi iptal et
+	if stored.UsedAt != nil {
+		return nil, s.revokeReusedFamily(stored.FamilyID)
+	}
+
+	if !time.Now().Before(stored.ExpiresAt) {
+		return nil, invalidErr
+	}
+
+	// Eşzamanlı isteklerden yalnızca biri token'ı tüketebilir
+	marked, err := s.refreshRepo.MarkUsed(stored.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !marked {
+		return nil, s.revokeReusedFamily(stored.FamilyID)
+	}
+
+	// Rol değişikliklerinin hemen yansıması için kullanıcıyı yeniden oku
+	user, err := s.userRepo.GetByID(stored.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			_ = s.sessions.EndSession(stored.FamilyID)
+			return nil, invalidErr
+		}
+		return nil, err
+	}
+
+	tokens, expiresAt, err := s.issueTokens(user, stored.FamilyID, stored.Remember)
+	if err != nil {
+		return nil, err
+	}
+
+	// Oturumun son görülme bilgisi her yenilemede güncellenir
+	if err := s.sessions.TouchSession(user.ID, stored.FamilyID, client, expiresAt); err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// Logout mevcut erişim token'ını ve oturumu sonlandırır
+//
+// Oturum, erişim token'ındaki oturum kimliğinden ya da gönderilen yenileme
+// token'ından bulunur.
+func (s *AuthService) Logout(claims *auth.JWTCustomClaims, refreshToken string) error {
+	// Erişim token'ını süresi dolana kadar iptal et
+	if claims.ID != "" && claims.ExpiresAt != nil {
+		if err := s.revocations.RevokeToken(claims.ID, claims.ExpiresAt.Time); err != nil {
+			return err
+		}
+	}
+
+	if claims.SessionID != "" {
+		return s.sessions.EndSession(claims.SessionID)
+	}
+
+	if refreshToken == "" {
+		return nil
+	}
+
+	// Yenileme token'ının ailesini iptal et, başka kullanıcıya ait token'lara dokunma
+	stored, err := s.refreshRepo.GetByHash(auth.HashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	if stored.UserID != claims.UserID {
+		return nil
+	}
+
+	return s.sessions.EndSession(stored.FamilyID)
+}
+
+// RevokeUserTokens kullanıcının tüm erişim ve yenileme token'larını iptal eder
+func (s *AuthService) RevokeUserTokens(userID uint, actor *domain.Actor) error {
+	if _, err := s.userRepo.GetByID(userID); err != nil {
+		return err
+	}
+
+	return s.sessions.RevokeAllSessions(userID, actor)
+}
+
+// ResetLocalCredentials hesabın yerel şifresini ve 2FA bilgilerini siler, tüm oturumlarını sonlandırır
+//
+// Doğrulanmamış bir hesap adresin gerçek sahibine devredilirken hesabı açan
+// kişinin bıraktığı giriş bilgileri ve bekleyen doğrulama adımları geçersiz kılınır.
+func (s *AuthService) ResetLocalCredentials(user *domain.User, actor *domain.Actor) error {
+	user.PasswordHash = ""
+	user.TOTPEnabled = false
+	user.TOTPSecret = ""
+	user.TOTPLastStep = 0
+	user.UpdatedAt = time.Now()
+	if err := s.userRepo.Update(user); err != nil {
+		return err
+	}
+
+	if err := s.recoveryRepo.DeleteByUser(user.ID); err != nil {
+		return err
+	}
+	if err := s.tokenRepo.InvalidateUserTokens(user.ID, domain.TokenTypeMFAChallenge); err != nil {
+		return err
+	}
+
+	return s.sessions.RevokeAllSessions(user.ID, actor)
+}
+
+// issueTokens verilen aile altında token çifti üretir ve yenileme token'ını saklar
+//
+// Yenileme token'ının bitiş zamanı da döner.
+func (s *AuthService) issueTokens(user *domain.User, familyID string, remember bool) (*domain.TokenResponse, time.Time, error) {
+	refreshTTL := s.jwtAuth.RefreshTokenDuration()
+	if remember {
+		refreshTTL = s.jwtAuth.RememberRefreshTokenDuration()
+	}
+
+	accessToken, refreshToken, err := s.jwtAuth.GenerateSessionTokensWithExpiry(user, familyID, refreshTTL)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	now := time.Now()
+	stored := &domain.RefreshToken{
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		TokenHash: auth.HashToken(refreshToken),
+		ExpiresAt: now.Add(refreshTTL),
+		Remember:  remember,
+		CreatedAt: now,
+	}
+	if err := s.refreshRepo.Create(stored); err != nil {
+		return nil, time.Time{}, err
+	}
+
+	return &domain.TokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(s.jwtAuth.AccessTokenDuration().Seconds()),
+	}, stored.ExpiresAt, nil
+}
+
+// sendVerificationEmail yeni bir doğrulama token'ı üretir ve kullanıcıya gönderir
+func (s *AuthService) sendVerificationEmail(user *domain.User) error {
+	token, err := createOneTimeToken(s.tokenRepo, user.ID, domain.TokenTypeEmailVerification, emailVerificationTokenTTL)
+	if err != nil {
+		return err
+	}
+
+	return s.email.SendEmailVerification(user, token, emailVerificationTokenTTL)
+}
+
+// markVerified kullanıcının hesabını etkinleştirir
+func (s *AuthService) markVerified(user *domain.User) error {
+	now := time.Now()
+	user.Status = domain.UserStatusActive
+	user.EmailVerifiedAt = &now
+	user.UpdatedAt = now
+	return s.userRepo.Update(user)
+}
+
+// revokeReusedFamily yeniden kullanılan token'ın ailesini iptal eder
+func (s *AuthService) revokeReusedFamily(familyID string) error {
+	if err := s.sessions.EndSession(familyID); err != nil {
+		return err
+	}
+	return &domain.AuthError{
+		Message: "Yenileme token'ı daha önce kullanılmış, oturum sonlandırıldı",
+	}
 }
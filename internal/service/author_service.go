@@ -0,0 +1,94 @@
+package service
+
+import (
+	"github.com/username/haber/internal/domain"
+	"github.com/username/haber/internal/repository"
+)
+
+// authorRecentArticleLimit yazar profilinde gösterilen son makale sayısı
+const authorRecentArticleLimit = 5
+
+// IAuthorService herkese açık yazar profilleri için service interface
+type IAuthorService interface {
+	ListAuthors(offset, limit int) ([]*domain.AuthorProfile, int64, error)
+	GetAuthorProfile(username string) (*domain.AuthorProfile, error)
+}
+
+// AuthorService yazar servisinin implementasyonu
+type AuthorService struct {
+	userRepo    repository.IUserRepository
+	articleRepo repository.IArticleRepository
+}
+
+// NewAuthorService yeni bir AuthorService oluşturur
+func NewAuthorService(userRepo repository.IUserRepository, articleRepo repository.IArticleRepository) IAuthorService {
+	return &AuthorService{
+		userRepo:    userRepo,
+		articleRepo: articleRepo,
+	}
+}
+
+// ListAuthors yazarları istatistikleriyle birlikte listeler
+func (s *AuthorService) ListAuthors(offset, limit int) ([]*domain.AuthorProfile, int64, error) {
+	users, total, err := s.userRepo.ListAuthors(offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ids := make([]uint, len(users))
+	for i, user := range users {
+		ids[i] = user.ID
+	}
+
+	stats, err := s.articleRepo.GetAuthorStats(ids)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	profiles := make([]*domain.AuthorProfile, len(users))
+	for i, user := range users {
+		profiles[i] = domain.NewAuthorProfile(user, stats[user.ID])
+	}
+	return profiles, total, nil
+}
+
+// GetAuthorProfile kullanıcı adına göre yazar profilini ve son makalelerini getirir
+//
+// Yazar sayılmayan hesaplar (okuyucular, doğrulanmamış hesaplar) bulunamadı
+// olarak döner; böylece kullanıcı adlarının varlığı dışarıya sızmaz.
+func (s *AuthorService) GetAuthorProfile(username string) (*domain.AuthorProfile, error) {
+	notFound := &domain.NotFoundError{
+		ResourceType: domain.ResourceUser,
+		ID:           username,
+	}
+
+	user, err := s.userRepo.GetByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+
+	isAuthor, err := s.userRepo.IsAuthor(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !isAuthor {
+		return nil, notFound
+	}
+
+	stats, err := s.articleRepo.GetAuthorStats([]uint{user.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	articles, _, err := s.articleRepo.GetByAuthor(user.ID, 0, authorRecentArticleLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := domain.NewAuthorProfile(user, stats[user.ID])
+	profile.RecentArticles = make([]*domain.AuthorArticle, len(articles))
+	for i, article := range articles {
+		profile.RecentArticles[i] = domain.NewAuthorArticle(article)
+	}
+	return profile, nil
+}
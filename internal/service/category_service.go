@@ -13,20 +13,24 @@ type ICategoryService interface {
 	ListCategories(offset, limit int, filters map[string]interface{}) ([]*domain.Category, int64, error)
 	GetCategoryByID(id uint) (*domain.Category, error)
 	GetCategoryBySlug(slug string) (*domain.Category, error)
-	CreateCategory(req *domain.CreateCategoryRequest) (*domain.Category, error)
-	UpdateCategory(id uint, req *domain.UpdateCategoryRequest) (*domain.Category, error)
-	DeleteCategory(id uint) error
+	CreateCategory(req *domain.CreateCategoryRequest, actor *domain.Actor) (*domain.Category, error)
+	UpdateCategory(id uint, req *domain.UpdateCategoryRequest, actor *domain.Actor) (*domain.Category, error)
+	DeleteCategory(id uint, actor *domain.Actor) error
 }
 
 // CategoryService kategori servisinin implementasyonu
 type CategoryService struct {
 	categoryRepo repository.ICategoryRepository
+	policy       IPolicyService
+	audit        IAuditService
 }
 
 // NewCategoryService yeni bir CategoryService oluşturur
-func NewCategoryService(categoryRepo repository.ICategoryRepository) ICategoryService {
+func NewCategoryService(categoryRepo repository.ICategoryRepository, policy IPolicyService, audit IAuditService) ICategoryService {
 	return &CategoryService{
 		categoryRepo: categoryRepo,
+		policy:       policy,
+		audit:        audit,
 	}
 }
 
@@ -46,7 +50,11 @@ func (s *CategoryService) GetCategoryBySlug(slug string) (*domain.Category, erro
 }
 
 // CreateCategory yeni bir kategori oluşturur
-func (s *CategoryService) CreateCategory(req *domain.CreateCategoryRequest) (*domain.Category, error) {
+func (s *CategoryService) CreateCategory(req *domain.CreateCategoryRequest, actor *domain.Actor) (*domain.Category, error) {
+	if err := s.policy.Authorize(actor, domain.PermCategoryManage); err != nil {
+		return nil, err
+	}
+
 	// Slug belirtilmemişse, isimden oluştur
 	if req.Slug == "" {
 		req.Slug = slug.Make(req.Name)
@@ -66,15 +74,21 @@ func (s *CategoryService) CreateCategory(req *domain.CreateCategoryRequest) (*do
 		return nil, err
 	}
 
+	s.audit.Record(actor, domain.AuditActionCreate, domain.ResourceCategory, category.ID, nil, category)
 	return category, nil
 }
 
 // UpdateCategory kategoriyi günceller
-func (s *CategoryService) UpdateCategory(id uint, req *domain.UpdateCategoryRequest) (*domain.Category, error) {
+func (s *CategoryService) UpdateCategory(id uint, req *domain.UpdateCategoryRequest, actor *domain.Actor) (*domain.Category, error) {
+	if err := s.policy.Authorize(actor, domain.PermCategoryManage); err != nil {
+		return nil, err
+	}
+
 	category, err := s.categoryRepo.GetByID(id)
 	if err != nil {
 		return nil, err
 	}
+	before := auditSnapshot(category)
 
 	if req.Name != "" {
 		category.Name = req.Name
@@ -98,10 +112,25 @@ func (s *CategoryService) UpdateCategory(id uint, req *domain.UpdateCategoryRequ
 		return nil, err
 	}
 
+	s.audit.Record(actor, domain.AuditActionUpdate, domain.ResourceCategory, category.ID, before, category)
 	return category, nil
 }
 
 // DeleteCategory kategoriyi siler
-func (s *CategoryService) DeleteCategory(id uint) error {
-	return s.categoryRepo.Delete(id)
+func (s *CategoryService) DeleteCategory(id uint, actor *domain.Actor) error {
+	if err := s.policy.Authorize(actor, domain.PermCategoryManage); err != nil {
+		return err
+	}
+
+	category, err := s.categoryRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.categoryRepo.Delete(id); err != nil {
+		return err
+	}
+
+	s.audit.Record(actor, domain.AuditActionDelete, domain.ResourceCategory, id, category, nil)
+	return nil
 }
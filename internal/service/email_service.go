@@ -0,0 +1,235 @@
+package service
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/username/haber/internal/domain"
+	"github.com/username/haber/pkg/mailer"
+)
+
+//go:embed templates/email
+var emailTemplates embed.FS
+
+// E-posta şablon adları
+const (
+	emailTemplatePasswordReset     = "password_reset"
+	emailTemplateEmailVerification = "email_verification"
+	emailTemplateModerationNotice  = "moderation_notice"
+	emailTemplateAccountLocked     = "account_locked"
+)
+
+// defaultEmailLocale şablonu bulunmayan diller için kullanılan dil
+const defaultEmailLocale = "tr"
+
+// E-posta gönderim sürücüleri ("email" ayar grubundaki mail_driver anahtarı)
+const (
+	MailDriverSMTP = "smtp"
+	MailDriverFile = "file"
+)
+
+// ModerationNotice içerik moderasyon sonucunu bildiren e-posta verisi
+type ModerationNotice struct {
+	ContentTitle string
+	Approved     bool
+	Reason       string
+	Path         string
+}
+
+// IEmailService uygulama e-postaları için service interface
+type IEmailService interface {
+	SendPasswordReset(user *domain.User, token string, ttl time.Duration) error
+	SendEmailVerification(user *domain.User, token string, ttl time.Duration) error
+	SendModerationNotice(user *domain.User, notice *ModerationNotice) error
+	SendAccountLocked(user *domain.User, token string, lockDuration time.Duration) error
+}
+
+// EmailService şablonları işleyip mailer üzerinden gönderen servis
+type EmailService struct {
+	settings ISettingsService
+	mailer   mailer.Mailer
+	renderer *mailer.Renderer
+}
+
+// NewEmailService yeni bir EmailService oluşturur
+func NewEmailService(settings ISettingsService, m mailer.Mailer) IEmailService {
+	templates, err := fs.Sub(emailTemplates, "templates/email")
+	if err != nil {
+		// Gömülü dizin derleme zamanında sabittir
+		panic(err)
+	}
+
+	return &EmailService{
+		settings: settings,
+		mailer:   m,
+		renderer: mailer.NewRenderer(templates, defaultEmailLocale),
+	}
+}
+
+// SendPasswordReset şifre sıfırlama bağlantısını gönderir
+func (s *EmailService) SendPasswordReset(user *domain.User, token string, ttl time.Duration) error {
+	site := s.siteInfo()
+	return s.send(user, emailTemplatePasswordReset, site, map[string]interface{}{
+		"Link":      site.link("/reset-password", url.Values{"token": {token}}),
+		"ExpiresIn": formatEmailDuration(site.Locale, ttl),
+	})
+}
+
+// SendEmailVerification e-posta doğrulama bağlantısını gönderir
+func (s *EmailService) SendEmailVerification(user *domain.User, token string, ttl time.Duration) error {
+	site := s.siteInfo()
+	return s.send(user, emailTemplateEmailVerification, site, map[string]interface{}{
+		"Link":      site.link("/verify-email", url.Values{"token": {token}}),
+		"ExpiresIn": formatEmailDuration(site.Locale, ttl),
+	})
+}
+
+// SendModerationNotice içerik moderasyon sonucunu yazarına bildirir
+func (s *EmailService) SendModerationNotice(user *domain.User, notice *ModerationNotice) error {
+	site := s.siteInfo()
+
+	link := ""
+	if notice.Path != "" {
+		link = site.link(notice.Path, nil)
+	}
+
+	return s.send(user, emailTemplateModerationNotice, site, map[string]interface{}{
+		"ContentTitle": notice.ContentTitle,
+		"Approved":     notice.Approved,
+		"Reason":       notice.Reason,
+		"Link":         link,
+	})
+}
+
+// SendAccountLocked hesap kilitlendiğinde kilit açma bağlantısını gönderir
+func (s *EmailService) SendAccountLocked(user *domain.User, token string, lockDuration time.Duration) error {
+	site := s.siteInfo()
+	return s.send(user, emailTemplateAccountLocked, site, map[string]interface{}{
+		"Link":         site.link("/unlock-account", url.Values{"token": {token}}),
+		"LockDuration": formatEmailDuration(site.Locale, lockDuration),
+	})
+}
+
+// send şablonu işler ve kullanıcıya gönderir
+func (s *EmailService) send(user *domain.User, name string, site emailSite, data map[string]interface{}) error {
+	data["SiteName"] = site.Name
+	data["Name"] = user.FullName
+	if user.FullName == "" {
+		data["Name"] = user.Username
+	}
+
+	msg, err := s.renderer.Render(site.Locale, name, data)
+	if err != nil {
+		return err
+	}
+	msg.To = []string{user.Email}
+
+	return s.mailer.Send(context.Background(), msg)
+}
+
+// emailSite e-postalarda kullanılan site bilgileri
+type emailSite struct {
+	Name   string
+	URL    string
+	Locale string
+}
+
+// link site adresine göre mutlak bağlantı oluşturur
+func (s emailSite) link(path string, query url.Values) string {
+	link := strings.TrimRight(s.URL, "/") + path
+	if len(query) > 0 {
+		link += "?" + query.Encode()
+	}
+	return link
+}
+
+// siteInfo genel ayarlardan site bilgilerini okur
+func (s *EmailService) siteInfo() emailSite {
+	general, err := s.settings.GetSettingsByGroup("general")
+	if err != nil {
+		general = map[string]string{}
+	}
+
+	return emailSite{
+		Name:   getOrDefault(general, "site_name", "Haber"),
+		URL:    getOrDefault(general, "site_url", "http://localhost:8080"),
+		Locale: getOrDefault(general, "default_language", defaultEmailLocale),
+	}
+}
+
+// formatEmailDuration süreyi e-posta diline göre okunur hale getirir
+func formatEmailDuration(locale string, d time.Duration) string {
+	hours := int(d.Hours())
+	if hours >= 1 && d%time.Hour == 0 {
+		if locale == "en" {
+			if hours == 1 {
+				return "1 hour"
+			}
+			return fmt.Sprintf("%d hours", hours)
+		}
+		return fmt.Sprintf("%d saat", hours)
+	}
+
+	minutes := int(d.Minutes())
+	if locale == "en" {
+		return fmt.Sprintf("%d minutes", minutes)
+	}
+	return fmt.Sprintf("%d dakika", minutes)
+}
+
+// SettingsMailer her gönderimde "email" ayar grubunu okuyarak uygun mailer'ı seçer
+//
+// Böylece yönetim panelinden yapılan SMTP değişiklikleri yeniden başlatma
+// gerektirmeden uygulanır. SMTP sunucusu tanımlı değilse ya da mail_driver
+// "file" ise postalar outbox dizinine yazılır.
+type SettingsMailer struct {
+	settings  ISettingsService
+	outboxDir string
+}
+
+// NewSettingsMailer yeni bir SettingsMailer oluşturur
+func NewSettingsMailer(settings ISettingsService, outboxDir string) *SettingsMailer {
+	return &SettingsMailer{
+		settings:  settings,
+		outboxDir: outboxDir,
+	}
+}
+
+// Send mesajı ayarlarda seçili sürücü ile gönderir
+func (m *SettingsMailer) Send(ctx context.Context, msg *mailer.Message) error {
+	inner, err := m.resolve()
+	if err != nil {
+		return err
+	}
+	return inner.Send(ctx, msg)
+}
+
+// resolve güncel ayarlara göre mailer oluşturur
+func (m *SettingsMailer) resolve() (mailer.Mailer, error) {
+	cfg, err := m.settings.GetSettingsByGroup("email")
+	if err != nil {
+		return nil, err
+	}
+
+	fromEmail := getOrDefault(cfg, "from_email", "no-reply@localhost")
+	host := cfg["smtp_host"]
+
+	if getOrDefault(cfg, "mail_driver", MailDriverSMTP) == MailDriverFile || host == "" {
+		return mailer.NewFileMailer(m.outboxDir, fromEmail)
+	}
+
+	return mailer.NewSMTPMailer(mailer.SMTPConfig{
+		Host:       host,
+		Port:       getIntOrDefault(cfg, "smtp_port", 587),
+		Username:   cfg["smtp_username"],
+		Password:   cfg["smtp_password"],
+		Encryption: getOrDefault(cfg, "smtp_encryption", mailer.EncryptionSTARTTLS),
+		FromEmail:  fromEmail,
+		FromName:   getOrDefault(cfg, "from_name", "Haber"),
+	}), nil
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/username/haber/internal/domain"
+	"github.com/username/haber/pkg/storage"
+)
+
+// GetContent medyanın orijinal dosyasını veya belirtilen türevini sunmak için
+// gereken bilgileri döner; preset boşsa orijinal dosya döner
+//
+// Orijinal dosyanın anahtarı hiç yeniden yazılmadığından içeriği değişmez;
+// ETag içerik özetinden (eski yüklemelerde anahtardan) üretilir. Türevler
+// yeniden üretildiğinde aynı anahtara yazılır, ETag'leri üretim zamanını da içerir.
+func (s *MediaService) GetContent(media *domain.Media, preset string, width int) (*domain.MediaContent, error) {
+	if preset == "" {
+		etag := media.ContentHash
+		if etag == "" {
+			etag = hashBytes([]byte(fmt.Sprintf("%s:%d", media.ObjectName, media.Filesize)))[:32]
+		}
+		return &domain.MediaContent{
+			ObjectName:   media.ObjectName,
+			ContentType:  media.ContentType,
+			ETag:         `"` + etag + `"`,
+			LastModified: media.CreatedAt,
+			Immutable:    true,
+		}, nil
+	}
+
+	for _, derivative := range media.Derivatives {
+		if derivative.Preset == preset && derivative.Width == width {
+			etag := hashBytes([]byte(fmt.Sprintf("%s:%d:%d",
+				derivative.ObjectName, derivative.Filesize, derivative.CreatedAt.UnixNano())))
+			return &domain.MediaContent{
+				ObjectName:   derivative.ObjectName,
+				ContentType:  derivative.ContentType,
+				ETag:         `"` + etag[:32] + `"`,
+				LastModified: derivative.CreatedAt,
+			}, nil
+		}
+	}
+
+	return nil, &domain.NotFoundError{
+		ResourceType: domain.ResourceMedia,
+		ID:           fmt.Sprintf("%d/%s/%d", media.ID, preset, width),
+	}
+}
+
+// StatContent dosyanın depodaki bilgilerini içeriği okumadan döner
+func (s *MediaService) StatContent(content *domain.MediaContent) (*storage.ObjectInfo, error) {
+	if s.storage == nil {
+		return nil, domain.NewServiceUnavailableError(ErrStorageUnavailable, ErrStorageUnavailable.Error())
+	}
+
+	info, err := s.storage.Stat(content.ObjectName)
+	if err != nil {
+		return nil, contentError(content, err)
+	}
+	return withContentType(info, content), nil
+}
+
+// OpenContent dosyayı depodan okumak için açar; rng nil ise dosyanın tamamı okunur
+//
+// İçerik belleğe alınmadan akış olarak döner. Okuyucuyu kapatmak çağıranın
+// sorumluluğundadır.
+func (s *MediaService) OpenContent(content *domain.MediaContent, rng *storage.Range) (io.ReadCloser, *storage.ObjectInfo, error) {
+	if s.storage == nil {
+		return nil, nil, domain.NewServiceUnavailableError(ErrStorageUnavailable, ErrStorageUnavailable.Error())
+	}
+
+	reader, info, err := s.storage.Get(content.ObjectName, rng)
+	if err != nil {
+		return nil, nil, contentError(content, err)
+	}
+	return reader, withContentType(info, content), nil
+}
+
+// withContentType kayıttaki içerik türünü depo bilgisine yazar; kayıttaki tür
+// depodan gelen tahminden daha güvenilirdir
+func withContentType(info *storage.ObjectInfo, content *domain.MediaContent) *storage.ObjectInfo {
+	if content.ContentType != "" {
+		info.ContentType = content.ContentType
+	}
+	return info
+}
+
+// contentError depo hatalarını istemciye dönülecek hatalara çevirir
+func contentError(content *domain.MediaContent, err error) error {
+	if errors.Is(err, storage.ErrNotFound) {
+		return &domain.NotFoundError{
+			ResourceType: domain.ResourceMedia,
+			ID:           content.ObjectName,
+		}
+	}
+	if errors.Is(err, storage.ErrInvalidRange) {
+		return &domain.ValidationError{
+			Field:   "Range",
+			Message: "İstenen bayt aralığı dosyanın dışında",
+		}
+	}
+	return err
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/username/haber/internal/domain"
+)
+
+// hashContent içeriği okuyarak SHA-256 özetini hex olarak döner
+func hashContent(r io.Reader) (string, error) {
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, r); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// hashBytes bellekteki içeriğin SHA-256 özetini hex olarak döner
+func hashBytes(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// storeObject içeriği depoya yazar; aynı özetli bir nesne zaten varsa
+// içerik tekrar yazılmaz, mevcut nesnenin referans sayısı artırılır
+//
+// İkinci dönüş değeri mevcut bir nesnenin kullanılıp kullanılmadığını
+// belirtir. Aynı içeriğin eş zamanlı yüklemeleri ayrı nesneler
+// oluşturabilir; bunlar kopya raporunda görünür.
+func (s *MediaService) storeObject(folder, ext, contentType, contentHash string, body io.Reader, size int64) (*domain.MediaObject, bool, error) {
+	object, err := s.mediaRepo.AcquireObject(contentHash)
+	if err == nil {
+		return object, true, nil
+	}
+	var notFound *domain.NotFoundError
+	if !errors.As(err, &notFound) {
+		return nil, false, err
+	}
+
+	objectName, err := newMediaObjectName(folder, ext)
+	if err != nil {
+		return nil, false, err
+	}
+
+	info, err := s.storage.Put(objectName, body, size, contentType)
+	if err != nil {
+		return nil, false, err
+	}
+
+	object = &domain.MediaObject{
+		ObjectName:  objectName,
+		ContentHash: contentHash,
+		ContentType: contentType,
+		Filesize:    uint(info.Size),
+		RefCount:    1,
+	}
+	if err := s.mediaRepo.CreateObject(object); err != nil {
+		_ = s.storage.Delete(objectName)
+		return nil, false, err
+	}
+
+	return object, false, nil
+}
+
+// shareDerivatives paylaşılan nesneyi kullanan mevcut medyanın boyutlarını ve
+// türevlerini yeni medyaya kopyalar; kopyalanacak medya yoksa false döner
+//
+// Türev nesneleri de paylaşılır, yalnızca kayıtları çoğaltılır. Türevleri
+// hiç üretilmemiş ya da boyutları bilinmeyen medya (ör. türev desteğinden
+// önceki yüklemeler) kopyalanmaz; çağıran türevleri yeniden üretir.
+func (s *MediaService) shareDerivatives(media *domain.Media) ([]*domain.MediaDerivative, bool) {
+	sibling, err := s.mediaRepo.FindByObjectName(media.ObjectName)
+	if err != nil {
+		return nil, false
+	}
+	if len(sibling.Derivatives) == 0 || sibling.Width == 0 || sibling.Height == 0 {
+		return nil, false
+	}
+
+	media.Width, media.Height = sibling.Width, sibling.Height
+	derivatives := make([]*domain.MediaDerivative, 0, len(sibling.Derivatives))
+	for _, derivative := range sibling.Derivatives {
+		clone := *derivative
+		clone.ID = 0
+		clone.MediaID = 0
+		derivatives = append(derivatives, &clone)
+	}
+	return derivatives, true
+}
+
+// releaseObject medyanın depo nesnesindeki referansını bırakır; son referans
+// ise nesneyi ve türevlerini depodan siler
+func (s *MediaService) releaseObject(media *domain.Media) error {
+	remaining, err := s.mediaRepo.ReleaseObject(media.ObjectName)
+	if err != nil {
+		return err
+	}
+	if remaining > 0 {
+		return nil
+	}
+
+	// Önce türevleri, sonra orijinali depodan sil
+	for _, derivative := range media.Derivatives {
+		if err := s.storage.Delete(derivative.ObjectName); err != nil {
+			return err
+		}
+	}
+	return s.storage.Delete(media.ObjectName)
+}
+
+// BackfillContentHashes içerik özeti olmayan eski yüklemelerin özetlerini
+// arka planda hesaplar
+//
+// Mevcut nesneler birleştirilmez; sonuçlar kopya raporunda incelenir.
+func (s *MediaService) BackfillContentHashes(actor *domain.Actor) (*domain.MediaJob, error) {
+	if s.storage == nil {
+		return nil, domain.NewServiceUnavailableError(ErrStorageUnavailable, ErrStorageUnavailable.Error())
+	}
+
+	total, err := s.mediaRepo.CountWithoutHash()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.startJob(domain.MediaJobHashes, total, actor, s.runContentHashJob)
+}
+
+// GetContentHashJob son içerik özeti işinin durumunu döner
+func (s *MediaService) GetContentHashJob() (*domain.MediaJob, error) {
+	return s.getJob(domain.MediaJobHashes)
+}
+
+// runContentHashJob özeti olmayan medyaları ID sırasıyla parça parça işler
+func (s *MediaService) runContentHashJob(job *domain.MediaJob) error {
+	var afterID uint
+	for {
+		batch, err := s.mediaRepo.ListWithoutHash(afterID, mediaJobBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+
+		for _, media := range batch {
+			afterID = media.ID
+			s.recordJobItem(job, fmt.Sprintf("medya %d", media.ID), s.backfillContentHash(media))
+		}
+	}
+}
+
+// backfillContentHash tek bir medyanın nesnesini depodan okuyup özetini kaydeder
+func (s *MediaService) backfillContentHash(media *domain.Media) error {
+	reader, _, err := s.storage.Get(media.ObjectName, nil)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	contentHash, err := hashContent(reader)
+	if err != nil {
+		return err
+	}
+
+	if err := s.mediaRepo.EnsureObject(&domain.MediaObject{
+		ObjectName:  media.ObjectName,
+		ContentHash: contentHash,
+		ContentType: media.ContentType,
+		Filesize:    media.Filesize,
+		RefCount:    1,
+	}); err != nil {
+		return err
+	}
+	return s.mediaRepo.SetContentHash(media.ID, contentHash)
+}
+
+// ListDuplicates aynı içeriği ayrı nesnelerde saklayan medya gruplarını listeler
+func (s *MediaService) ListDuplicates(offset, limit int) ([]*domain.DuplicateCluster, int64, error) {
+	clusters, total, err := s.mediaRepo.ListDuplicates(offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for _, cluster := range clusters {
+		media, err := s.mediaRepo.ListByContentHash(cluster.ContentHash)
+		if err != nil {
+			return nil, 0, err
+		}
+		cluster.Media = make([]*domain.MediaResponse, len(media))
+		for i, item := range media {
+			cluster.Media[i] = s.ToResponse(item)
+		}
+	}
+
+	return clusters, total, nil
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/username/haber/internal/domain"
+	"github.com/username/haber/pkg/imaging"
+)
+
+// generateDerivatives görseli çözer, boyutlarını kaydeder ve yapılandırılmış
+// türevleri orijinal dosyanın yanına yazar
+//
+// Tanımdan çıkarılmış eski türevler depodan silinir. Orijinalden geniş
+// türevler üretilmez; görseller büyütülmez.
+func (s *MediaService) generateDerivatives(media *domain.Media, src io.Reader) error {
+	img, format, err := imaging.Decode(src, s.maxImagePixels)
+	if err != nil {
+		return err
+	}
+
+	bounds := img.Bounds()
+	media.Width, media.Height = bounds.Dx(), bounds.Dy()
+
+	base := strings.TrimSuffix(media.ObjectName, path.Ext(media.ObjectName))
+	derivatives := make([]*domain.MediaDerivative, 0)
+	keep := make(map[string]bool)
+
+	for _, preset := range s.presets {
+		for _, width := range preset.Widths {
+			if width >= media.Width {
+				continue
+			}
+
+			var buf bytes.Buffer
+			contentType, ext, err := imaging.Encode(&buf, imaging.Resize(img, width), format, s.jpegQuality)
+			if err != nil {
+				return err
+			}
+
+			key := fmt.Sprintf("%s_%s_%dw%s", base, preset.Name, width, ext)
+			size := int64(buf.Len())
+			if _, err := s.storage.Put(key, &buf, size, contentType); err != nil {
+				return err
+			}
+
+			derivatives = append(derivatives, &domain.MediaDerivative{
+				Preset:      preset.Name,
+				Width:       width,
+				Height:      media.Height * width / media.Width,
+				ObjectName:  key,
+				ContentType: contentType,
+				Filesize:    uint(size),
+				CreatedAt:   time.Now(),
+			})
+			keep[key] = true
+		}
+	}
+
+	if err := s.mediaRepo.ReplaceDerivatives(media.ID, derivatives); err != nil {
+		return err
+	}
+
+	// Artık tanımda olmayan eski türevleri depodan temizle
+	for _, old := range media.Derivatives {
+		if keep[old.ObjectName] {
+			continue
+		}
+		if err := s.storage.Delete(old.ObjectName); err != nil {
+			log.Printf("Eski görsel türevi silinemedi (%s): %v", old.ObjectName, err)
+		}
+	}
+
+	media.Derivatives = derivatives
+	return s.mediaRepo.Update(media)
+}
+
+// RegenerateDerivatives tüm görsellerin türevlerini arka planda yeniden üretir
+//
+// Türev tanımı değiştirildikten sonra kullanılır. Aynı anda yalnızca bir iş
+// çalışabilir.
+func (s *MediaService) RegenerateDerivatives(actor *domain.Actor) (*domain.MediaJob, error) {
+	if s.storage == nil {
+		return nil, domain.NewServiceUnavailableError(ErrStorageUnavailable, ErrStorageUnavailable.Error())
+	}
+
+	total, err := s.mediaRepo.CountByContentTypes(imaging.SupportedContentTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.startJob(domain.MediaJobDerivatives, total, actor, s.runDerivativeJob)
+}
+
+// GetDerivativeJob son türev yeniden üretim işinin durumunu döner
+func (s *MediaService) GetDerivativeJob() (*domain.MediaJob, error) {
+	return s.getJob(domain.MediaJobDerivatives)
+}
+
+// runDerivativeJob görselleri ID sırasıyla parça parça işler
+func (s *MediaService) runDerivativeJob(job *domain.MediaJob) error {
+	var afterID uint
+	for {
+		batch, err := s.mediaRepo.ListByContentTypes(imaging.SupportedContentTypes, afterID, mediaJobBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+
+		for _, media := range batch {
+			afterID = media.ID
+			s.recordJobItem(job, fmt.Sprintf("medya %d", media.ID), s.regenerateMedia(media))
+		}
+	}
+}
+
+// regenerateMedia tek bir görselin orijinalini depodan okuyup türevlerini üretir
+func (s *MediaService) regenerateMedia(media *domain.Media) error {
+	reader, _, err := s.storage.Get(media.ObjectName, nil)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	return s.generateDerivatives(media, reader)
+}
+
+// reencodeImage görseli çözüp yeniden kodlayarak tüm meta verisini atar
+func (s *MediaService) reencodeImage(data []byte, format string) ([]byte, error) {
+	img, _, err := imaging.Decode(bytes.NewReader(data), s.maxImagePixels)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if _, _, err := imaging.Encode(&buf, img, format, s.jpegQuality); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
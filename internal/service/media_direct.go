@@ -0,0 +1,250 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/username/haber/internal/domain"
+	"github.com/username/haber/pkg/auth"
+	"github.com/username/haber/pkg/storage"
+)
+
+// directUploadPrefix onay bekleyen doğrudan yüklemelerin depodaki ön eki
+const directUploadPrefix = "direct"
+
+// ErrDirectUploadUnsupported depo doğrudan yüklemeyi desteklemediğinde döner
+var ErrDirectUploadUnsupported = errors.New("dosya deposu doğrudan yüklemeyi desteklemiyor")
+
+// CreateDirectUpload istemcinin dosyayı sunucudan geçirmeden depoya
+// yükleyebileceği imzalı bir adres üretir
+//
+// Dosyanın türü ve türe özgü boyut sınırı onay sırasında doğrulanır; burada
+// yalnızca en büyük sınır uygulanır. Adres süresinin sonunda başlayan
+// yüklemelerin onaylanabilmesi için kayıt, adresten bir süre daha uzun tutulur.
+func (s *MediaService) CreateDirectUpload(req *domain.CreateDirectUploadRequest, actor *domain.Actor) (*domain.DirectUploadResponse, error) {
+	if err := s.policy.Authorize(actor, domain.PermMediaUpload); err != nil {
+		return nil, err
+	}
+
+	if s.storage == nil {
+		return nil, domain.NewServiceUnavailableError(ErrStorageUnavailable, ErrStorageUnavailable.Error())
+	}
+	presigner, ok := s.storage.(storage.PresignedUploader)
+	if !ok {
+		return nil, domain.NewServiceUnavailableError(ErrDirectUploadUnsupported,
+			"Dosya deposu doğrudan yüklemeyi desteklemiyor; normal veya parçalı yükleme kullanın")
+	}
+
+	maxSize := s.MaxUploadSize()
+	if maxSize > 0 && req.Size > maxSize {
+		return nil, &domain.ValidationError{
+			Field:   "size",
+			Message: fmt.Sprintf("Dosya en fazla %dMB olabilir", maxSize/(1024*1024)),
+		}
+	}
+
+	folder, err := normalizeMediaFolder(req.Folder)
+	if err != nil {
+		return nil, err
+	}
+
+	// Süresinde onaylanmamış dosyaları temizle
+	s.cleanupExpiredDirectUploads()
+
+	id, err := auth.GenerateRandomToken(16)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	upload := &domain.DirectUpload{
+		ID:          id,
+		UserID:      actor.UserID,
+		Filename:    uploadFilename(req.Filename),
+		Folder:      folder,
+		ObjectName:  directUploadPrefix + "/" + id,
+		Size:        req.Size,
+		ContentHash: strings.ToLower(req.SHA256),
+		ExpiresAt:   now.Add(2 * s.directExpiry),
+		CreatedAt:   now,
+	}
+
+	uploadURL, err := presigner.PresignPut(upload.ObjectName, s.directExpiry)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.directRepo.Create(upload); err != nil {
+		return nil, err
+	}
+
+	allowed := make([]string, len(allowedMediaTypes))
+	for i, mediaType := range allowedMediaTypes {
+		allowed[i] = mediaType.ContentType
+	}
+
+	return &domain.DirectUploadResponse{
+		ID:           upload.ID,
+		UploadURL:    uploadURL,
+		Method:       http.MethodPut,
+		Size:         upload.Size,
+		MaxSize:      maxSize,
+		AllowedTypes: allowed,
+		ExpiresAt:    now.Add(s.directExpiry),
+	}, nil
+}
+
+// ConfirmDirectUpload depoya yüklenen dosyayı doğrulayıp medya kaydını oluşturur
+//
+// Dosyanın boyutu ve bildirildiyse SHA-256 özeti karşılaştırılır, ardından
+// dosya normal yükleme akışından geçirilir (içerikten tür tespiti, EXIF
+// temizliği, tekilleştirme). Reddedilen dosyalar silinir. Onaylanmış bir
+// yükleme tekrar onaylanırsa oluşturulan medya döner.
+func (s *MediaService) ConfirmDirectUpload(id string, actor *domain.Actor) (*domain.Media, error) {
+	if s.storage == nil {
+		return nil, domain.NewServiceUnavailableError(ErrStorageUnavailable, ErrStorageUnavailable.Error())
+	}
+
+	// Aynı yüklemenin eş zamanlı onaylanmasını engelle
+	unlock := s.lockUpload(id)
+	defer unlock()
+
+	upload, err := s.directRepo.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if actor == nil || upload.UserID != actor.UserID || !time.Now().Before(upload.ExpiresAt) {
+		return nil, &domain.NotFoundError{
+			ResourceType: domain.ResourceUpload,
+			ID:           id,
+		}
+	}
+
+	if upload.Completed() {
+		return s.mediaRepo.Get(*upload.MediaID)
+	}
+
+	info, err := s.storage.Stat(upload.ObjectName)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, &domain.ValidationError{
+			Field:   "file",
+			Message: "Dosya henüz depoya yüklenmedi",
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if info.Size != upload.Size {
+		s.rejectDirectUpload(upload)
+		return nil, &domain.ValidationError{
+			Field:   "size",
+			Message: fmt.Sprintf("Yüklenen dosyanın boyutu (%d bayt) bildirilen boyutla (%d bayt) uyuşmuyor", info.Size, upload.Size),
+		}
+	}
+
+	media, err := s.ingestDirectUpload(upload, actor)
+	if err != nil {
+		var validationErr *domain.ValidationError
+		if errors.As(err, &validationErr) {
+			s.rejectDirectUpload(upload)
+		}
+		return nil, err
+	}
+
+	// Kayıt, onay tekrarlanabilsin diye süresi dolana kadar tutulur
+	if err := s.storage.Delete(upload.ObjectName); err != nil {
+		log.Printf("Onaylanan doğrudan yüklemenin geçici dosyası silinemedi (%s): %v", upload.ObjectName, err)
+	}
+	upload.MediaID = &media.ID
+	if err := s.directRepo.Update(upload); err != nil {
+		log.Printf("Doğrudan yükleme durumu güncellenemedi (%s): %v", upload.ID, err)
+	}
+
+	return media, nil
+}
+
+// ingestDirectUpload geçici dosyayı depodan okuyup özetini doğrular ve normal
+// yükleme akışından geçirir
+func (s *MediaService) ingestDirectUpload(upload *domain.DirectUpload, actor *domain.Actor) (*domain.Media, error) {
+	reader, _, err := s.storage.Get(upload.ObjectName, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	tmp, err := os.CreateTemp("", "haber-upload-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	// İmzalı adres hâlâ geçerli olduğundan nesne boyut kontrolünden sonra
+	// değiştirilmiş olabilir; bildirilen boyuttan fazlası okunmaz
+	hasher := sha256.New()
+	written, err := io.Copy(io.MultiWriter(tmp, hasher), io.LimitReader(reader, upload.Size+1))
+	if err != nil {
+		return nil, err
+	}
+	if written != upload.Size {
+		return nil, &domain.ValidationError{
+			Field:   "size",
+			Message: fmt.Sprintf("Yüklenen dosyanın boyutu bildirilen boyutla (%d bayt) uyuşmuyor", upload.Size),
+		}
+	}
+	if upload.ContentHash != "" && hex.EncodeToString(hasher.Sum(nil)) != upload.ContentHash {
+		return nil, &domain.ValidationError{
+			Field:   "sha256",
+			Message: "Yüklenen dosyanın özeti bildirilen özetle uyuşmuyor",
+		}
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	return s.ingest(tmp, written, upload.Filename, upload.Folder, actor)
+}
+
+// discardDirectUpload yüklemenin geçici dosyasını ve kaydını siler
+func (s *MediaService) discardDirectUpload(upload *domain.DirectUpload) error {
+	if s.storage != nil {
+		if err := s.storage.Delete(upload.ObjectName); err != nil {
+			log.Printf("Doğrudan yükleme dosyası silinemedi (%s): %v", upload.ObjectName, err)
+		}
+	}
+	return s.directRepo.Delete(upload.ID)
+}
+
+// rejectDirectUpload doğrulamadan geçemeyen yüklemeyi siler; hatalar yalnızca loglanır
+func (s *MediaService) rejectDirectUpload(upload *domain.DirectUpload) {
+	if err := s.discardDirectUpload(upload); err != nil {
+		log.Printf("Reddedilen doğrudan yükleme silinemedi (%s): %v", upload.ID, err)
+	}
+}
+
+// cleanupExpiredDirectUploads süresi dolmuş doğrudan yüklemelerin bir kısmını
+// temizler ve silinen kayıt sayısını döner
+func (s *MediaService) cleanupExpiredDirectUploads() int {
+	expired, err := s.directRepo.ListExpired(time.Now(), resumableCleanupBatch)
+	if err != nil {
+		log.Printf("Süresi dolmuş doğrudan yüklemeler listelenemedi: %v", err)
+		return 0
+	}
+
+	removed := 0
+	for _, upload := range expired {
+		if err := s.discardDirectUpload(upload); err != nil {
+			log.Printf("Süresi dolmuş doğrudan yükleme silinemedi (%s): %v", upload.ID, err)
+			continue
+		}
+		removed++
+	}
+	return removed
+}
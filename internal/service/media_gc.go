@@ -0,0 +1,228 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/username/haber/internal/domain"
+)
+
+// mediaGCReportLimit raporda ayrıntısı listelenen en fazla kayıt sayısı;
+// sayılar ve toplam boyut her zaman tüm bulguları kapsar
+const mediaGCReportLimit = 500
+
+// RunGarbageCollection depo ile veritabanını karşılaştıran taramayı arka
+// planda başlatır
+//
+// Depoda olup hiçbir kayda bağlı olmayan dosyalar ve dosyası depoda
+// bulunmayan medya kayıtları raporlanır. deleteFound true ise sahipsiz
+// dosyalar ve kullanımda olmayan dosyasız kayıtlar silinir. Yeni yazılmış
+// dosyalar, kaydı henüz oluşturulmamış olabileceğinden bekleme süresi
+// dolana kadar sahipsiz sayılmaz.
+func (s *MediaService) RunGarbageCollection(deleteFound bool, actor *domain.Actor) (*domain.MediaJob, error) {
+	if s.storage == nil {
+		return nil, domain.NewServiceUnavailableError(ErrStorageUnavailable, ErrStorageUnavailable.Error())
+	}
+
+	return s.startJob(domain.MediaJobGC, 0, actor, func(job *domain.MediaJob) error {
+		report := &domain.MediaGCReport{
+			StartedAt:      time.Now(),
+			Delete:         deleteFound,
+			OrphanObjects:  make([]*domain.OrphanObject, 0),
+			MissingObjects: make([]*domain.MissingObject, 0),
+		}
+		if err := s.collectGarbage(job, report, actor); err != nil {
+			return err
+		}
+		report.FinishedAt = time.Now()
+
+		s.jobMu.Lock()
+		s.gcReport = report
+		s.jobMu.Unlock()
+		return nil
+	})
+}
+
+// GetGarbageCollection son taramanın iş durumunu ve tamamlandıysa raporunu döner
+//
+// Rapor, son başarılı taramaya aittir; çalışan bir tarama varken bir önceki
+// taramanın raporu döner.
+func (s *MediaService) GetGarbageCollection() (*domain.MediaJob, *domain.MediaGCReport, error) {
+	job, err := s.getJob(domain.MediaJobGC)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.jobMu.Lock()
+	defer s.jobMu.Unlock()
+	if s.gcReport == nil {
+		return job, nil, nil
+	}
+	report := *s.gcReport
+	return job, &report, nil
+}
+
+// StartGarbageCollection taramanın periyodik olarak çalıştırılmasını başlatır
+//
+// Bulunanlar yapılandırmadaki STORAGE_GC_DELETE değerine göre silinir veya
+// yalnızca raporlanır. interval sıfır veya negatifse tarama planlanmaz.
+// Dönen fonksiyon çağrıldığında tarama durdurulur.
+func (s *MediaService) StartGarbageCollection(interval time.Duration) (stop func()) {
+	if interval <= 0 {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	ticker := time.NewTicker(interval)
+
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if _, err := s.RunGarbageCollection(s.gcDelete, nil); err != nil {
+					log.Printf("Depo taraması başlatılamadı: %v", err)
+				}
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() { once.Do(func() { close(done) }) }
+}
+
+// collectGarbage önce sahipsiz dosyaları, ardından dosyası olmayan kayıtları bulur
+func (s *MediaService) collectGarbage(job *domain.MediaJob, report *domain.MediaGCReport, actor *domain.Actor) error {
+	// Yarıda kalan ve onaylanmayan yüklemelerin dosyaları kendi süreleriyle
+	// temizlenir; tarama bu temizliği sonuna kadar çalıştırır
+	for s.cleanupExpiredUploads() > 0 {
+	}
+	for s.cleanupExpiredDirectUploads() > 0 {
+	}
+
+	objects, err := s.storage.List("")
+	if err != nil {
+		return err
+	}
+
+	names, err := s.mediaRepo.ListObjectNames()
+	if err != nil {
+		return err
+	}
+	referenced := make(map[string]bool, len(names))
+	for _, name := range names {
+		referenced[name] = true
+	}
+
+	s.updateJob(job, func(j *domain.MediaJob) {
+		j.Total += int64(len(objects))
+	})
+
+	stored := make(map[string]bool, len(objects))
+	cutoff := time.Now().Add(-s.gcGrace)
+	for _, object := range objects {
+		stored[object.Key] = true
+
+		if referenced[object.Key] || isPendingUploadKey(object.Key) || object.LastModified.After(cutoff) {
+			s.recordJobItem(job, object.Key, nil)
+			continue
+		}
+
+		report.OrphanObjectCount++
+		report.OrphanBytes += object.Size
+		if len(report.OrphanObjects) < mediaGCReportLimit {
+			report.OrphanObjects = append(report.OrphanObjects, &domain.OrphanObject{
+				Key:          object.Key,
+				Size:         object.Size,
+				LastModified: object.LastModified,
+			})
+		}
+
+		var deleteErr error
+		if report.Delete {
+			if deleteErr = s.storage.Delete(object.Key); deleteErr == nil {
+				report.RemovedObjects++
+			}
+		}
+		s.recordJobItem(job, object.Key, deleteErr)
+	}
+
+	var afterID uint
+	for {
+		batch, err := s.mediaRepo.ListAfter(afterID, mediaJobBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+		afterID = batch[len(batch)-1].ID
+
+		s.updateJob(job, func(j *domain.MediaJob) {
+			j.Total += int64(len(batch))
+		})
+
+		missing := make([]*domain.Media, 0)
+		ids := make([]uint, 0)
+		for _, media := range batch {
+			if stored[media.ObjectName] {
+				s.recordJobItem(job, fmt.Sprintf("medya %d", media.ID), nil)
+				continue
+			}
+			missing = append(missing, media)
+			ids = append(ids, media.ID)
+		}
+		if len(missing) == 0 {
+			continue
+		}
+
+		inUse, err := s.usage.ListInUse(ids)
+		if err != nil {
+			return err
+		}
+
+		for _, media := range missing {
+			report.MissingObjectCount++
+			if len(report.MissingObjects) < mediaGCReportLimit {
+				report.MissingObjects = append(report.MissingObjects, &domain.MissingObject{
+					MediaID:    media.ID,
+					ObjectName: media.ObjectName,
+					InUse:      inUse[media.ID],
+				})
+			}
+
+			var deleteErr error
+			if report.Delete && !inUse[media.ID] {
+				if deleteErr = s.removeMissingMedia(media, actor); deleteErr == nil {
+					report.RemovedMedia++
+				}
+			}
+			s.recordJobItem(job, fmt.Sprintf("medya %d", media.ID), deleteErr)
+		}
+	}
+}
+
+// isPendingUploadKey anahtarın tamamlanmamış bir yüklemeye ait olup olmadığını belirtir
+func isPendingUploadKey(key string) bool {
+	return strings.HasPrefix(key, resumablePartPrefix+"/") || strings.HasPrefix(key, directUploadPrefix+"/")
+}
+
+// removeMissingMedia dosyası depoda bulunmayan medya kaydını siler
+//
+// Nesne referansı da bırakılır; varsa depoda kalan türevler temizlenir.
+func (s *MediaService) removeMissingMedia(media *domain.Media, actor *domain.Actor) error {
+	if err := s.mediaRepo.Delete(media.ID); err != nil {
+		return err
+	}
+	if err := s.releaseObject(media); err != nil {
+		log.Printf("Medya %d silindi ancak depo nesnesi (%s) temizlenemedi: %v", media.ID, media.ObjectName, err)
+	}
+
+	s.audit.Record(actor, domain.AuditActionDelete, domain.ResourceMedia, media.ID, media, nil)
+	return nil
+}
@@ -0,0 +1,380 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/username/haber/internal/domain"
+	"github.com/username/haber/pkg/storage"
+)
+
+// importManifestName arşiv kökünde künye bilgilerini içeren isteğe bağlı CSV dosyası
+const importManifestName = "manifest.csv"
+
+// importManifestMaxSize CSV manifestinin en büyük boyutu (bayt)
+const importManifestMaxSize = 1 << 20
+
+// importManifestColumns manifestte okunan sütunlar ve medyadaki karşılıkları;
+// filename sütunu zorunludur, diğerleri isteğe bağlıdır
+var importManifestColumns = map[string]func(media *domain.Media, value string){
+	"caption":   func(media *domain.Media, value string) { media.Caption = value },
+	"alt_text":  func(media *domain.Media, value string) { media.AltText = truncate(value, 255) },
+	"credit":    func(media *domain.Media, value string) { media.Credit = truncate(value, 255) },
+	"source":    func(media *domain.Media, value string) { media.Source = truncate(value, 255) },
+	"copyright": func(media *domain.Media, value string) { media.Copyright = truncate(value, 255) },
+}
+
+// importManifest dosya yoluna göre künye bilgileri
+type importManifest struct {
+	byPath map[string]map[string]string
+	byBase map[string]map[string]string
+}
+
+// lookup dosyanın künye bilgilerini arşivdeki yoluna, bulunamazsa adına göre döner
+func (m *importManifest) lookup(name string) (map[string]string, bool) {
+	if m == nil {
+		return nil, false
+	}
+	if values, ok := m.byPath[name]; ok {
+		return values, true
+	}
+	values, ok := m.byBase[path.Base(name)]
+	return values, ok
+}
+
+// ImportArchive ZIP arşivindeki dosyaları arka planda medya kütüphanesine aktarır
+//
+// Arşiv hiçbir zaman diske açılmaz; her dosya geçici bir dosyaya okunup normal
+// yükleme akışından (içerikten tür tespiti, boyut sınırları, EXIF temizliği,
+// türevler) geçirilir ve dosya adı olarak yalnızca yolun son parçası kullanılır.
+// Dosya sayısı ve açılmış toplam boyut iş başlamadan kontrol edilir. Arşiv
+// kökündeki manifest.csv varsa açıklama, alternatif metin ve künye bilgileri
+// buradan okunur. Her dosyanın sonucu iş durumunda raporlanır.
+func (s *MediaService) ImportArchive(file *multipart.FileHeader, folder string, actor *domain.Actor) (*domain.MediaJob, error) {
+	if err := s.policy.Authorize(actor, domain.PermMediaUpload); err != nil {
+		return nil, err
+	}
+
+	if s.storage == nil {
+		return nil, domain.NewServiceUnavailableError(ErrStorageUnavailable, ErrStorageUnavailable.Error())
+	}
+
+	folder, err := normalizeMediaFolder(folder)
+	if err != nil {
+		return nil, err
+	}
+
+	// Arşiv, istek tamamlandıktan sonra da okunabilmesi için geçici dosyaya kopyalanır
+	archiveFile, err := copyToTemp(file)
+	if err != nil {
+		return nil, err
+	}
+	started := false
+	defer func() {
+		if !started {
+			archiveFile.Close()
+			os.Remove(archiveFile.Name())
+		}
+	}()
+
+	archive, err := zip.NewReader(archiveFile, file.Size)
+	if err != nil {
+		return nil, &domain.ValidationError{
+			Field:   "file",
+			Message: "Dosya geçerli bir ZIP arşivi değil",
+		}
+	}
+
+	entries, manifestFile, err := s.planImport(archive)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := readImportManifest(manifestFile)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := s.startJob(domain.MediaJobImport, int64(len(entries)), actor, func(job *domain.MediaJob) error {
+		defer os.Remove(archiveFile.Name())
+		defer archiveFile.Close()
+		return s.runImport(job, entries, manifest, folder, actor)
+	})
+	if err != nil {
+		return nil, err
+	}
+	started = true
+	return job, nil
+}
+
+// GetImportJob içe aktarma işinin durumunu ve dosya bazında sonuçlarını döner
+//
+// Yalnızca son içe aktarma işi saklanır; daha eski işler bulunamadı olarak döner.
+func (s *MediaService) GetImportJob(id string) (*domain.MediaJob, error) {
+	job, err := s.getJob(domain.MediaJobImport)
+	if err != nil {
+		return nil, err
+	}
+	if job.ID != id {
+		return nil, &domain.NotFoundError{
+			ResourceType: domain.ResourceMedia,
+			ID:           id + " işi",
+		}
+	}
+	return job, nil
+}
+
+// planImport arşivdeki aktarılacak dosyaları ve manifesti belirler, sınırları
+// uygular
+//
+// Klasörler ile gizli ve sistem dosyaları (.DS_Store, __MACOSX) atlanır.
+func (s *MediaService) planImport(archive *zip.Reader) ([]*zip.File, *zip.File, error) {
+	entries := make([]*zip.File, 0)
+	var manifest *zip.File
+	var total uint64
+
+	for _, entry := range archive.File {
+		name := entry.Name
+		if entry.FileInfo().IsDir() || strings.HasSuffix(name, "/") {
+			continue
+		}
+		if strings.HasPrefix(name, "__MACOSX/") || strings.HasPrefix(path.Base(name), ".") {
+			continue
+		}
+		if strings.EqualFold(name, importManifestName) {
+			manifest = entry
+			continue
+		}
+
+		entries = append(entries, entry)
+		if s.importMaxEntries > 0 && len(entries) > s.importMaxEntries {
+			return nil, nil, &domain.ValidationError{
+				Field:   "file",
+				Message: fmt.Sprintf("Arşivde en fazla %d dosya olabilir", s.importMaxEntries),
+			}
+		}
+
+		total += entry.UncompressedSize64
+		if s.importMaxTotal > 0 && total > uint64(s.importMaxTotal) {
+			return nil, nil, &domain.ValidationError{
+				Field:   "file",
+				Message: fmt.Sprintf("Arşivin açılmış boyutu en fazla %dMB olabilir", s.importMaxTotal/(1024*1024)),
+			}
+		}
+	}
+
+	if len(entries) == 0 {
+		return nil, nil, &domain.ValidationError{
+			Field:   "file",
+			Message: "Arşivde aktarılacak dosya yok",
+		}
+	}
+
+	return entries, manifest, nil
+}
+
+// runImport arşivdeki dosyaları sırayla aktarır; tek bir dosyanın hatası işi durdurmaz
+func (s *MediaService) runImport(job *domain.MediaJob, entries []*zip.File, manifest *importManifest, folder string, actor *domain.Actor) error {
+	// Her dosya aynı geçici dosyaya okunur
+	scratch, err := os.CreateTemp("", "haber-import-entry-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(scratch.Name())
+	defer scratch.Close()
+
+	for _, entry := range entries {
+		item := &domain.MediaJobItem{Name: entry.Name}
+		media, err := s.importEntry(scratch, entry, manifest, folder, actor)
+		if err != nil {
+			item.Error = err.Error()
+		} else {
+			mediaID := media.ID
+			item.MediaID = &mediaID
+		}
+
+		s.recordJobItem(job, entry.Name, err)
+		s.updateJob(job, func(j *domain.MediaJob) {
+			j.Items = append(j.Items, item)
+		})
+	}
+	return nil
+}
+
+// importEntry tek bir arşiv dosyasını geçici dosyaya okuyup yükleme akışından geçirir
+func (s *MediaService) importEntry(scratch *os.File, entry *zip.File, manifest *importManifest, folder string, actor *domain.Actor) (*domain.Media, error) {
+	// Ad yalnızca dosya adı ve manifest eşleşmesi için kullanılır, yine de
+	// arşiv dışına işaret eden yollar reddedilir
+	if err := storage.ValidateKey(entry.Name); err != nil || entry.Mode()&os.ModeSymlink != 0 {
+		return nil, &domain.ValidationError{
+			Field:   "file",
+			Message: "Güvenli olmayan dosya yolu",
+		}
+	}
+
+	size := int64(entry.UncompressedSize64)
+	if limit := s.MaxUploadSize(); limit > 0 && size > limit {
+		return nil, &domain.ValidationError{
+			Field:   "file",
+			Message: fmt.Sprintf("Dosya en fazla %dMB olabilir", limit/(1024*1024)),
+		}
+	}
+
+	reader, err := entry.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	if err := scratch.Truncate(0); err != nil {
+		return nil, err
+	}
+	if _, err := scratch.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	// Bildirilen boyuttan fazlası okunmaz (sıkıştırma bombalarına karşı)
+	written, err := io.Copy(scratch, io.LimitReader(reader, size+1))
+	if err != nil {
+		return nil, err
+	}
+	if written > size {
+		return nil, &domain.ValidationError{
+			Field:   "file",
+			Message: "Dosya arşivde bildirilen boyuttan büyük",
+		}
+	}
+	if _, err := scratch.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	media, err := s.ingest(scratch, written, path.Base(entry.Name), folder, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	if values, ok := manifest.lookup(entry.Name); ok {
+		for column, value := range values {
+			importManifestColumns[column](media, value)
+		}
+		if err := s.mediaRepo.Update(media); err != nil {
+			log.Printf("İçe aktarılan medyanın (%d) künye bilgileri kaydedilemedi: %v", media.ID, err)
+		}
+	}
+
+	return media, nil
+}
+
+// readImportManifest arşivdeki CSV manifestini okur; manifest yoksa nil döner
+//
+// İlk satır sütun adlarıdır. Excel'in Türkçe ayarlarla ürettiği noktalı
+// virgülle ayrılmış dosyalar da kabul edilir.
+func readImportManifest(entry *zip.File) (*importManifest, error) {
+	if entry == nil {
+		return nil, nil
+	}
+
+	invalid := func(message string) error {
+		return &domain.ValidationError{
+			Field:   importManifestName,
+			Message: message,
+		}
+	}
+
+	reader, err := entry.Open()
+	if err != nil {
+		return nil, invalid("Manifest okunamadı")
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(io.LimitReader(reader, importManifestMaxSize+1))
+	if err != nil {
+		return nil, invalid("Manifest okunamadı")
+	}
+	if len(data) > importManifestMaxSize {
+		return nil, invalid(fmt.Sprintf("Manifest en fazla %dKB olabilir", importManifestMaxSize/1024))
+	}
+	data = bytes.TrimPrefix(data, []byte("\ufeff")) // UTF-8 BOM
+
+	csvReader := csv.NewReader(bytes.NewReader(data))
+	firstLine, _, _ := strings.Cut(string(data), "\n")
+	if strings.Contains(firstLine, ";") && !strings.Contains(firstLine, ",") {
+		csvReader.Comma = ';'
+	}
+	csvReader.FieldsPerRecord = -1
+
+	rows, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, invalid(fmt.Sprintf("Manifest geçerli bir CSV dosyası değil: %v", err))
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	filenameColumn := -1
+	columns := make(map[int]string)
+	for i, header := range rows[0] {
+		header = strings.ToLower(strings.TrimSpace(header))
+		if header == "filename" {
+			filenameColumn = i
+		} else if _, ok := importManifestColumns[header]; ok {
+			columns[i] = header
+		}
+	}
+	if filenameColumn < 0 {
+		return nil, invalid("Manifestte filename sütunu bulunmalıdır")
+	}
+
+	manifest := &importManifest{
+		byPath: make(map[string]map[string]string),
+		byBase: make(map[string]map[string]string),
+	}
+	for _, row := range rows[1:] {
+		if filenameColumn >= len(row) {
+			continue
+		}
+		name := path.Clean(strings.TrimPrefix(strings.TrimSpace(row[filenameColumn]), "/"))
+		if name == "." {
+			continue
+		}
+
+		values := make(map[string]string)
+		for i, column := range columns {
+			if i < len(row) {
+				if value := strings.TrimSpace(row[i]); value != "" {
+					values[column] = value
+				}
+			}
+		}
+		manifest.byPath[name] = values
+		manifest.byBase[path.Base(name)] = values
+	}
+
+	return manifest, nil
+}
+
+// copyToTemp yüklenen dosyayı geçici bir dosyaya kopyalar
+func copyToTemp(file *multipart.FileHeader) (*os.File, error) {
+	src, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	tmp, err := os.CreateTemp("", "haber-import-*.zip")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(tmp, src); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return nil, err
+	}
+	return tmp, nil
+}
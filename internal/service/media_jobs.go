@@ -0,0 +1,117 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/username/haber/internal/domain"
+	"github.com/username/haber/pkg/auth"
+)
+
+// mediaJobBatchSize toplu medya işlerinde tek seferde işlenen kayıt sayısı
+const mediaJobBatchSize = 50
+
+// mediaJobLabels hata ve log mesajlarında kullanılan iş adları
+var mediaJobLabels = map[string]string{
+	domain.MediaJobDerivatives: "Görsel türevleri",
+	domain.MediaJobHashes:      "İçerik özetleri",
+	domain.MediaJobUsages:      "Medya kullanımları",
+	domain.MediaJobGC:          "Depo taraması",
+	domain.MediaJobImport:      "Toplu içe aktarma",
+}
+
+// startJob verilen türde bir arka plan işi başlatır
+//
+// Her türden aynı anda yalnızca bir iş çalışabilir. run hata dönerse iş
+// başarısız olarak işaretlenir.
+func (s *MediaService) startJob(kind string, total int64, actor *domain.Actor, run func(job *domain.MediaJob) error) (*domain.MediaJob, error) {
+	s.jobMu.Lock()
+	defer s.jobMu.Unlock()
+
+	if job := s.jobs[kind]; job != nil && job.Status == domain.MediaJobRunning {
+		return nil, domain.NewConflictError(fmt.Sprintf("%s işi zaten çalışıyor", mediaJobLabels[kind]))
+	}
+
+	id, err := auth.GenerateRandomToken(8)
+	if err != nil {
+		return nil, err
+	}
+
+	job := &domain.MediaJob{
+		ID:        id,
+		Kind:      kind,
+		Status:    domain.MediaJobRunning,
+		Total:     total,
+		StartedAt: time.Now(),
+	}
+	if actor != nil && actor.UserID != 0 {
+		userID := actor.UserID
+		job.StartedByID = &userID
+	}
+	if s.jobs == nil {
+		s.jobs = make(map[string]*domain.MediaJob)
+	}
+	s.jobs[kind] = job
+
+	go func() {
+		status := domain.MediaJobCompleted
+		if err := run(job); err != nil {
+			log.Printf("%s işi başarısız oldu: %v", mediaJobLabels[kind], err)
+			status = domain.MediaJobFailed
+			s.updateJob(job, func(j *domain.MediaJob) {
+				j.LastError = err.Error()
+			})
+		}
+		s.updateJob(job, func(j *domain.MediaJob) {
+			now := time.Now()
+			j.Status = status
+			j.FinishedAt = &now
+		})
+	}()
+
+	snapshot := *job
+	return &snapshot, nil
+}
+
+// getJob verilen türdeki son işin durumunu döner
+func (s *MediaService) getJob(kind string) (*domain.MediaJob, error) {
+	s.jobMu.Lock()
+	defer s.jobMu.Unlock()
+
+	job := s.jobs[kind]
+	if job == nil {
+		return nil, &domain.NotFoundError{
+			ResourceType: domain.ResourceMedia,
+			ID:           kind + " işi",
+		}
+	}
+
+	snapshot := *job
+	snapshot.Items = append([]*domain.MediaJobItem(nil), job.Items...)
+	return &snapshot, nil
+}
+
+// recordJobItem tek bir kaydın işlenme sonucunu iş durumuna yansıtır
+//
+// Tek bir kaydın hatası işi durdurmaz; hata sayılır ve sonuncusu saklanır.
+// item hata mesajlarında kaydı tanımlar (ör. "medya 12").
+func (s *MediaService) recordJobItem(job *domain.MediaJob, item string, err error) {
+	if err != nil {
+		log.Printf("%s işi %s için başarısız: %v", mediaJobLabels[job.Kind], item, err)
+	}
+	s.updateJob(job, func(j *domain.MediaJob) {
+		j.Processed++
+		if err != nil {
+			j.Failed++
+			j.LastError = fmt.Sprintf("%s: %v", item, err)
+		}
+	})
+}
+
+// updateJob iş durumunu kilit altında günceller
+func (s *MediaService) updateJob(job *domain.MediaJob, update func(j *domain.MediaJob)) {
+	s.jobMu.Lock()
+	defer s.jobMu.Unlock()
+	update(job)
+}
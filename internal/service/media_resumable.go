@@ -0,0 +1,301 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/username/haber/internal/domain"
+	"github.com/username/haber/pkg/auth"
+)
+
+// resumablePartPrefix parçalı yüklemelerin parçalarının depodaki ön eki
+const resumablePartPrefix = "tus"
+
+// resumableCleanupBatch tek seferde temizlenen süresi dolmuş yükleme sayısı
+const resumableCleanupBatch = 20
+
+// MaxUploadSize tek bir dosyanın en büyük türdeki sınırını bayt cinsinden döner
+func (s *MediaService) MaxUploadSize() int64 {
+	var largest int64
+	for _, limit := range s.uploadLimits {
+		if limit > largest {
+			largest = limit
+		}
+	}
+	return largest
+}
+
+// CreateResumableUpload yeni bir parçalı yükleme başlatır
+//
+// Dosyanın türü ve türe özgü boyut sınırı, yükleme tamamlandığında normal
+// yükleme akışında doğrulanır; burada yalnızca en büyük sınır uygulanır.
+func (s *MediaService) CreateResumableUpload(length int64, filename, folder string, actor *domain.Actor) (*domain.ResumableUpload, error) {
+	if err := s.policy.Authorize(actor, domain.PermMediaUpload); err != nil {
+		return nil, err
+	}
+
+	if s.storage == nil {
+		return nil, domain.NewServiceUnavailableError(ErrStorageUnavailable, ErrStorageUnavailable.Error())
+	}
+
+	if length <= 0 {
+		return nil, &domain.ValidationError{
+			Field:   "Upload-Length",
+			Message: "Dosya boyutu sıfırdan büyük olmalıdır",
+		}
+	}
+	if limit := s.MaxUploadSize(); limit > 0 && length > limit {
+		return nil, &domain.ValidationError{
+			Field:   "Upload-Length",
+			Message: fmt.Sprintf("Dosya en fazla %dMB olabilir", limit/(1024*1024)),
+		}
+	}
+
+	folder, err := normalizeMediaFolder(folder)
+	if err != nil {
+		return nil, err
+	}
+
+	// Yarıda bırakılmış yüklemelerin parçalarını temizle
+	s.cleanupExpiredUploads()
+
+	id, err := auth.GenerateRandomToken(16)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	upload := &domain.ResumableUpload{
+		ID:        id,
+		UserID:    actor.UserID,
+		Filename:  uploadFilename(filename),
+		Folder:    folder,
+		Length:    length,
+		ExpiresAt: now.Add(s.resumableExpiry),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.uploadRepo.Create(upload); err != nil {
+		return nil, err
+	}
+
+	return upload, nil
+}
+
+// GetResumableUpload kullanıcının parçalı yüklemesini getirir
+//
+// Başka kullanıcıya ait veya süresi dolmuş yüklemeler bulunamadı olarak döner.
+func (s *MediaService) GetResumableUpload(id string, actor *domain.Actor) (*domain.ResumableUpload, error) {
+	upload, err := s.uploadRepo.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if actor == nil || upload.UserID != actor.UserID || !time.Now().Before(upload.ExpiresAt) {
+		return nil, &domain.NotFoundError{
+			ResourceType: domain.ResourceUpload,
+			ID:           id,
+		}
+	}
+
+	return upload, nil
+}
+
+// AppendResumableUpload verilen konumdan itibaren bir parça ekler
+//
+// Konum yüklemenin mevcut konumuyla eşleşmezse çakışma hatası döner. Son
+// parça eklendiğinde dosya birleştirilip normal yükleme akışından geçirilir
+// ve oluşturulan medya döner; aksi halde medya nil'dir.
+func (s *MediaService) AppendResumableUpload(id string, offset int64, chunk io.Reader, size int64, actor *domain.Actor) (*domain.ResumableUpload, *domain.Media, error) {
+	if s.storage == nil {
+		return nil, nil, domain.NewServiceUnavailableError(ErrStorageUnavailable, ErrStorageUnavailable.Error())
+	}
+
+	// Aynı yüklemeye eş zamanlı parça yazılmasını engelle
+	unlock := s.lockUpload(id)
+	defer unlock()
+
+	upload, err := s.GetResumableUpload(id, actor)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if upload.Completed() {
+		return nil, nil, domain.NewConflictError("Yükleme zaten tamamlandı")
+	}
+	if offset != upload.Offset {
+		return nil, nil, domain.NewConflictError(
+			fmt.Sprintf("Yükleme konumu uyuşmuyor: beklenen %d, gönderilen %d", upload.Offset, offset))
+	}
+	if size > upload.Length-upload.Offset {
+		return nil, nil, &domain.ValidationError{
+			Field:   "body",
+			Message: "Parça, bildirilen dosya boyutunu aşıyor",
+		}
+	}
+
+	if size > 0 {
+		if _, err := s.storage.Put(resumablePartKey(upload.ID, upload.Parts), chunk, size, "application/octet-stream"); err != nil {
+			return nil, nil, err
+		}
+		upload.Offset += size
+		upload.Parts++
+		upload.ExpiresAt = time.Now().Add(s.resumableExpiry)
+		upload.UpdatedAt = time.Now()
+		if err := s.uploadRepo.Update(upload); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if upload.Offset < upload.Length {
+		return upload, nil, nil
+	}
+
+	media, err := s.completeResumableUpload(upload, actor)
+	if err != nil {
+		return nil, nil, err
+	}
+	return upload, media, nil
+}
+
+// DeleteResumableUpload yüklemeyi iptal eder ve parçalarını siler
+func (s *MediaService) DeleteResumableUpload(id string, actor *domain.Actor) error {
+	unlock := s.lockUpload(id)
+	defer unlock()
+
+	upload, err := s.GetResumableUpload(id, actor)
+	if err != nil {
+		return err
+	}
+
+	return s.discardResumableUpload(upload)
+}
+
+// completeResumableUpload parçaları geçici dosyada birleştirir ve dosyayı
+// normal yükleme akışından geçirir
+//
+// Dosya reddedilirse (ör. desteklenmeyen tür) yükleme silinir ve hata döner.
+func (s *MediaService) completeResumableUpload(upload *domain.ResumableUpload, actor *domain.Actor) (*domain.Media, error) {
+	tmp, err := os.CreateTemp("", "haber-upload-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	for part := 0; part < upload.Parts; part++ {
+		if err := s.copyPart(tmp, upload.ID, part); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	media, err := s.ingest(tmp, upload.Length, upload.Filename, upload.Folder, actor)
+	if err != nil {
+		var validationErr *domain.ValidationError
+		if errors.As(err, &validationErr) {
+			if discardErr := s.discardResumableUpload(upload); discardErr != nil {
+				log.Printf("Reddedilen parçalı yükleme silinemedi (%s): %v", upload.ID, discardErr)
+			}
+		}
+		return nil, err
+	}
+
+	// Kayıt, istemcinin HEAD ile sonucu öğrenebilmesi için süresi dolana kadar tutulur
+	s.deleteParts(upload)
+	upload.MediaID = &media.ID
+	upload.Parts = 0
+	upload.UpdatedAt = time.Now()
+	if err := s.uploadRepo.Update(upload); err != nil {
+		log.Printf("Parçalı yükleme durumu güncellenemedi (%s): %v", upload.ID, err)
+	}
+
+	return media, nil
+}
+
+// copyPart tek bir parçayı depodan okuyup hedefe yazar
+func (s *MediaService) copyPart(dst io.Writer, uploadID string, part int) error {
+	reader, _, err := s.storage.Get(resumablePartKey(uploadID, part), nil)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	_, err = io.Copy(dst, reader)
+	return err
+}
+
+// discardResumableUpload yüklemenin parçalarını ve kaydını siler
+func (s *MediaService) discardResumableUpload(upload *domain.ResumableUpload) error {
+	s.deleteParts(upload)
+	return s.uploadRepo.Delete(upload.ID)
+}
+
+// deleteParts yüklemenin depodaki parçalarını siler; hatalar yalnızca loglanır
+func (s *MediaService) deleteParts(upload *domain.ResumableUpload) {
+	if s.storage == nil {
+		return
+	}
+	for part := 0; part < upload.Parts; part++ {
+		key := resumablePartKey(upload.ID, part)
+		if err := s.storage.Delete(key); err != nil {
+			log.Printf("Yükleme parçası silinemedi (%s): %v", key, err)
+		}
+	}
+}
+
+// cleanupExpiredUploads süresi dolmuş yüklemelerin bir kısmını temizler ve
+// silinen kayıt sayısını döner
+func (s *MediaService) cleanupExpiredUploads() int {
+	expired, err := s.uploadRepo.ListExpired(time.Now(), resumableCleanupBatch)
+	if err != nil {
+		log.Printf("Süresi dolmuş parçalı yüklemeler listelenemedi: %v", err)
+		return 0
+	}
+
+	removed := 0
+	for _, upload := range expired {
+		if err := s.discardResumableUpload(upload); err != nil {
+			log.Printf("Süresi dolmuş parçalı yükleme silinemedi (%s): %v", upload.ID, err)
+			continue
+		}
+		removed++
+	}
+	return removed
+}
+
+// lockUpload yükleme için süreç içi kilidi alır ve bırakma fonksiyonunu döner
+//
+// Kilitler sabit sayıda dilime bölünmüştür; farklı yüklemeler nadiren aynı
+// kilidi paylaşır.
+func (s *MediaService) lockUpload(id string) func() {
+	hasher := fnv.New32a()
+	hasher.Write([]byte(id))
+	mu := &s.uploadLocks[hasher.Sum32()%uint32(len(s.uploadLocks))]
+	mu.Lock()
+	return mu.Unlock
+}
+
+// uploadFilename istemcinin bildirdiği dosya adından dizin bilgisini atar;
+// ad boşsa varsayılan ad döner
+func uploadFilename(filename string) string {
+	filename = filepath.Base(strings.TrimSpace(filename))
+	if filename == "" || filename == "." || filename == string(filepath.Separator) {
+		filename = "upload"
+	}
+	return truncate(filename, 255)
+}
+
+// resumablePartKey parçanın depodaki anahtarını üretir (ör. tus/abc123/000004)
+func resumablePartKey(uploadID string, part int) string {
+	return fmt.Sprintf("%s/%s/%06d", resumablePartPrefix, uploadID, part)
+}
@@ -1,168 +1,555 @@
 package service
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
+	"io"
+	"log"
 	"mime/multipart"
 	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/username/haber/internal/config"
 	"github.com/username/haber/internal/domain"
 	"github.com/username/haber/internal/repository"
+	"github.com/username/haber/pkg/auth"
+	"github.com/username/haber/pkg/imaging"
 	"github.com/username/haber/pkg/storage"
 )
 
+// defaultMediaFolder klasör belirtilmeyen yüklemelerin klasörü
+const defaultMediaFolder = "general"
+
+// mediaFolderPattern klasör adlarında izin verilen karakterler
+var mediaFolderPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,49}$`)
+
 // IMediaService medya işlemleri için servis arayüzü
+//
+// Dosyaların nerede saklandığı (yerel disk, MinIO/S3) yapılandırmadaki
+// depolama sürücüsüne bağlıdır; servisi kullananlar bunu bilmez.
 type IMediaService interface {
-	UploadFile(file *multipart.FileHeader, folder string, userID uint) (*domain.Media, error)
-	GetFileURL(media *domain.Media, expires time.Duration) (string, error)
-	DeleteFile(id uint) error
-	GetMediaByID(id uint) (*domain.Media, error)
-	GetMediaByUser(userID uint) ([]*domain.Media, error)
+	UploadFile(file *multipart.FileHeader, folder string, actor *domain.Actor) (*domain.Media, error)
+	GetMedia(id uint) (*domain.Media, error)
+	MaxUploadSize() int64
+	CreateResumableUpload(length int64, filename, folder string, actor *domain.Actor) (*domain.ResumableUpload, error)
+	GetResumableUpload(id string, actor *domain.Actor) (*domain.ResumableUpload, error)
+	AppendResumableUpload(id string, offset int64, chunk io.Reader, size int64, actor *domain.Actor) (*domain.ResumableUpload, *domain.Media, error)
+	DeleteResumableUpload(id string, actor *domain.Actor) error
+	CreateDirectUpload(req *domain.CreateDirectUploadRequest, actor *domain.Actor) (*domain.DirectUploadResponse, error)
+	ConfirmDirectUpload(id string, actor *domain.Actor) (*domain.Media, error)
+	GetMediaByUser(userID uint, offset, limit int) ([]*domain.Media, int64, error)
 	ListMedia(offset, limit int) ([]*domain.Media, int64, error)
-	GetMediaContent(objectName string) ([]byte, error)
-	DeleteMedia(id uint, userID uint) error
+	SearchMedia(filter *domain.MediaFilter, offset, limit int) ([]*domain.Media, int64, error)
+	UpdateMedia(id uint, req *domain.UpdateMediaRequest, actor *domain.Actor) (*domain.Media, error)
+	GetContent(media *domain.Media, preset string, width int) (*domain.MediaContent, error)
+	StatContent(content *domain.MediaContent) (*storage.ObjectInfo, error)
+	OpenContent(content *domain.MediaContent, rng *storage.Range) (io.ReadCloser, *storage.ObjectInfo, error)
+	ToResponse(media *domain.Media) *domain.MediaResponse
+	DeleteMedia(id uint, force bool, actor *domain.Actor) error
+	ListUsages(id uint) ([]*domain.MediaUsage, error)
+	RebuildUsages(actor *domain.Actor) (*domain.MediaJob, error)
+	GetUsageJob() (*domain.MediaJob, error)
+	RunGarbageCollection(deleteFound bool, actor *domain.Actor) (*domain.MediaJob, error)
+	GetGarbageCollection() (*domain.MediaJob, *domain.MediaGCReport, error)
+	StartGarbageCollection(interval time.Duration) (stop func())
+	RegenerateDerivatives(actor *domain.Actor) (*domain.MediaJob, error)
+	GetDerivativeJob() (*domain.MediaJob, error)
+	BackfillContentHashes(actor *domain.Actor) (*domain.MediaJob, error)
+	GetContentHashJob() (*domain.MediaJob, error)
+	ListDuplicates(offset, limit int) ([]*domain.DuplicateCluster, int64, error)
+	ImportArchive(file *multipart.FileHeader, folder string, actor *domain.Actor) (*domain.MediaJob, error)
+	GetImportJob(id string) (*domain.MediaJob, error)
 }
 
 // MediaService MediaService'in implementasyonu
 type MediaService struct {
-	mediaRepo   repository.IMediaRepository
-	minioClient *storage.MinioService
+	mediaRepo  repository.IMediaRepository
+	tagRepo    repository.ITagRepository
+	uploadRepo repository.IResumableUploadRepository
+	directRepo repository.IDirectUploadRepository
+	usage      IMediaUsageService
+	storage    storage.Backend
+	policy     IPolicyService
+	audit      IAuditService
+
+	uploadLimits   map[mediaCategory]int64
+	maxImagePixels int64
+	urlExpiry      time.Duration
+	presets        []imaging.Preset
+	jpegQuality    int
+
+	resumableExpiry  time.Duration
+	directExpiry     time.Duration
+	importMaxEntries int
+	importMaxTotal   int64
+	uploadLocks      [32]sync.Mutex // Parçalı ve doğrudan yüklemelerin eş zamanlı işlenmesini engeller
+
+	jobMu    sync.Mutex
+	jobs     map[string]*domain.MediaJob // Türüne göre son arka plan işi
+	gcReport *domain.MediaGCReport       // Son depo taramasının sonucu
+
+	gcDelete bool
+	gcGrace  time.Duration
 }
 
 // NewMediaService yeni bir MediaService oluşturur
-func NewMediaService(mediaRepo repository.IMediaRepository, minioClient *storage.MinioService) IMediaService {
+func NewMediaService(
+	mediaRepo repository.IMediaRepository,
+	tagRepo repository.ITagRepository,
+	uploadRepo repository.IResumableUploadRepository,
+	directRepo repository.IDirectUploadRepository,
+	usage IMediaUsageService,
+	backend storage.Backend,
+	cfg config.IConfig,
+	policy IPolicyService,
+	audit IAuditService,
+) IMediaService {
+	presets, err := imaging.ParsePresets(cfg.GetImage().GetDerivatives())
+	if err != nil {
+		log.Printf("Görsel türevi tanımı geçersiz, türev üretilmeyecek: %v", err)
+		presets = nil
+	}
+
 	return &MediaService{
-		mediaRepo:   mediaRepo,
-		minioClient: minioClient,
+		mediaRepo:  mediaRepo,
+		tagRepo:    tagRepo,
+		uploadRepo: uploadRepo,
+		directRepo: directRepo,
+		usage:      usage,
+		storage:    backend,
+		policy:     policy,
+		audit:      audit,
+		uploadLimits: buildUploadLimits(cfg.GetServer().GetMaxUploadMB(), map[mediaCategory]int{
+			mediaCategoryImage:    cfg.GetUpload().GetMaxImageMB(),
+			mediaCategoryDocument: cfg.GetUpload().GetMaxDocumentMB(),
+			mediaCategoryArchive:  cfg.GetUpload().GetMaxArchiveMB(),
+		}),
+		maxImagePixels:   int64(cfg.GetUpload().GetMaxImageMegapixels()) * 1_000_000,
+		urlExpiry:        time.Duration(cfg.GetStorage().GetURLExpiryMinutes()) * time.Minute,
+		presets:          presets,
+		jpegQuality:      cfg.GetImage().GetJPEGQuality(),
+		resumableExpiry:  time.Duration(cfg.GetUpload().GetResumableExpiryHours()) * time.Hour,
+		directExpiry:     time.Duration(cfg.GetUpload().GetDirectExpiryMinutes()) * time.Minute,
+		importMaxEntries: cfg.GetUpload().GetImportMaxEntries(),
+		importMaxTotal:   int64(cfg.GetUpload().GetImportMaxTotalMB()) * 1024 * 1024,
+		gcDelete:         cfg.GetStorage().GetGCDelete(),
+		gcGrace:          time.Duration(cfg.GetStorage().GetGCGraceHours()) * time.Hour,
 	}
 }
 
-// ErrMinioServiceUnavailable MinIO servisi kullanılamadığında döndürülen hata
-var ErrMinioServiceUnavailable = errors.New("MinIO servisi kullanılamıyor, medya işlemleri devre dışı")
+// ErrStorageUnavailable dosya deposu kullanılamadığında döndürülen hata
+var ErrStorageUnavailable = errors.New("Dosya deposu kullanılamıyor, medya işlemleri devre dışı")
 
-// UploadFile dosyayı MinIO'ya yükler ve medya kaydı oluşturur
-func (s *MediaService) UploadFile(file *multipart.FileHeader, folder string, userID uint) (*domain.Media, error) {
-	// MinIO servisi bağlantısını kontrol et
-	if s.minioClient == nil {
-		return nil, ErrMinioServiceUnavailable
+// UploadFile dosyayı depoya yükler ve medya kaydı oluşturur
+func (s *MediaService) UploadFile(file *multipart.FileHeader, folder string, actor *domain.Actor) (*domain.Media, error) {
+	if err := s.policy.Authorize(actor, domain.PermMediaUpload); err != nil {
+		return nil, err
 	}
 
-	// Dosyayı aç
-	fileReader, err := file.Open()
+	if s.storage == nil {
+		return nil, domain.NewServiceUnavailableError(ErrStorageUnavailable, ErrStorageUnavailable.Error())
+	}
+
+	folder, err := normalizeMediaFolder(folder)
 	if err != nil {
 		return nil, err
 	}
-	defer fileReader.Close()
 
-	// Dosyayı MinIO'ya yükle
-	objectName, err := s.minioClient.UploadFile(
-		fileReader,
-		file.Filename,
-		int64(file.Size),
-		file.Header.Get("Content-Type"),
-	)
+	src, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	return s.ingest(src, file.Size, file.Filename, folder, actor)
+}
+
+// ingest yüklenen dosyayı doğrular, depoya yazar ve medya kaydını oluşturur
+//
+// Hem tek istekte hem parçalı (tus) yüklenen dosyalar bu akıştan geçer;
+// yetki ve klasör kontrolü çağıranın sorumluluğundadır.
+func (s *MediaService) ingest(src mediaSource, fileSize int64, filename, folder string, actor *domain.Actor) (*domain.Media, error) {
+	// Tür ve boyut dosya içeriğinden doğrulanır; istemcinin bildirdiği tür kullanılmaz
+	detected, err := s.validateUpload(src, fileSize, filename)
+	if err != nil {
+		return nil, err
+	}
+	contentType := detected.ContentType
+
+	// Görsellerde EXIF bilgileri okunur ve konum bilgisi yayımlanmadan önce
+	// silinir; türevler de temizlenmiş içerikten üretilir
+	var body io.Reader = src
+	size := fileSize
+	var imageData []byte
+	var exifMeta *imaging.Metadata
+	var contentHash string
+	if imaging.IsSupported(contentType) {
+		if imageData, err = io.ReadAll(src); err != nil {
+			return nil, err
+		}
+		format := strings.TrimPrefix(contentType, "image/")
+		exifMeta = imaging.ReadMetadata(imageData, format)
+		cleaned, stripped := imaging.StripGPS(imageData, format)
+		if exifMeta.HasGPS && (!stripped || imaging.ReadMetadata(cleaned, format).HasGPS) {
+			// Konum bilgisi yerinde silinemediyse görsel meta verisiz yeniden
+			// kodlanır; bu da olmazsa yükleme reddedilir
+			if cleaned, err = s.reencodeImage(imageData, format); err != nil {
+				return nil, &domain.ValidationError{
+					Field:   "file",
+					Message: "Görseldeki konum bilgisi temizlenemedi",
+				}
+			}
+		}
+		imageData = cleaned
+		body = bytes.NewReader(imageData)
+		size = int64(len(imageData))
+		contentHash = hashBytes(imageData)
+	} else {
+		// Yüklenen dosya sunucuda tamponlandığından özet depoya yazmadan önce
+		// hesaplanır; böylece kopya içerik depoya hiç gönderilmez
+		if contentHash, err = hashContent(src); err != nil {
+			return nil, err
+		}
+		if _, err := src.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+	}
+
+	// Aynı içerik daha önce yüklendiyse mevcut nesne paylaşılır
+	object, reused, err := s.storeObject(folder, detected.Ext, contentType, contentHash, body, size)
 	if err != nil {
 		return nil, err
 	}
 
-	// Medya bilgilerini veritabanına kaydet
 	media := &domain.Media{
-		Filename:    filepath.Base(file.Filename),
-		ObjectName:  objectName,
-		ContentType: file.Header.Get("Content-Type"),
-		Filesize:    uint(file.Size),
-		UserID:      userID,
+		Filename:    filepath.Base(filename),
+		ObjectName:  object.ObjectName,
+		ContentType: contentType,
+		Filesize:    object.Filesize,
+		ContentHash: contentHash,
+		UserID:      actor.UserID,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
+	if exifMeta != nil {
+		applyExifMetadata(media, exifMeta)
+	}
+
+	var sharedDerivatives []*domain.MediaDerivative
+	shared := false
+	if reused && imageData != nil {
+		sharedDerivatives, shared = s.shareDerivatives(media)
+	}
 
-	// Veritabanına kaydet
 	if err := s.mediaRepo.Create(media); err != nil {
-		// Veritabanına kayıt başarısız olursa, MinIO'dan da sil
-		_ = s.minioClient.DeleteFile(objectName) // Hata görmezden geliniyor
+		// Veritabanına kayıt başarısız olursa nesnedeki referansı da bırak
+		if releaseErr := s.releaseObject(media); releaseErr != nil {
+			log.Printf("Depo nesnesi bırakılamadı (%s): %v", media.ObjectName, releaseErr)
+		}
 		return nil, err
 	}
 
+	// Görsel türevleri üretilemese de orijinal kullanılabilir; hata yüklemeyi bozmaz
+	switch {
+	case shared:
+		if err := s.mediaRepo.ReplaceDerivatives(media.ID, sharedDerivatives); err != nil {
+			log.Printf("Görsel türevleri kopyalanamadı (medya %d): %v", media.ID, err)
+		} else {
+			media.Derivatives = sharedDerivatives
+		}
+	case !shared && imageData != nil:
+		if err := s.generateDerivatives(media, bytes.NewReader(imageData)); err != nil {
+			log.Printf("Görsel türevleri üretilemedi (medya %d): %v", media.ID, err)
+		}
+	}
+
+	s.audit.Record(actor, domain.AuditActionUpload, domain.ResourceMedia, media.ID, nil, media)
 	return media, nil
 }
 
-// GetFileURL dosyanın geçici URL'sini oluşturur
-func (s *MediaService) GetFileURL(media *domain.Media, expires time.Duration) (string, error) {
-	if s.minioClient == nil {
-		return "", ErrMinioServiceUnavailable
+// GetMedia ID'ye göre medya kaydını bulur
+func (s *MediaService) GetMedia(id uint) (*domain.Media, error) {
+	return s.mediaRepo.Get(id)
+}
+
+// GetMediaByUser kullanıcıya ait medya kayıtlarını listeler
+func (s *MediaService) GetMediaByUser(userID uint, offset, limit int) ([]*domain.Media, int64, error) {
+	return s.mediaRepo.GetByUser(userID, offset, limit)
+}
+
+// ListMedia medya kayıtlarını listeler
+func (s *MediaService) ListMedia(offset, limit int) ([]*domain.Media, int64, error) {
+	return s.mediaRepo.List(offset, limit)
+}
+
+// SearchMedia medya kütüphanesinde arama yapar
+func (s *MediaService) SearchMedia(filter *domain.MediaFilter, offset, limit int) ([]*domain.Media, int64, error) {
+	filter.Query = strings.TrimSpace(filter.Query)
+
+	if filter.Kind != "" {
+		filter.ContentTypes = mediaContentTypes(mediaCategory(filter.Kind))
+		if filter.ContentTypes == nil {
+			return nil, 0, &domain.ValidationError{
+				Field:   "kind",
+				Message: "Geçersiz dosya grubu (image, document veya archive olmalıdır)",
+			}
+		}
 	}
-	return s.minioClient.GetFileURL(media.ObjectName, expires)
+
+	return s.mediaRepo.Search(filter, offset, limit)
 }
 
-// DeleteFile dosya ve medya kaydını siler
-func (s *MediaService) DeleteFile(id uint) error {
-	// Medya kaydını bul
+// UpdateMedia medyanın künye bilgilerini ve etiketlerini günceller
+func (s *MediaService) UpdateMedia(id uint, req *domain.UpdateMediaRequest, actor *domain.Actor) (*domain.Media, error) {
 	media, err := s.mediaRepo.Get(id)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// MinIO servisi bağlantısını kontrol et
-	if s.minioClient == nil {
-		return ErrMinioServiceUnavailable
+	if err := s.policy.AuthorizeMediaUpdate(actor, media); err != nil {
+		return nil, err
 	}
+	before := auditSnapshot(media)
 
-	// MinIO'dan dosyayı sil
-	err = s.minioClient.DeleteFile(media.ObjectName)
-	if err != nil {
-		return err
+	for _, field := range []struct {
+		value  *string
+		target *string
+	}{
+		{req.AltText, &media.AltText},
+		{req.Caption, &media.Caption},
+		{req.Credit, &media.Credit},
+		{req.Source, &media.Source},
+		{req.Copyright, &media.Copyright},
+	} {
+		if field.value != nil {
+			*field.target = strings.TrimSpace(*field.value)
+		}
 	}
 
-	// DB'den medya kaydını sil
-	return s.mediaRepo.Delete(id)
-}
+	var tags []*domain.Tag
+	if req.TagIDs != nil {
+		tags = make([]*domain.Tag, 0, len(*req.TagIDs))
+		for _, tagID := range *req.TagIDs {
+			tag, err := s.tagRepo.GetByID(tagID)
+			if err != nil {
+				return nil, err
+			}
+			tags = append(tags, tag)
+		}
+	}
 
-// GetMediaByID ID'ye göre medya kaydını bulur
-func (s *MediaService) GetMediaByID(id uint) (*domain.Media, error) {
-	return s.mediaRepo.Get(id)
-}
+	media.UpdatedAt = time.Now()
+	if err := s.mediaRepo.Update(media); err != nil {
+		return nil, err
+	}
+	if tags != nil {
+		if err := s.mediaRepo.ReplaceTags(media, tags); err != nil {
+			return nil, err
+		}
+	}
 
-// GetMediaByUser kullanıcıya ait medya kayıtlarını listeler
-func (s *MediaService) GetMediaByUser(userID uint) ([]*domain.Media, error) {
-	result, _, err := s.mediaRepo.GetByUser(userID, 0, 1000) // Şimdilik tüm kayıtları getir
-	return result, err
+	s.audit.Record(actor, domain.AuditActionUpdate, domain.ResourceMedia, media.ID, before, media)
+	return media, nil
 }
 
-// ListMedia medya kayıtlarını listeler
-func (s *MediaService) ListMedia(offset, limit int) ([]*domain.Media, int64, error) {
-	return s.mediaRepo.List(offset, limit)
+// ToResponse medya kaydından istemciye dönülecek yanıtı oluşturur
+//
+// Depo doğrudan erişime açıksa (herkese açık adres veya imzalı adres) URL
+// oraya, değilse API üzerinden indirme adresine işaret eder.
+func (s *MediaService) ToResponse(media *domain.Media) *domain.MediaResponse {
+	response := &domain.MediaResponse{
+		ID:          media.ID,
+		Filename:    media.Filename,
+		ObjectName:  media.ObjectName,
+		ContentType: media.ContentType,
+		Filesize:    media.Filesize,
+		ContentHash: media.ContentHash,
+		Width:       media.Width,
+		Height:      media.Height,
+		AltText:     media.AltText,
+		Caption:     media.Caption,
+		Credit:      media.Credit,
+		Source:      media.Source,
+		Copyright:   media.Copyright,
+		CapturedAt:  media.CapturedAt,
+		CameraMake:  media.CameraMake,
+		CameraModel: media.CameraModel,
+		Tags:        media.Tags,
+		URL:         s.objectURL(media.ObjectName, fmt.Sprintf("/api/uploads/%d", media.ID)),
+		CreatedAt:   media.CreatedAt,
+	}
+
+	for _, derivative := range media.Derivatives {
+		fallback := fmt.Sprintf("/api/uploads/%d/%s/%d", media.ID, derivative.Preset, derivative.Width)
+		response.Derivatives = append(response.Derivatives, &domain.MediaDerivativeResponse{
+			Preset:      derivative.Preset,
+			Width:       derivative.Width,
+			Height:      derivative.Height,
+			ContentType: derivative.ContentType,
+			URL:         s.objectURL(derivative.ObjectName, fallback),
+		})
+	}
+	response.BuildSrcSet()
+
+	return response
 }
 
-// GetMediaContent medya içeriğini getirir
-func (s *MediaService) GetMediaContent(objectName string) ([]byte, error) {
-	// Bu fonksiyon henüz implement edilmedi
-	return nil, errors.New("not implemented")
+// objectURL nesnenin doğrudan adresini, yoksa API üzerinden indirme adresini döner
+func (s *MediaService) objectURL(key, fallback string) string {
+	if s.storage == nil {
+		return fallback
+	}
+	url, err := s.storage.URL(key, s.urlExpiry)
+	if err != nil || url == "" {
+		return fallback
+	}
+	return url
 }
 
 // DeleteMedia medyayı siler
-func (s *MediaService) DeleteMedia(id uint, userID uint) error {
-	// Medyayı getir
+//
+// Makale veya profillerde kullanılan medya silinmez, çakışma hatası döner.
+// force ile kullanımdaki medya da silinebilir; bunun için tüm medyaları
+// silme yetkisi gerekir.
+func (s *MediaService) DeleteMedia(id uint, force bool, actor *domain.Actor) error {
 	media, err := s.mediaRepo.Get(id)
 	if err != nil {
 		return err
 	}
 
-	// Sadece medyayı yükleyen kullanıcı veya admin silebilir
-	// Admin kontrolü burada yapılmıyor, üst katmanda yapılmalı
-	if media.UserID != userID {
-		return domain.ErrForbidden
+	if err := s.policy.AuthorizeMediaDelete(actor, media); err != nil {
+		return err
+	}
+
+	usages, err := s.usage.ListUsages(id)
+	if err != nil {
+		return err
+	}
+	if len(usages) > 0 {
+		if !force {
+			return domain.NewConflictError(fmt.Sprintf("Medya %s kullanılıyor; silmek için force=true gönderin", describeUsages(usages)))
+		}
+		if err := s.policy.Authorize(actor, domain.PermMediaDeleteAny); err != nil {
+			return err
+		}
 	}
 
-	// MinIO servisi bağlantısını kontrol et
-	if s.minioClient == nil {
-		return ErrMinioServiceUnavailable
+	if s.storage == nil {
+		return domain.NewServiceUnavailableError(ErrStorageUnavailable, ErrStorageUnavailable.Error())
 	}
 
-	// Önce MinIO'dan sil
-	if err := s.minioClient.DeleteFile(media.ObjectName); err != nil {
+	// Veritabanından sil
+	if err := s.mediaRepo.Delete(id); err != nil {
 		return err
 	}
 
-	// Veritabanından sil
-	return s.mediaRepo.Delete(id)
+	// Nesne başka medyalarca da kullanılıyorsa depoda kalır. Kayıt silindiği
+	// için depo hatası isteği bozmaz, yalnızca loglanır.
+	if err := s.releaseObject(media); err != nil {
+		log.Printf("Medya %d silindi ancak depo nesnesi (%s) temizlenemedi: %v", id, media.ObjectName, err)
+	}
+
+	s.audit.Record(actor, domain.AuditActionDelete, domain.ResourceMedia, id, media, nil)
+	return nil
+}
+
+// ListUsages medyanın kullanıldığı makale ve profilleri listeler
+func (s *MediaService) ListUsages(id uint) ([]*domain.MediaUsage, error) {
+	if _, err := s.mediaRepo.Get(id); err != nil {
+		return nil, err
+	}
+	return s.usage.ListUsages(id)
+}
+
+// RebuildUsages tüm makale ve kullanıcıların medya kullanımlarını arka planda
+// yeniden oluşturur
+func (s *MediaService) RebuildUsages(actor *domain.Actor) (*domain.MediaJob, error) {
+	total, err := s.usage.CountOwners()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.startJob(domain.MediaJobUsages, total, actor, func(job *domain.MediaJob) error {
+		return s.usage.Rebuild(func(item string, err error) {
+			s.recordJobItem(job, item, err)
+		})
+	})
+}
+
+// GetUsageJob son kullanım yeniden oluşturma işinin durumunu döner
+func (s *MediaService) GetUsageJob() (*domain.MediaJob, error) {
+	return s.getJob(domain.MediaJobUsages)
+}
+
+// describeUsages kullanım listesini hata mesajı için özetler
+// (ör. "2 makalede ve 1 profilde")
+func describeUsages(usages []*domain.MediaUsage) string {
+	articles := make(map[uint]bool)
+	users := make(map[uint]bool)
+	for _, usage := range usages {
+		switch usage.OwnerType {
+		case domain.MediaOwnerArticle:
+			articles[usage.OwnerID] = true
+		case domain.MediaOwnerUser:
+			users[usage.OwnerID] = true
+		}
+	}
+
+	var parts []string
+	if len(articles) > 0 {
+		parts = append(parts, fmt.Sprintf("%d makalede", len(articles)))
+	}
+	if len(users) > 0 {
+		parts = append(parts, fmt.Sprintf("%d profilde", len(users)))
+	}
+	return strings.Join(parts, " ve ")
+}
+
+// applyExifMetadata EXIF bilgilerini medya kaydına aktarır
+//
+// Künye ve telif alanları yalnızca öneri niteliğindedir; editör tarafından
+// sonradan değiştirilebilir.
+func applyExifMetadata(media *domain.Media, meta *imaging.Metadata) {
+	media.CapturedAt = meta.CapturedAt
+	media.CameraMake = truncate(meta.CameraMake, 100)
+	media.CameraModel = truncate(meta.CameraModel, 100)
+	if media.Credit == "" {
+		media.Credit = truncate(meta.Artist, 255)
+	}
+	if media.Copyright == "" {
+		media.Copyright = truncate(meta.Copyright, 255)
+	}
+}
+
+// normalizeMediaFolder klasör adını küçük harfe çevirir ve doğrular; boşsa
+// varsayılan klasörü döner
+//
+// Klasör adı nesne anahtarının parçası olduğundan yalnızca güvenli
+// karakterlere izin verilir.
+func normalizeMediaFolder(folder string) (string, error) {
+	folder = strings.ToLower(strings.TrimSpace(folder))
+	if folder == "" {
+		return defaultMediaFolder, nil
+	}
+	if !mediaFolderPattern.MatchString(folder) {
+		return "", &domain.ValidationError{
+			Field:   "folder",
+			Message: "Klasör adı yalnızca küçük harf, rakam, - ve _ içerebilir",
+		}
+	}
+	return folder, nil
+}
+
+// newMediaObjectName yüklenen dosya için tahmin edilemeyen bir nesne anahtarı üretir
+// (ör. general/2024/01/31/9f86d081884c7d65.jpg)
+func newMediaObjectName(folder, ext string) (string, error) {
+	name, err := auth.GenerateRandomToken(8)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/%s/%s%s", folder, time.Now().Format("2006/01/02"), name, ext), nil
 }
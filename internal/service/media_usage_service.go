@@ -0,0 +1,214 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/username/haber/internal/domain"
+	"github.com/username/haber/internal/repository"
+)
+
+// mediaUsageBatchSize kullanım kayıtları yeniden oluşturulurken tek seferde
+// okunan kayıt sayısı
+const mediaUsageBatchSize = 100
+
+// Metin içindeki medya referansları
+var (
+	// API üzerinden indirme adresleri (/api/uploads/{id} ve türevleri)
+	mediaURLPattern = regexp.MustCompile(`/api/uploads/(\d+)`)
+	// Depo anahtarları (herkese açık, imzalı veya CDN adreslerinin içinde)
+	// ör. general/2024/01/31/9f86d081884c7d65.jpg, …_card_480w.jpg
+	mediaKeyPattern = regexp.MustCompile(`[a-z0-9][a-z0-9_-]{0,49}/\d{4}/\d{2}/\d{2}/[0-9a-f]{16}(?:_[a-z0-9_-]+_\d+w)?\.[a-z0-9]+`)
+)
+
+// IMediaUsageService medya kullanım takibi için servis arayüzü
+//
+// Makale ve kullanıcı servisleri kayıt sırasında bu servisi çağırır; medya
+// servisi silme öncesinde kullanımı buradan kontrol eder.
+type IMediaUsageService interface {
+	SyncArticle(article *domain.Article) error
+	SyncUser(user *domain.User) error
+	RemoveOwner(ownerType string, ownerID uint) error
+	ListUsages(mediaID uint) ([]*domain.MediaUsage, error)
+	ListInUse(mediaIDs []uint) (map[uint]bool, error)
+	CountOwners() (int64, error)
+	Rebuild(report func(item string, err error)) error
+}
+
+// MediaUsageService IMediaUsageService'in implementasyonu
+type MediaUsageService struct {
+	usageRepo repository.IMediaUsageRepository
+	mediaRepo repository.IMediaRepository
+}
+
+// NewMediaUsageService yeni bir MediaUsageService oluşturur
+func NewMediaUsageService(usageRepo repository.IMediaUsageRepository, mediaRepo repository.IMediaRepository) IMediaUsageService {
+	return &MediaUsageService{
+		usageRepo: usageRepo,
+		mediaRepo: mediaRepo,
+	}
+}
+
+// SyncArticle makalenin kapak görseli ve içeriğindeki medya referanslarını kaydeder
+func (s *MediaUsageService) SyncArticle(article *domain.Article) error {
+	return s.sync(domain.MediaOwnerArticle, article.ID, map[string]string{
+		domain.MediaFieldFeaturedImage: article.FeaturedImage,
+		domain.MediaFieldContent:       article.Content,
+	})
+}
+
+// SyncUser kullanıcının profil görseli referansını kaydeder
+func (s *MediaUsageService) SyncUser(user *domain.User) error {
+	return s.sync(domain.MediaOwnerUser, user.ID, map[string]string{
+		domain.MediaFieldProfileImage: user.ProfileImage,
+	})
+}
+
+// RemoveOwner silinen kaydın medya kullanımlarını siler
+func (s *MediaUsageService) RemoveOwner(ownerType string, ownerID uint) error {
+	return s.usageRepo.DeleteForOwner(ownerType, ownerID)
+}
+
+// ListUsages medyanın kullanıldığı yerleri listeler
+func (s *MediaUsageService) ListUsages(mediaID uint) ([]*domain.MediaUsage, error) {
+	return s.usageRepo.ListByMedia(mediaID)
+}
+
+// ListInUse verilen medyalardan kullanımda olanları döner
+func (s *MediaUsageService) ListInUse(mediaIDs []uint) (map[uint]bool, error) {
+	ids, err := s.usageRepo.ListInUse(mediaIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	inUse := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		inUse[id] = true
+	}
+	return inUse, nil
+}
+
+// CountOwners medya referansı içerebilecek kayıt sayısını döner
+func (s *MediaUsageService) CountOwners() (int64, error) {
+	return s.usageRepo.CountOwners()
+}
+
+// Rebuild tüm makale ve kullanıcıların kullanım kayıtlarını yeniden oluşturur
+//
+// Kullanım takibinden önce kaydedilmiş içerik için kullanılır. Her kaydın
+// sonucu report ile bildirilir; tek bir kaydın hatası işlemi durdurmaz.
+func (s *MediaUsageService) Rebuild(report func(item string, err error)) error {
+	var afterID uint
+	for {
+		articles, err := s.usageRepo.ListArticlesAfter(afterID, mediaUsageBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(articles) == 0 {
+			break
+		}
+		for _, article := range articles {
+			afterID = article.ID
+			report(fmt.Sprintf("makale %d", article.ID), s.SyncArticle(article))
+		}
+	}
+
+	afterID = 0
+	for {
+		users, err := s.usageRepo.ListUsersAfter(afterID, mediaUsageBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(users) == 0 {
+			return nil
+		}
+		for _, user := range users {
+			afterID = user.ID
+			report(fmt.Sprintf("kullanıcı %d", user.ID), s.SyncUser(user))
+		}
+	}
+}
+
+// sync alanlardaki referansları çözümleyip kaydın kullanımlarını günceller
+func (s *MediaUsageService) sync(ownerType string, ownerID uint, fields map[string]string) error {
+	usages, err := s.resolve(fields)
+	if err != nil {
+		return err
+	}
+	return s.usageRepo.ReplaceForOwner(ownerType, ownerID, usages)
+}
+
+// resolve alanlardaki medya adreslerini var olan medya kayıtlarına eşler
+//
+// Kayıtla eşleşmeyen adresler (harici görseller, silinmiş medyalar) yok sayılır.
+func (s *MediaUsageService) resolve(fields map[string]string) ([]*domain.MediaUsage, error) {
+	idsByField := make(map[string][]uint)
+	keysByField := make(map[string][]string)
+	var allIDs []uint
+	var allKeys []string
+
+	for field, text := range fields {
+		if text == "" {
+			continue
+		}
+		for _, match := range mediaURLPattern.FindAllStringSubmatch(text, -1) {
+			id, err := strconv.ParseUint(match[1], 10, 32)
+			if err != nil {
+				continue
+			}
+			idsByField[field] = append(idsByField[field], uint(id))
+			allIDs = append(allIDs, uint(id))
+		}
+		for _, key := range mediaKeyPattern.FindAllString(text, -1) {
+			keysByField[field] = append(keysByField[field], key)
+			allKeys = append(allKeys, key)
+		}
+	}
+
+	existing, err := s.mediaRepo.FindExistingIDs(allIDs)
+	if err != nil {
+		return nil, err
+	}
+	exists := make(map[uint]bool, len(existing))
+	for _, id := range existing {
+		exists[id] = true
+	}
+
+	byKey, err := s.mediaRepo.FindIDsByObjectNames(allKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	type usageKey struct {
+		mediaID uint
+		field   string
+	}
+	seen := make(map[usageKey]bool)
+	usages := make([]*domain.MediaUsage, 0)
+	add := func(mediaID uint, field string) {
+		key := usageKey{mediaID, field}
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		usages = append(usages, &domain.MediaUsage{MediaID: mediaID, Field: field})
+	}
+
+	for field, ids := range idsByField {
+		for _, id := range ids {
+			if exists[id] {
+				add(id, field)
+			}
+		}
+	}
+	for field, keys := range keysByField {
+		for _, key := range keys {
+			if id, ok := byKey[key]; ok {
+				add(id, field)
+			}
+		}
+	}
+
+	return usages, nil
+}
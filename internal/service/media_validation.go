@@ -0,0 +1,228 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/username/haber/internal/domain"
+	"github.com/username/haber/pkg/imaging"
+)
+
+// mediaSniffLength içerik türü tespiti için okunan bayt sayısı
+const mediaSniffLength = 512
+
+// mediaCategory boyut sınırlarının uygulandığı dosya grubu
+type mediaCategory string
+
+// Dosya grupları
+const (
+	mediaCategoryImage    mediaCategory = "image"
+	mediaCategoryDocument mediaCategory = "document"
+	mediaCategoryArchive  mediaCategory = "archive"
+)
+
+// mediaCategoryLabels hata mesajlarında kullanılan grup adları
+var mediaCategoryLabels = map[mediaCategory]string{
+	mediaCategoryImage:    "Görsel",
+	mediaCategoryDocument: "Belge",
+	mediaCategoryArchive:  "Arşiv",
+}
+
+// mediaType izin verilen bir dosya türü
+type mediaType struct {
+	ContentType string
+	Ext         string
+	Category    mediaCategory
+}
+
+// İzin verilen dosya türleri
+var (
+	mediaTypeJPEG = &mediaType{"image/jpeg", ".jpg", mediaCategoryImage}
+	mediaTypePNG  = &mediaType{"image/png", ".png", mediaCategoryImage}
+	mediaTypeGIF  = &mediaType{"image/gif", ".gif", mediaCategoryImage}
+	mediaTypePDF  = &mediaType{"application/pdf", ".pdf", mediaCategoryDocument}
+	mediaTypeDOC  = &mediaType{"application/msword", ".doc", mediaCategoryDocument}
+	mediaTypeXLS  = &mediaType{"application/vnd.ms-excel", ".xls", mediaCategoryDocument}
+	mediaTypeDOCX = &mediaType{"application/vnd.openxmlformats-officedocument.wordprocessingml.document", ".docx", mediaCategoryDocument}
+	mediaTypeXLSX = &mediaType{"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", ".xlsx", mediaCategoryDocument}
+	mediaTypeZIP  = &mediaType{"application/zip", ".zip", mediaCategoryArchive}
+)
+
+// allowedMediaTypes izin verilen tüm dosya türleri
+var allowedMediaTypes = []*mediaType{
+	mediaTypeJPEG, mediaTypePNG, mediaTypeGIF,
+	mediaTypePDF, mediaTypeDOC, mediaTypeXLS, mediaTypeDOCX, mediaTypeXLSX,
+	mediaTypeZIP,
+}
+
+// Dosya imzaları (magic bytes)
+var (
+	signatureJPEG   = []byte("\xFF\xD8\xFF")
+	signaturePNG    = []byte("\x89PNG\r\n\x1a\n")
+	signatureGIF87a = []byte("GIF87a")
+	signatureGIF89a = []byte("GIF89a")
+	signaturePDF    = []byte("%PDF-")
+	signatureOLE    = []byte("\xD0\xCF\x11\xE0\xA1\xB1\x1A\xE1") // Eski Office belgeleri
+	signatureZIP    = []byte("PK\x03\x04")
+	signatureZIPNil = []byte("PK\x05\x06") // Boş ZIP arşivi
+)
+
+// errUnsupportedMedia içerik izin verilen türlerden biriyle eşleşmediğinde döner
+var errUnsupportedMedia = &domain.ValidationError{
+	Field:   "file",
+	Message: "Desteklenmeyen dosya formatı: dosya içeriği izin verilen türlerle (JPEG, PNG, GIF, PDF, Word, Excel, ZIP) eşleşmiyor",
+}
+
+// mediaSource içeriği doğrulanacak dosya; multipart dosyaları bu arayüzü sağlar
+type mediaSource interface {
+	io.Reader
+	io.ReaderAt
+	io.Seeker
+}
+
+// validateUpload dosyanın türünü içeriğinden tespit eder, tür sınırlarını
+// uygular ve okuma konumunu dosyanın başına geri alır
+//
+// İstemcinin gönderdiği Content-Type ve dosya uzantısına güvenilmez; uzantı
+// yalnızca aynı imzayı paylaşan Office türlerini ayırt etmek için kullanılır.
+func (s *MediaService) validateUpload(src mediaSource, size int64, filename string) (*mediaType, error) {
+	head := make([]byte, mediaSniffLength)
+	n, err := io.ReadFull(src, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	head = head[:n]
+
+	ext := strings.ToLower(filepath.Ext(filename))
+	detected := sniffMediaType(head, src, size, ext)
+	if detected == nil {
+		return nil, errUnsupportedMedia
+	}
+
+	if limit := s.uploadLimits[detected.Category]; limit > 0 && size > limit {
+		return nil, &domain.ValidationError{
+			Field: "file",
+			Message: fmt.Sprintf("%s dosyaları en fazla %dMB olabilir",
+				mediaCategoryLabels[detected.Category], limit/(1024*1024)),
+		}
+	}
+
+	if detected.Category == mediaCategoryImage {
+		if _, err := src.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		if err := s.validateImage(src); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := src.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return detected, nil
+}
+
+// validateImage görsel başlığının çözülebildiğini ve piksel sınırını
+// aşmadığını doğrular
+func (s *MediaService) validateImage(src io.Reader) error {
+	cfg, _, err := imaging.DecodeConfig(src, s.maxImagePixels)
+	if errors.Is(err, imaging.ErrTooManyPixels) {
+		return &domain.ValidationError{
+			Field: "file",
+			Message: fmt.Sprintf("Görsel çok büyük (%dx%d); en fazla %d milyon piksel olabilir",
+				cfg.Width, cfg.Height, s.maxImagePixels/1_000_000),
+		}
+	}
+	if err != nil {
+		return &domain.ValidationError{
+			Field:   "file",
+			Message: "Görsel dosyası bozuk veya okunamıyor",
+		}
+	}
+	return nil
+}
+
+// sniffMediaType dosyanın ilk baytlarından türünü tespit eder; izin verilmeyen
+// veya tanınmayan içerik için nil döner
+func sniffMediaType(head []byte, src io.ReaderAt, size int64, ext string) *mediaType {
+	switch {
+	case bytes.HasPrefix(head, signatureJPEG):
+		return mediaTypeJPEG
+	case bytes.HasPrefix(head, signaturePNG):
+		return mediaTypePNG
+	case bytes.HasPrefix(head, signatureGIF87a), bytes.HasPrefix(head, signatureGIF89a):
+		return mediaTypeGIF
+	case bytes.HasPrefix(head, signaturePDF):
+		return mediaTypePDF
+	case bytes.HasPrefix(head, signatureOLE):
+		// Word ve Excel aynı kapsayıcı biçimi kullanır
+		switch ext {
+		case ".doc":
+			return mediaTypeDOC
+		case ".xls":
+			return mediaTypeXLS
+		}
+		return nil
+	case bytes.HasPrefix(head, signatureZIP), bytes.HasPrefix(head, signatureZIPNil):
+		return sniffZipType(src, size, ext)
+	}
+	return nil
+}
+
+// sniffZipType ZIP tabanlı Office belgelerini içlerindeki zorunlu parçalara
+// bakarak düz ZIP arşivlerinden ayırır
+func sniffZipType(src io.ReaderAt, size int64, ext string) *mediaType {
+	reader, err := zip.NewReader(src, size)
+	if err != nil {
+		return nil
+	}
+
+	has := func(name string) bool {
+		for _, file := range reader.File {
+			if file.Name == name {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch {
+	case ext == ".docx" && has("word/document.xml"):
+		return mediaTypeDOCX
+	case ext == ".xlsx" && has("xl/workbook.xml"):
+		return mediaTypeXLSX
+	case ext == ".docx" || ext == ".xlsx":
+		// Uzantısı Office belgesi olan ama içeriği uymayan arşivler reddedilir
+		return nil
+	}
+	return mediaTypeZIP
+}
+
+// buildUploadLimits tür sınırlarını genel yükleme sınırıyla kırparak bayt
+// cinsinden hesaplar
+func buildUploadLimits(maxUploadMB int, limitsMB map[mediaCategory]int) map[mediaCategory]int64 {
+	limits := make(map[mediaCategory]int64, len(limitsMB))
+	for category, mb := range limitsMB {
+		if maxUploadMB > 0 && (mb <= 0 || mb > maxUploadMB) {
+			mb = maxUploadMB
+		}
+		limits[category] = int64(mb) * 1024 * 1024
+	}
+	return limits
+}
+
+// mediaContentTypes dosya grubundaki içerik türlerini döner; bilinmeyen grup için nil döner
+func mediaContentTypes(category mediaCategory) []string {
+	var contentTypes []string
+	for _, t := range allowedMediaTypes {
+		if t.Category == category {
+			contentTypes = append(contentTypes, t.ContentType)
+		}
+	}
+	return contentTypes
+}
@@ -0,0 +1,629 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/username/haber/internal/config"
+	"github.com/username/haber/internal/domain"
+	"github.com/username/haber/internal/repository"
+	"github.com/username/haber/pkg/auth"
+	"golang.org/x/oauth2"
+)
+
+// SSO girişi ayarları
+const (
+	// Kimlik sağlayıcıyla yapılan isteklerin zaman aşımı
+	oidcRequestTimeout = 15 * time.Second
+	// Dönüş adresindeki giriş kodunun token'larla değiştirilmesi için süre
+	oidcHandoffTTL = 2 * time.Minute
+)
+
+// OIDCStateTTL SSO girişinin başlatıldıktan sonra tamamlanması için süre
+//
+// Tarayıcıya yazılan state çerezinin ömrü de bu süredir.
+const OIDCStateTTL = 10 * time.Minute
+
+// usernameInvalidChars kullanıcı adlarında izin verilmeyen karakterler
+var usernameInvalidChars = regexp.MustCompile(`[^a-z0-9._-]+`)
+
+// errInvalidOIDCLogin geçersiz veya süresi dolmuş SSO girişi hatası
+var errInvalidOIDCLogin = &domain.AuthError{
+	Message: "SSO girişi geçersiz veya süresi dolmuş, lütfen tekrar deneyin",
+}
+
+// IOIDCService OpenID Connect ile tek oturum açma için service interface
+type IOIDCService interface {
+	Enabled() bool
+	StartLogin(returnTo string) (authURL, state string, err error)
+	CompleteLogin(state, code string) (*domain.OIDCHandoff, error)
+	ExchangeCode(code string, client *domain.ClientInfo) (*domain.LoginResponse, error)
+	ListIdentities(userID uint) ([]*domain.UserIdentity, error)
+}
+
+// oidcRoleMapping kimlik sağlayıcı grubunun yerel role karşılığı
+type oidcRoleMapping struct {
+	group string
+	role  string
+}
+
+// oidcClaims ID token'dan okunan alanlar
+type oidcClaims struct {
+	Subject           string      `json:"sub"`
+	Email             string      `json:"email"`
+	EmailVerified     interface{} `json:"email_verified"`
+	Name              string      `json:"name"`
+	PreferredUsername string      `json:"preferred_username"`
+}
+
+// OIDCService authorization code + PKCE akışıyla SSO girişinin implementasyonu
+//
+// Kimlik sağlayıcının keşif belgesi ilk kullanımda okunur; sağlayıcı
+// uygulama açılırken erişilemez olsa bile diğer giriş yöntemleri çalışır.
+type OIDCService struct {
+	cfg          config.IOIDCConfig
+	userRepo     repository.IUserRepository
+	identityRepo repository.IUserIdentityRepository
+	stateRepo    repository.IOIDCStateRepository
+	tokenRepo    repository.ITokenRepository
+	authService  IAuthService
+	audit        IAuditService
+	httpClient   *http.Client
+
+	roleMappings   []oidcRoleMapping
+	allowedDomains []string
+
+	mu       sync.Mutex
+	oauth    *oauth2.Config
+	verifier *oidc.IDTokenVerifier
+}
+
+// NewOIDCService yeni bir OIDCService oluşturur
+//
+// httpClient nil ise varsayılan istemci kullanılır; testlerde yerel bir
+// sahte kimlik sağlayıcıya bağlanmak için özel istemci verilebilir.
+func NewOIDCService(
+	cfg config.IOIDCConfig,
+	userRepo repository.IUserRepository,
+	identityRepo repository.IUserIdentityRepository,
+	stateRepo repository.IOIDCStateRepository,
+	tokenRepo repository.ITokenRepository,
+	authService IAuthService,
+	audit IAuditService,
+	httpClient *http.Client,
+) IOIDCService {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: oidcRequestTimeout}
+	}
+
+	return &OIDCService{
+		cfg:            cfg,
+		userRepo:       userRepo,
+		identityRepo:   identityRepo,
+		stateRepo:      stateRepo,
+		tokenRepo:      tokenRepo,
+		authService:    authService,
+		audit:          audit,
+		httpClient:     httpClient,
+		roleMappings:   parseRoleMappings(cfg.GetRoleMapping()),
+		allowedDomains: splitList(cfg.GetAllowedDomains(), ","),
+	}
+}
+
+// Enabled SSO girişinin yapılandırılıp yapılandırılmadığını döndürür
+func (s *OIDCService) Enabled() bool {
+	return s.cfg.GetEnabled() && s.cfg.GetIssuerURL() != "" && s.cfg.GetClientID() != ""
+}
+
+// StartLogin yeni bir giriş durumu oluşturur ve kimlik sağlayıcının yetkilendirme adresini döndürür
+//
+// returnTo yalnızca uygulama içi bir yol olabilir; başka bir değer yok sayılır.
+// Dönen state değeri girişi başlatan tarayıcıya bağlamak için çerezde saklanmalıdır.
+func (s *OIDCService) StartLogin(returnTo string) (string, string, error) {
+	if !s.Enabled() {
+		return "", "", domain.NewForbiddenError("SSO girişi etkin değil")
+	}
+
+	oauthCfg, _, err := s.client()
+	if err != nil {
+		return "", "", err
+	}
+
+	state, err := auth.GenerateRandomToken(32)
+	if err != nil {
+		return "", "", err
+	}
+	nonce, err := auth.GenerateRandomToken(16)
+	if err != nil {
+		return "", "", err
+	}
+	verifier := oauth2.GenerateVerifier()
+
+	// Yarıda kalan girişlerin kayıtlarını temizle
+	if err := s.stateRepo.DeleteExpired(); err != nil {
+		log.Printf("Süresi dolmuş SSO girişleri silinemedi: %v", err)
+	}
+
+	now := time.Now()
+	record := &domain.OIDCLoginState{
+		StateHash:    auth.HashToken(state),
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		ReturnTo:     safeReturnTo(returnTo),
+		ExpiresAt:    now.Add(OIDCStateTTL),
+		CreatedAt:    now,
+	}
+	if err := s.stateRepo.Create(record); err != nil {
+		return "", "", err
+	}
+
+	authURL := oauthCfg.AuthCodeURL(state, oidc.Nonce(nonce), oauth2.S256ChallengeOption(verifier))
+	return authURL, state, nil
+}
+
+// CompleteLogin kimlik sağlayıcıdan dönen kodu doğrular, kullanıcıyı bulur ya da
+// oluşturur ve token'larla değiştirilecek tek kullanımlık bir giriş kodu üretir
+//
+// Eşleştirme sırası:
+//   - Sağlayıcı ve sub ile daha önce bağlanmış hesap
+//   - Sağlayıcının doğruladığı e-posta adresiyle eşleşen yerel hesap (bağlanır;
+//     adresi doğrulanmamış hesabın yerel giriş bilgileri silinir)
+//   - Otomatik hesap açma etkinse yeni hesap
+//
+// State değerinin girişi başlatan tarayıcıdan geldiği çağıran tarafından
+// kontrol edilmelidir.
+func (s *OIDCService) CompleteLogin(state, code string) (*domain.OIDCHandoff, error) {
+	if !s.Enabled() {
+		return nil, domain.NewForbiddenError("SSO girişi etkin değil")
+	}
+
+	oauthCfg, verifier, err := s.client()
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := s.stateRepo.Consume(auth.HashToken(state))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, errInvalidOIDCLogin
+		}
+		return nil, err
+	}
+
+	ctx, cancel := s.context()
+	defer cancel()
+
+	// Kodu PKCE doğrulayıcısıyla token'a çevir
+	token, err := oauthCfg.Exchange(ctx, code, oauth2.VerifierOption(record.CodeVerifier))
+	if err != nil {
+		log.Printf("SSO kodu token'a çevrilemedi: %v", err)
+		return nil, errInvalidOIDCLogin
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, errInvalidOIDCLogin
+	}
+
+	idToken, err := verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		log.Printf("SSO ID token'ı doğrulanamadı: %v", err)
+		return nil, errInvalidOIDCLogin
+	}
+	if subtle.ConstantTimeCompare([]byte(idToken.Nonce), []byte(record.Nonce)) != 1 {
+		return nil, errInvalidOIDCLogin
+	}
+
+	var claims oidcClaims
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, errInvalidOIDCLogin
+	}
+	var rawClaims map[string]interface{}
+	if err := idToken.Claims(&rawClaims); err != nil {
+		return nil, errInvalidOIDCLogin
+	}
+	groups := claimStrings(rawClaims[s.cfg.GetGroupsClaim()])
+
+	if claims.Email != "" && !s.isAllowedDomain(claims.Email) {
+		return nil, domain.NewForbiddenError("Bu e-posta alan adıyla SSO girişi yapılamaz")
+	}
+
+	user, err := s.resolveUser(idToken.Issuer, &claims, groups)
+	if err != nil {
+		return nil, err
+	}
+
+	handoff, err := createOneTimeToken(s.tokenRepo, user.ID, domain.TokenTypeOIDCHandoff, oidcHandoffTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.OIDCHandoff{
+		Code:     handoff,
+		ReturnTo: record.ReturnTo,
+	}, nil
+}
+
+// ExchangeCode SSO dönüşünde verilen giriş kodunu tüketir ve girişi başlatır
+//
+// Kimlik sağlayıcıdaki doğrulama yerel 2FA yerine geçmez; TOTP açık olan ya da
+// 2FA zorunlu tutulan hesaplar şifreli girişteki gibi ikinci adıma yönlendirilir.
+func (s *OIDCService) ExchangeCode(code string, client *domain.ClientInfo) (*domain.LoginResponse, error) {
+	record, err := consumeOneTimeToken(s.tokenRepo, code, domain.TokenTypeOIDCHandoff)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(record.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.authService.StartLogin(user, false, client)
+}
+
+// ListIdentities kullanıcıya bağlı harici kimlikleri listeler
+func (s *OIDCService) ListIdentities(userID uint) ([]*domain.UserIdentity, error) {
+	return s.identityRepo.ListByUser(userID)
+}
+
+// resolveUser claim'lere karşılık gelen yerel kullanıcıyı bulur, bağlar ya da oluşturur
+func (s *OIDCService) resolveUser(provider string, claims *oidcClaims, groups []string) (*domain.User, error) {
+	now := time.Now()
+
+	// Daha önce bağlanmış kimlik
+	identity, err := s.identityRepo.GetBySubject(provider, claims.Subject)
+	if err == nil {
+		user, err := s.userRepo.GetByID(identity.UserID)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return nil, domain.NewForbiddenError("Bu kimliğe bağlı hesap artık bulunmuyor")
+			}
+			return nil, err
+		}
+		if err := s.identityRepo.TouchLogin(identity.ID, claims.Email, now); err != nil {
+			return nil, err
+		}
+		if err := s.syncRole(user, groups); err != nil {
+			return nil, err
+		}
+		return user, nil
+	}
+	if !errors.Is(err, domain.ErrNotFound) {
+		return nil, err
+	}
+
+	// Bağlama ve hesap açma yalnızca sağlayıcının doğruladığı e-postayla yapılır
+	if claims.Email == "" || !claimBool(claims.EmailVerified) {
+		return nil, domain.NewForbiddenError("Kimlik sağlayıcı doğrulanmış bir e-posta adresi göndermedi")
+	}
+
+	user, err := s.userRepo.GetByEmail(claims.Email)
+	switch {
+	case err == nil:
+		// Doğrulanmamış hesabı adresin sahibi olmayan biri açmış olabilir; hesap
+		// sağlayıcının doğruladığı kişiye yerel şifresi, 2FA'sı ve oturumları
+		// silinerek devredilir
+		if !user.IsEmailVerified() {
+			before := auditSnapshot(user)
+			if err := s.authService.ResetLocalCredentials(user, nil); err != nil {
+				return nil, err
+			}
+			user.Status = domain.UserStatusActive
+			user.EmailVerifiedAt = &now
+			user.UpdatedAt = now
+			if err := s.userRepo.Update(user); err != nil {
+				return nil, err
+			}
+			s.audit.Record(nil, domain.AuditActionVerifyEmail, domain.ResourceUser, user.ID, before, user)
+		}
+		if err := s.syncRole(user, groups); err != nil {
+			return nil, err
+		}
+	case errors.Is(err, domain.ErrNotFound):
+		if !s.cfg.GetAutoProvision() {
+			return nil, domain.NewForbiddenError("Bu kimlikle eşleşen bir hesap bulunamadı")
+		}
+		user, err = s.provisionUser(claims, groups, now)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+
+	identity = &domain.UserIdentity{
+		UserID:      user.ID,
+		Provider:    provider,
+		Subject:     claims.Subject,
+		Email:       claims.Email,
+		LastLoginAt: &now,
+		CreatedAt:   now,
+	}
+	if err := s.identityRepo.Create(identity); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// provisionUser kimlik sağlayıcıdaki bilgilerle yeni bir kullanıcı oluşturur
+//
+// Hesabın yerel şifresi yoktur; kullanıcı isterse şifre sıfırlama ile belirleyebilir.
+func (s *OIDCService) provisionUser(claims *oidcClaims, groups []string, now time.Time) (*domain.User, error) {
+	localPart := claims.Email
+	if i := strings.IndexByte(localPart, '@'); i > 0 {
+		localPart = localPart[:i]
+	}
+
+	username, err := s.availableUsername(claims.PreferredUsername, localPart)
+	if err != nil {
+		return nil, err
+	}
+
+	fullName := strings.TrimSpace(claims.Name)
+	if fullName == "" {
+		fullName = username
+	}
+
+	role := s.cfg.GetDefaultRole()
+	if mapped, ok := s.mappedRole(groups); ok {
+		role = mapped
+	}
+	if role == "" {
+		role = domain.RoleUser
+	}
+
+	user := &domain.User{
+		Username:        username,
+		Email:           claims.Email,
+		FullName:        truncate(fullName, 100),
+		Role:            role,
+		Status:          domain.UserStatusActive,
+		EmailVerifiedAt: &now,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if err := s.userRepo.Create(user); err != nil {
+		return nil, err
+	}
+
+	// Hesabı bir yönetici değil SSO girişi açtığından kayıt sistem işlemi olarak tutulur
+	s.audit.Record(nil, domain.AuditActionCreate, domain.ResourceUser, user.ID, nil, user)
+	return user, nil
+}
+
+// syncRole kullanıcının rolünü kimlik sağlayıcı gruplarına göre günceller
+//
+// Eşleşen bir grup varsa o rol verilir. Hiçbir grup eşleşmiyorsa yalnızca rolü
+// daha önce eşleştirmeyle verilmiş kullanıcılar varsayılan role düşürülür;
+// yöneticilerin elle verdiği diğer rollere dokunulmaz.
+func (s *OIDCService) syncRole(user *domain.User, groups []string) error {
+	if len(s.roleMappings) == 0 {
+		return nil
+	}
+
+	role, ok := s.mappedRole(groups)
+	if !ok {
+		if !s.isMappedRole(user.Role) {
+			return nil
+		}
+		role = s.cfg.GetDefaultRole()
+		if role == "" {
+			role = domain.RoleUser
+		}
+	}
+
+	if user.Role == role {
+		return nil
+	}
+
+	before := auditSnapshot(user)
+	user.Role = role
+	user.UpdatedAt = time.Now()
+	if err := s.userRepo.Update(user); err != nil {
+		return err
+	}
+
+	s.audit.Record(nil, domain.AuditActionRoleChange, domain.ResourceUser, user.ID, before, user)
+
+	// Diğer cihazlardaki token'lar eski rolü taşır
+	return s.authService.RevokeUserTokens(user.ID, nil)
+}
+
+// mappedRole gruplara karşılık gelen ilk rolü döndürür
+func (s *OIDCService) mappedRole(groups []string) (string, bool) {
+	for _, mapping := range s.roleMappings {
+		if containsString(groups, mapping.group) {
+			return mapping.role, true
+		}
+	}
+	return "", false
+}
+
+// isMappedRole rolün grup eşleştirmesiyle verilen rollerden biri olup olmadığını döndürür
+func (s *OIDCService) isMappedRole(role string) bool {
+	for _, mapping := range s.roleMappings {
+		if mapping.role == role {
+			return true
+		}
+	}
+	return false
+}
+
+// availableUsername adaylardan kullanılmayan bir kullanıcı adı türetir
+func (s *OIDCService) availableUsername(candidates ...string) (string, error) {
+	base := ""
+	for _, candidate := range candidates {
+		base = normalizeUsername(candidate)
+		if len(base) >= 3 {
+			break
+		}
+	}
+	if len(base) < 3 {
+		base = "kullanici"
+	}
+
+	for i := 0; i < 10; i++ {
+		username := base
+		if i > 0 {
+			suffix, err := auth.GenerateRandomToken(2)
+			if err != nil {
+				return "", err
+			}
+			username = truncate(base, 45) + "-" + suffix
+		}
+
+		_, err := s.userRepo.GetByUsername(username)
+		if errors.Is(err, domain.ErrNotFound) {
+			return username, nil
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return "", errors.New("kullanılabilir kullanıcı adı bulunamadı")
+}
+
+// isAllowedDomain e-posta adresinin izinli alan adlarından birine ait olup olmadığını döndürür
+func (s *OIDCService) isAllowedDomain(email string) bool {
+	if len(s.allowedDomains) == 0 {
+		return true
+	}
+
+	at := strings.LastIndexByte(email, '@')
+	if at < 0 {
+		return false
+	}
+	emailDomain := strings.ToLower(email[at+1:])
+	for _, allowed := range s.allowedDomains {
+		if strings.EqualFold(emailDomain, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// client kimlik sağlayıcı istemcisini ilk kullanımda oluşturur
+func (s *OIDCService) client() (*oauth2.Config, *oidc.IDTokenVerifier, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.oauth != nil {
+		return s.oauth, s.verifier, nil
+	}
+
+	ctx, cancel := s.context()
+	defer cancel()
+
+	provider, err := oidc.NewProvider(ctx, s.cfg.GetIssuerURL())
+	if err != nil {
+		return nil, nil, domain.NewServiceUnavailableError(
+			fmt.Errorf("kimlik sağlayıcı keşfi başarısız: %w", err),
+			"Kimlik sağlayıcıya şu anda ulaşılamıyor",
+		)
+	}
+
+	scopes := []string{oidc.ScopeOpenID}
+	for _, scope := range splitList(s.cfg.GetScopes(), " ") {
+		if scope != oidc.ScopeOpenID {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	s.oauth = &oauth2.Config{
+		ClientID:     s.cfg.GetClientID(),
+		ClientSecret: s.cfg.GetClientSecret(),
+		RedirectURL:  s.cfg.GetRedirectURL(),
+		Endpoint:     provider.Endpoint(),
+		Scopes:       scopes,
+	}
+	s.verifier = provider.Verifier(&oidc.Config{ClientID: s.cfg.GetClientID()})
+
+	return s.oauth, s.verifier, nil
+}
+
+// context kimlik sağlayıcı istekleri için zaman aşımlı bağlam oluşturur
+func (s *OIDCService) context() (context.Context, context.CancelFunc) {
+	ctx := oidc.ClientContext(context.Background(), s.httpClient)
+	return context.WithTimeout(ctx, oidcRequestTimeout)
+}
+
+// parseRoleMappings "grup=rol" çiftlerini sırasıyla çözümler
+func parseRoleMappings(value string) []oidcRoleMapping {
+	var mappings []oidcRoleMapping
+	for _, pair := range splitList(value, ",") {
+		group, role, ok := strings.Cut(pair, "=")
+		group, role = strings.TrimSpace(group), strings.TrimSpace(role)
+		if !ok || group == "" || role == "" {
+			log.Printf("Geçersiz SSO rol eşleştirmesi yok sayıldı: %q", pair)
+			continue
+		}
+		mappings = append(mappings, oidcRoleMapping{group: group, role: role})
+	}
+	return mappings
+}
+
+// splitList ayraçla ayrılmış listeyi boş öğeler olmadan döndürür
+func splitList(value, sep string) []string {
+	var items []string
+	for _, item := range strings.Split(value, sep) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// claimStrings tek bir dize ya da dize listesi olabilen claim değerini dilime çevirir
+func claimStrings(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		result := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+// claimBool bazı sağlayıcıların dize olarak gönderdiği boolean claim'leri de okur
+func claimBool(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		return strings.EqualFold(v, "true")
+	}
+	return false
+}
+
+// normalizeUsername değeri kullanıcı adı kurallarına uygun hale getirir
+func normalizeUsername(value string) string {
+	username := usernameInvalidChars.ReplaceAllString(strings.ToLower(strings.TrimSpace(value)), "")
+	username = strings.Trim(username, ".-_")
+	return truncate(username, 50)
+}
+
+// safeReturnTo yalnızca uygulama içi yolları kabul eder
+func safeReturnTo(value string) string {
+	if !strings.HasPrefix(value, "/") || strings.HasPrefix(value, "//") || strings.HasPrefix(value, "/\\") {
+		return ""
+	}
+	return truncate(value, 512)
+}
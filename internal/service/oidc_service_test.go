@@ -0,0 +1,425 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/username/haber/internal/config"
+	"github.com/username/haber/internal/domain"
+	"github.com/username/haber/internal/repository"
+)
+
+// stubIdP keşif, token ve JWKS uçlarını sunan yerel kimlik sağlayıcı
+type stubIdP struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	mu    sync.Mutex
+	codes map[string]stubGrant
+}
+
+// stubGrant yetkilendirme koduna bağlı PKCE ve ID token bilgileri
+type stubGrant struct {
+	challenge string
+	claims    jwt.MapClaims
+}
+
+func newStubIdP(t *testing.T) *stubIdP {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	idp := &stubIdP{key: key, codes: map[string]stubGrant{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", idp.discovery)
+	mux.HandleFunc("/jwks", idp.jwks)
+	mux.HandleFunc("/token", idp.token)
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+	return idp
+}
+
+func (p *stubIdP) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                p.server.URL,
+		"authorization_endpoint":                p.server.URL + "/authorize",
+		"token_endpoint":                        p.server.URL + "/token",
+		"jwks_uri":                              p.server.URL + "/jwks",
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+	})
+}
+
+func (p *stubIdP) jwks(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "stub",
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(p.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(p.key.E)).Bytes()),
+		}},
+	})
+}
+
+// token kodu PKCE doğrulayıcısını kontrol ederek imzalı ID token'a çevirir
+func (p *stubIdP) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	p.mu.Lock()
+	grant, ok := p.codes[r.PostForm.Get("code")]
+	delete(p.codes, r.PostForm.Get("code"))
+	p.mu.Unlock()
+
+	if !ok || r.PostForm.Get("grant_type") != "authorization_code" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+	if pkceChallenge(r.PostForm.Get("code_verifier")) != grant.challenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, grant.claims)
+	idToken.Header["kid"] = "stub"
+	signed, err := idToken.SignedString(p.key)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": "stub-access-token",
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     signed,
+	})
+}
+
+// authorize kullanıcının sağlayıcıda girişi onaylamasını taklit eder ve kod üretir
+func (p *stubIdP) authorize(challenge, nonce, subject, email string) string {
+	now := time.Now()
+	code := base64.RawURLEncoding.EncodeToString([]byte(subject + nonce))
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.codes[code] = stubGrant{
+		challenge: challenge,
+		claims: jwt.MapClaims{
+			"iss":                p.server.URL,
+			"aud":                "haber",
+			"sub":                subject,
+			"email":              email,
+			"email_verified":     true,
+			"name":               "Ayşe Yılmaz",
+			"preferred_username": "ayse",
+			"nonce":              nonce,
+			"iat":                now.Unix(),
+			"exp":                now.Add(time.Hour).Unix(),
+		},
+	}
+	return code
+}
+
+func pkceChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// startStubLogin girişi başlatır ve yetkilendirme adresindeki parametreleri döndürür
+func startStubLogin(t *testing.T, svc IOIDCService) (state, nonce, challenge string) {
+	t.Helper()
+
+	authURL, state, err := svc.StartLogin("/panel")
+	if err != nil {
+		t.Fatalf("StartLogin: %v", err)
+	}
+
+	parsed, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := parsed.Query()
+	if query.Get("state") != state {
+		t.Fatalf("yetkilendirme adresindeki state %q, beklenen %q", query.Get("state"), state)
+	}
+	if query.Get("code_challenge_method") != "S256" || query.Get("code_challenge") == "" {
+		t.Fatalf("PKCE parametreleri eksik: %s", authURL)
+	}
+	if query.Get("nonce") == "" {
+		t.Fatalf("nonce eksik: %s", authURL)
+	}
+
+	return state, query.Get("nonce"), query.Get("code_challenge")
+}
+
+func TestOIDCLoginAgainstStubProvider(t *testing.T) {
+	idp := newStubIdP(t)
+	svc, users := newTestOIDCService(idp)
+
+	state, nonce, challenge := startStubLogin(t, svc)
+	code := idp.authorize(challenge, nonce, "sub-1", "ayse@haber.test")
+
+	handoff, err := svc.CompleteLogin(state, code)
+	if err != nil {
+		t.Fatalf("CompleteLogin: %v", err)
+	}
+	if handoff.ReturnTo != "/panel" || handoff.Code == "" {
+		t.Fatalf("beklenmeyen dönüş: %+v", handoff)
+	}
+
+	user, err := users.GetByEmail("ayse@haber.test")
+	if err != nil {
+		t.Fatalf("kullanıcı oluşturulmadı: %v", err)
+	}
+	if !user.IsEmailVerified() || user.Role != domain.RoleUser {
+		t.Fatalf("beklenmeyen kullanıcı: %+v", user)
+	}
+
+	login, err := svc.ExchangeCode(handoff.Code, &domain.ClientInfo{})
+	if err != nil {
+		t.Fatalf("ExchangeCode: %v", err)
+	}
+	if login.AuthResponse == nil || login.AuthResponse.User.ID != user.ID {
+		t.Fatalf("beklenmeyen giriş yanıtı: %+v", login)
+	}
+
+	// Giriş kodu ve state tek kullanımlıktır
+	if _, err := svc.ExchangeCode(handoff.Code, &domain.ClientInfo{}); err == nil {
+		t.Fatal("kullanılmış giriş kodu kabul edildi")
+	}
+	if _, err := svc.CompleteLogin(state, code); err == nil {
+		t.Fatal("kullanılmış state kabul edildi")
+	}
+}
+
+func TestOIDCLoginRejectsNonceMismatch(t *testing.T) {
+	idp := newStubIdP(t)
+	svc, _ := newTestOIDCService(idp)
+
+	state, _, challenge := startStubLogin(t, svc)
+	code := idp.authorize(challenge, "baska-nonce", "sub-1", "ayse@haber.test")
+
+	if _, err := svc.CompleteLogin(state, code); !errors.Is(err, domain.ErrUnauthorized) {
+		t.Fatalf("nonce uyuşmazlığı reddedilmedi: %v", err)
+	}
+}
+
+func TestOIDCLoginRejectsPKCEMismatch(t *testing.T) {
+	idp := newStubIdP(t)
+	svc, _ := newTestOIDCService(idp)
+
+	state, nonce, _ := startStubLogin(t, svc)
+	code := idp.authorize(pkceChallenge("baska-dogrulayici"), nonce, "sub-1", "ayse@haber.test")
+
+	if _, err := svc.CompleteLogin(state, code); !errors.Is(err, domain.ErrUnauthorized) {
+		t.Fatalf("PKCE uyuşmazlığı reddedilmedi: %v", err)
+	}
+}
+
+func newTestOIDCService(idp *stubIdP) (IOIDCService, *memoryUserRepo) {
+	users := &memoryUserRepo{users: map[uint]*domain.User{}}
+	cfg := &config.OIDCConfig{
+		Enabled:       true,
+		IssuerURL:     idp.server.URL,
+		ClientID:      "haber",
+		ClientSecret:  "gizli",
+		RedirectURL:   "http://localhost/api/auth/oidc/callback",
+		Scopes:        "profile email",
+		GroupsClaim:   "groups",
+		DefaultRole:   domain.RoleUser,
+		AutoProvision: true,
+	}
+
+	svc := NewOIDCService(
+		cfg,
+		users,
+		&memoryIdentityRepo{},
+		&memoryStateRepo{states: map[string]*domain.OIDCLoginState{}},
+		&memoryTokenRepo{},
+		&stubAuthService{},
+		nopAudit{},
+		idp.server.Client(),
+	)
+	return svc, users
+}
+
+// memoryUserRepo testler için bellek içi kullanıcı deposu
+type memoryUserRepo struct {
+	repository.IUserRepository
+	users  map[uint]*domain.User
+	nextID uint
+}
+
+func (r *memoryUserRepo) Create(user *domain.User) error {
+	r.nextID++
+	user.ID = r.nextID
+	r.users[user.ID] = user
+	return nil
+}
+
+func (r *memoryUserRepo) Update(user *domain.User) error {
+	r.users[user.ID] = user
+	return nil
+}
+
+func (r *memoryUserRepo) GetByID(id uint) (*domain.User, error) {
+	if user, ok := r.users[id]; ok {
+		return user, nil
+	}
+	return nil, &domain.NotFoundError{ResourceType: domain.ResourceUser, ID: id}
+}
+
+func (r *memoryUserRepo) GetByEmail(email string) (*domain.User, error) {
+	for _, user := range r.users {
+		if user.Email == email {
+			return user, nil
+		}
+	}
+	return nil, &domain.NotFoundError{ResourceType: domain.ResourceUser, ID: email}
+}
+
+func (r *memoryUserRepo) GetByUsername(username string) (*domain.User, error) {
+	for _, user := range r.users {
+		if user.Username == username {
+			return user, nil
+		}
+	}
+	return nil, &domain.NotFoundError{ResourceType: domain.ResourceUser, ID: username}
+}
+
+// memoryIdentityRepo testler için bellek içi harici kimlik deposu
+type memoryIdentityRepo struct {
+	identities []*domain.UserIdentity
+}
+
+func (r *memoryIdentityRepo) Create(identity *domain.UserIdentity) error {
+	identity.ID = uint(len(r.identities) + 1)
+	r.identities = append(r.identities, identity)
+	return nil
+}
+
+func (r *memoryIdentityRepo) GetBySubject(provider, subject string) (*domain.UserIdentity, error) {
+	for _, identity := range r.identities {
+		if identity.Provider == provider && identity.Subject == subject {
+			return identity, nil
+		}
+	}
+	return nil, &domain.NotFoundError{ResourceType: domain.ResourceUser, ID: subject}
+}
+
+func (r *memoryIdentityRepo) ListByUser(userID uint) ([]*domain.UserIdentity, error) {
+	var result []*domain.UserIdentity
+	for _, identity := range r.identities {
+		if identity.UserID == userID {
+			result = append(result, identity)
+		}
+	}
+	return result, nil
+}
+
+func (r *memoryIdentityRepo) TouchLogin(id uint, email string, at time.Time) error {
+	return nil
+}
+
+// memoryStateRepo testler için bellek içi SSO durum deposu
+type memoryStateRepo struct {
+	states map[string]*domain.OIDCLoginState
+}
+
+func (r *memoryStateRepo) Create(state *domain.OIDCLoginState) error {
+	r.states[state.StateHash] = state
+	return nil
+}
+
+func (r *memoryStateRepo) Consume(stateHash string) (*domain.OIDCLoginState, error) {
+	state, ok := r.states[stateHash]
+	if !ok {
+		return nil, &domain.NotFoundError{ResourceType: domain.ResourceToken, ID: stateHash}
+	}
+	delete(r.states, stateHash)
+	return state, nil
+}
+
+func (r *memoryStateRepo) DeleteExpired() error {
+	return nil
+}
+
+// memoryTokenRepo testler için bellek içi tek kullanımlık token deposu
+type memoryTokenRepo struct {
+	repository.ITokenRepository
+	tokens []*domain.Token
+}
+
+func (r *memoryTokenRepo) Create(token *domain.Token) error {
+	token.ID = uint(len(r.tokens) + 1)
+	r.tokens = append(r.tokens, token)
+	return nil
+}
+
+func (r *memoryTokenRepo) GetByHash(tokenHash, tokenType string) (*domain.Token, error) {
+	for _, token := range r.tokens {
+		if token.TokenHash == tokenHash && token.Type == tokenType {
+			return token, nil
+		}
+	}
+	return nil, &domain.NotFoundError{ResourceType: domain.ResourceToken, ID: tokenHash}
+}
+
+func (r *memoryTokenRepo) MarkUsed(id uint) (bool, error) {
+	for _, token := range r.tokens {
+		if token.ID == id && token.UsedAt == nil {
+			now := time.Now()
+			token.UsedAt = &now
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *memoryTokenRepo) InvalidateUserTokens(userID uint, tokenType string) error {
+	return nil
+}
+
+// stubAuthService token üretmek yerine kullanıcıyı giriş yanıtına koyar
+type stubAuthService struct {
+	IAuthService
+}
+
+func (s *stubAuthService) StartLogin(user *domain.User, remember bool, client *domain.ClientInfo) (*domain.LoginResponse, error) {
+	return &domain.LoginResponse{
+		AuthResponse: &domain.AuthResponse{User: user, AccessToken: "access", TokenType: "Bearer"},
+	}, nil
+}
+
+// nopAudit denetim kayıtlarını yok sayar
+type nopAudit struct {
+	IAuditService
+}
+
+func (nopAudit) Record(actor *domain.Actor, action string, resourceType domain.ResourceType, resourceID interface{}, before, after interface{}) {
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/username/haber/internal/domain"
+	"github.com/username/haber/internal/repository"
+	"github.com/username/haber/pkg/auth"
+)
+
+// errInvalidOneTimeToken geçersiz, kullanılmış veya süresi dolmuş token hatası
+var errInvalidOneTimeToken = &domain.AuthError{
+	Message: "Geçersiz veya süresi dolmuş token",
+}
+
+// createOneTimeToken kullanıcı için tek kullanımlık token üretir ve özetini saklar
+//
+// Aynı tipteki önceki token'lar geçersiz kılınır, böylece yalnızca son
+// gönderilen bağlantı çalışır.
+func createOneTimeToken(tokenRepo repository.ITokenRepository, userID uint, tokenType string, ttl time.Duration) (string, error) {
+	return storeOneTimeToken(tokenRepo, &domain.Token{UserID: userID, Type: tokenType}, ttl)
+}
+
+// storeOneTimeToken kullanıcı ve tipi belirlenmiş kaydı yeni bir token ile saklar
+//
+// Token'a bağlı ek bilgiler (ör. Remember) kayıtta önceden doldurulabilir.
+func storeOneTimeToken(tokenRepo repository.ITokenRepository, record *domain.Token, ttl time.Duration) (string, error) {
+	if err := tokenRepo.InvalidateUserTokens(record.UserID, record.Type); err != nil {
+		return "", err
+	}
+
+	token, err := auth.GenerateRandomToken(32)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	record.TokenHash = auth.HashToken(token)
+	record.ExpiresAt = now.Add(ttl)
+	record.CreatedAt = now
+	if err := tokenRepo.Create(record); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// consumeOneTimeToken token'ı doğrular ve kullanıldı olarak işaretler
+func consumeOneTimeToken(tokenRepo repository.ITokenRepository, token, tokenType string) (*domain.Token, error) {
+	record, err := tokenRepo.GetByHash(auth.HashToken(token), tokenType)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, errInvalidOneTimeToken
+		}
+		return nil, err
+	}
+
+	if !record.IsUsable() {
+		return nil, errInvalidOneTimeToken
+	}
+
+	// Eşzamanlı isteklerden yalnızca biri token'ı tüketebilir
+	marked, err := tokenRepo.MarkUsed(record.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !marked {
+		return nil, errInvalidOneTimeToken
+	}
+
+	return record, nil
+}
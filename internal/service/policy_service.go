@@ -0,0 +1,285 @@
+package service
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/username/haber/internal/domain"
+	"github.com/username/haber/internal/repository"
+)
+
+// rolePermissionCacheTTL rol yetkilerinin bellekte tutulma süresi
+//
+// Yetki değişiklikleri bu sunucuda hemen, diğer sunucularda en geç bu süre
+// sonunda geçerli olur.
+const rolePermissionCacheTTL = time.Minute
+
+// IPolicyService rol yetkileri ve sahiplik kurallarını tek yerde toplayan yetkilendirme katmanı
+type IPolicyService interface {
+	HasPermission(actor *domain.Actor, permission string) (bool, error)
+	Authorize(actor *domain.Actor, permission string) error
+	AuthorizeArticleCreate(actor *domain.Actor, req *domain.CreateArticleRequest) error
+	AuthorizeArticleUpdate(actor *domain.Actor, article *domain.Article, req *domain.UpdateArticleRequest) error
+	AuthorizeArticleDelete(actor *domain.Actor, article *domain.Article) error
+	AuthorizeMediaDelete(actor *domain.Actor, media *domain.Media) error
+	AuthorizeMediaUpdate(actor *domain.Actor, media *domain.Media) error
+	InvalidateRole(name string)
+}
+
+// cachedRole önbellekteki rol kaydı
+type cachedRole struct {
+	role     *domain.Role
+	loadedAt time.Time
+}
+
+// PolicyService yetkilendirme katmanının implementasyonu
+type PolicyService struct {
+	roleRepo       repository.IRoleRepository
+	assignmentRepo repository.ICategoryAssignmentRepository
+
+	mu    sync.Mutex
+	cache map[string]cachedRole
+}
+
+// NewPolicyService yeni bir PolicyService oluşturur
+func NewPolicyService(roleRepo repository.IRoleRepository, assignmentRepo repository.ICategoryAssignmentRepository) IPolicyService {
+	return &PolicyService{
+		roleRepo:       roleRepo,
+		assignmentRepo: assignmentRepo,
+		cache:          make(map[string]cachedRole),
+	}
+}
+
+// HasPermission işlemi yapanın rolünün verilen yetkiye sahip olup olmadığını döndürür
+func (s *PolicyService) HasPermission(actor *domain.Actor, permission string) (bool, error) {
+	if actor == nil || actor.Role == "" {
+		return false, nil
+	}
+
+	role, err := s.role(actor.Role)
+	if err != nil || role == nil {
+		return false, err
+	}
+	return role.HasPermission(permission), nil
+}
+
+// Authorize yetki yoksa hata döndürür
+func (s *PolicyService) Authorize(actor *domain.Actor, permission string) error {
+	if actor == nil {
+		return domain.NewAuthError("Kimlik doğrulama gereklidir")
+	}
+
+	ok, err := s.HasPermission(actor, permission)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return domain.NewForbiddenError("Bu işlem için yetkiniz bulunmuyor")
+	}
+	return nil
+}
+
+// AuthorizeArticleCreate makale oluşturma yetkisini kontrol eder
+//
+// Yayınlanmış ya da öne çıkan makale oluşturmak kategoride yayın yetkisi gerektirir.
+func (s *PolicyService) AuthorizeArticleCreate(actor *domain.Actor, req *domain.CreateArticleRequest) error {
+	if err := s.Authorize(actor, domain.PermArticleCreate); err != nil {
+		return err
+	}
+
+	if req.Status == domain.ArticleStatusPublished || req.IsFeatured {
+		return s.authorizePublish(actor, req.CategoryID)
+	}
+	return nil
+}
+
+// AuthorizeArticleUpdate makale düzenleme yetkisini kontrol eder
+//
+// Kurallar:
+//   - article.edit.any her makaleyi düzenleyebilir
+//   - article.edit.assigned atanmış kategorilerdeki makaleleri düzenleyebilir
+//   - article.edit.own yalnızca kendi taslaklarını düzenleyebilir
+//
+// Yayınlanmış bir makaleye dokunmak ya da makaleyi yayınlamak ayrıca
+// hedef kategoride yayın yetkisi gerektirir.
+func (s *PolicyService) AuthorizeArticleUpdate(actor *domain.Actor, article *domain.Article, req *domain.UpdateArticleRequest) error {
+	if actor == nil {
+		return domain.NewAuthError("Kimlik doğrulama gereklidir")
+	}
+
+	targetCategory := article.CategoryID
+	if req.CategoryID != 0 {
+		targetCategory = req.CategoryID
+	}
+	targetStatus := article.Status
+	if req.Status != "" {
+		targetStatus = req.Status
+	}
+
+	canEdit, err := s.canEditArticle(actor, article, targetCategory)
+	if err != nil {
+		return err
+	}
+	if !canEdit {
+		return domain.NewForbiddenError("Bu makaleyi düzenleme yetkiniz yok")
+	}
+
+	featureChanged := req.IsFeatured != nil && *req.IsFeatured != article.IsFeatured
+	if article.Status == domain.ArticleStatusPublished {
+		if err := s.authorizePublish(actor, article.CategoryID); err != nil {
+			return err
+		}
+	}
+	if targetStatus == domain.ArticleStatusPublished || featureChanged {
+		return s.authorizePublish(actor, targetCategory)
+	}
+	return nil
+}
+
+// AuthorizeArticleDelete makale silme yetkisini kontrol eder
+//
+// article.delete.own yalnızca yayınlanmamış kendi makalelerini silebilir.
+func (s *PolicyService) AuthorizeArticleDelete(actor *domain.Actor, article *domain.Article) error {
+	if actor == nil {
+		return domain.NewAuthError("Kimlik doğrulama gereklidir")
+	}
+
+	if ok, err := s.HasPermission(actor, domain.PermArticleDeleteAny); err != nil || ok {
+		return err
+	}
+
+	if article.AuthorID == actor.UserID && article.Status != domain.ArticleStatusPublished {
+		if ok, err := s.HasPermission(actor, domain.PermArticleDeleteOwn); err != nil || ok {
+			return err
+		}
+	}
+
+	return domain.NewForbiddenError("Bu makaleyi silme yetkiniz yok")
+}
+
+// AuthorizeMediaDelete medya silme yetkisini kontrol eder
+func (s *PolicyService) AuthorizeMediaDelete(actor *domain.Actor, media *domain.Media) error {
+	if actor == nil {
+		return domain.NewAuthError("Kimlik doğrulama gereklidir")
+	}
+
+	if ok, err := s.HasPermission(actor, domain.PermMediaDeleteAny); err != nil || ok {
+		return err
+	}
+
+	if media.UserID == actor.UserID {
+		if ok, err := s.HasPermission(actor, domain.PermMediaDeleteOwn); err != nil || ok {
+			return err
+		}
+	}
+
+	return domain.NewForbiddenError("Bu dosyayı silme yetkiniz yok")
+}
+
+// AuthorizeMediaUpdate medya künye bilgilerini düzenleme yetkisini kontrol eder
+func (s *PolicyService) AuthorizeMediaUpdate(actor *domain.Actor, media *domain.Media) error {
+	if actor == nil {
+		return domain.NewAuthError("Kimlik doğrulama gereklidir")
+	}
+
+	if ok, err := s.HasPermission(actor, domain.PermMediaEditAny); err != nil || ok {
+		return err
+	}
+
+	if media.UserID == actor.UserID {
+		if ok, err := s.HasPermission(actor, domain.PermMediaEditOwn); err != nil || ok {
+			return err
+		}
+	}
+
+	return domain.NewForbiddenError("Bu dosyayı düzenleme yetkiniz yok")
+}
+
+// InvalidateRole rolün önbellekteki yetkilerini siler
+func (s *PolicyService) InvalidateRole(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.cache, name)
+}
+
+// canEditArticle düzenleme kurallarını uygular
+func (s *PolicyService) canEditArticle(actor *domain.Actor, article *domain.Article, targetCategory uint) (bool, error) {
+	if ok, err := s.HasPermission(actor, domain.PermArticleEditAny); err != nil || ok {
+		return ok, err
+	}
+
+	ok, err := s.HasPermission(actor, domain.PermArticleEditAssigned)
+	if err != nil {
+		return false, err
+	}
+	if ok {
+		assigned, err := s.isAssigned(actor.UserID, article.CategoryID, targetCategory)
+		if err != nil || assigned {
+			return assigned, err
+		}
+	}
+
+	if article.AuthorID == actor.UserID && article.Status == domain.ArticleStatusDraft {
+		return s.HasPermission(actor, domain.PermArticleEditOwn)
+	}
+
+	return false, nil
+}
+
+// authorizePublish kategoride yayın yetkisini kontrol eder
+func (s *PolicyService) authorizePublish(actor *domain.Actor, categoryID uint) error {
+	if ok, err := s.HasPermission(actor, domain.PermArticlePublishAny); err != nil || ok {
+		return err
+	}
+
+	ok, err := s.HasPermission(actor, domain.PermArticlePublishAssigned)
+	if err != nil {
+		return err
+	}
+	if ok {
+		assigned, err := s.isAssigned(actor.UserID, categoryID)
+		if err != nil || assigned {
+			return err
+		}
+	}
+
+	return domain.NewForbiddenError("Bu kategoride yayın yapma yetkiniz yok")
+}
+
+// isAssigned kategorilerin tamamının kullanıcıya atanmış olup olmadığını döndürür
+func (s *PolicyService) isAssigned(userID uint, categoryIDs ...uint) (bool, error) {
+	for _, id := range categoryIDs {
+		ok, err := s.assignmentRepo.IsAssigned(userID, id)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// role rolü önbellekten ya da veritabanından getirir
+//
+// Tanımlı olmayan roller için nil döner; bu roller hiçbir yetkiye sahip değildir.
+func (s *PolicyService) role(name string) (*domain.Role, error) {
+	s.mu.Lock()
+	entry, ok := s.cache[name]
+	s.mu.Unlock()
+	if ok && time.Since(entry.loadedAt) < rolePermissionCacheTTL {
+		return entry.role, nil
+	}
+
+	role, err := s.roleRepo.GetByName(name)
+	if err != nil {
+		if !errors.Is(err, domain.ErrNotFound) {
+			return nil, err
+		}
+		role = nil
+	}
+
+	s.mu.Lock()
+	s.cache[name] = cachedRole{role: role, loadedAt: time.Now()}
+	s.mu.Unlock()
+
+	return role, nil
+}
@@ -0,0 +1,243 @@
+package service
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/username/haber/internal/domain"
+	"github.com/username/haber/internal/repository"
+)
+
+// roleNamePattern rol adlarında izin verilen karakterler
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// IRoleService rol ve yetki yönetimi için service interface
+type IRoleService interface {
+	ListPermissions() []string
+	ListRoles() ([]*domain.Role, error)
+	GetRole(name string) (*domain.Role, error)
+	CreateRole(req *domain.CreateRoleRequest, actor *domain.Actor) (*domain.Role, error)
+	UpdateRole(name string, req *domain.UpdateRoleRequest, actor *domain.Actor) (*domain.Role, error)
+	DeleteRole(name string, actor *domain.Actor) error
+	GetAssignedCategories(userID uint) ([]uint, error)
+	AssignCategories(userID uint, categoryIDs []uint, actor *domain.Actor) ([]uint, error)
+}
+
+// RoleService rol servisinin implementasyonu
+type RoleService struct {
+	roleRepo       repository.IRoleRepository
+	assignmentRepo repository.ICategoryAssignmentRepository
+	userRepo       repository.IUserRepository
+	categoryRepo   repository.ICategoryRepository
+	policy         IPolicyService
+	audit          IAuditService
+}
+
+// NewRoleService yeni bir RoleService oluşturur
+func NewRoleService(
+	roleRepo repository.IRoleRepository,
+	assignmentRepo repository.ICategoryAssignmentRepository,
+	userRepo repository.IUserRepository,
+	categoryRepo repository.ICategoryRepository,
+	policy IPolicyService,
+	audit IAuditService,
+) IRoleService {
+	return &RoleService{
+		roleRepo:       roleRepo,
+		assignmentRepo: assignmentRepo,
+		userRepo:       userRepo,
+		categoryRepo:   categoryRepo,
+		policy:         policy,
+		audit:          audit,
+	}
+}
+
+// ListPermissions tanımlı tüm yetkileri döndürür
+func (s *RoleService) ListPermissions() []string {
+	return domain.AllPermissions
+}
+
+// ListRoles tüm rolleri listeler
+func (s *RoleService) ListRoles() ([]*domain.Role, error) {
+	return s.roleRepo.List()
+}
+
+// GetRole ada göre rol getirir
+func (s *RoleService) GetRole(name string) (*domain.Role, error) {
+	return s.roleRepo.GetByName(name)
+}
+
+// CreateRole yeni bir rol oluşturur
+func (s *RoleService) CreateRole(req *domain.CreateRoleRequest, actor *domain.Actor) (*domain.Role, error) {
+	if !roleNamePattern.MatchString(req.Name) {
+		return nil, &domain.ValidationError{
+			Field:   "name",
+			Message: "Rol adı küçük harfle başlamalı ve yalnızca küçük harf, rakam ve alt çizgi içermelidir",
+		}
+	}
+
+	if _, err := s.roleRepo.GetByName(req.Name); err == nil {
+		return nil, domain.NewDuplicateError(domain.ResourceRole, "name", req.Name)
+	}
+
+	permissions, err := normalizePermissions(req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	role := &domain.Role{
+		Name:        req.Name,
+		Description: req.Description,
+		Permissions: permissions,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err := s.roleRepo.Create(role); err != nil {
+		return nil, err
+	}
+
+	s.policy.InvalidateRole(role.Name)
+	s.audit.Record(actor, domain.AuditActionCreate, domain.ResourceRole, role.Name, nil, role)
+	return role, nil
+}
+
+// UpdateRole rolün açıklamasını ve yetkilerini günceller
+func (s *RoleService) UpdateRole(name string, req *domain.UpdateRoleRequest, actor *domain.Actor) (*domain.Role, error) {
+	role, err := s.roleRepo.GetByName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions, err := normalizePermissions(req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	// Yöneticilerin rol yönetimine erişimi kaybetmesini engelle
+	if role.Name == domain.RoleAdmin && !containsString(permissions, domain.PermRoleManage) {
+		return nil, &domain.ValidationError{
+			Field:   "permissions",
+			Message: "Yönetici rolünden rol yönetimi yetkisi kaldırılamaz",
+		}
+	}
+
+	before := auditSnapshot(role)
+	if req.Description != nil {
+		role.Description = *req.Description
+	}
+	role.Permissions = permissions
+	role.UpdatedAt = time.Now()
+
+	if err := s.roleRepo.Update(role); err != nil {
+		return nil, err
+	}
+
+	s.policy.InvalidateRole(role.Name)
+	s.audit.Record(actor, domain.AuditActionUpdate, domain.ResourceRole, role.Name, before, role)
+	return role, nil
+}
+
+// DeleteRole sistem rolü olmayan ve kullanılmayan rolü siler
+func (s *RoleService) DeleteRole(name string, actor *domain.Actor) error {
+	role, err := s.roleRepo.GetByName(name)
+	if err != nil {
+		return err
+	}
+
+	if role.IsSystem {
+		return &domain.ValidationError{
+			Field:   "name",
+			Message: "Sistem rolleri silinemez",
+		}
+	}
+
+	count, err := s.roleRepo.CountUsers(name)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return &domain.ValidationError{
+			Field:   "name",
+			Message: "Bu role sahip kullanıcılar varken rol silinemez",
+		}
+	}
+
+	if err := s.roleRepo.Delete(name); err != nil {
+		return err
+	}
+
+	s.policy.InvalidateRole(name)
+	s.audit.Record(actor, domain.AuditActionDelete, domain.ResourceRole, name, role, nil)
+	return nil
+}
+
+// GetAssignedCategories kullanıcının sorumlu olduğu kategorileri döndürür
+func (s *RoleService) GetAssignedCategories(userID uint) ([]uint, error) {
+	if _, err := s.userRepo.GetByID(userID); err != nil {
+		return nil, err
+	}
+	return s.assignmentRepo.ListCategoryIDs(userID)
+}
+
+// AssignCategories kullanıcının sorumlu olduğu kategorileri belirler
+func (s *RoleService) AssignCategories(userID uint, categoryIDs []uint, actor *domain.Actor) ([]uint, error) {
+	if _, err := s.userRepo.GetByID(userID); err != nil {
+		return nil, err
+	}
+
+	previous, err := s.assignmentRepo.ListCategoryIDs(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[uint]bool, len(categoryIDs))
+	ids := make([]uint, 0, len(categoryIDs))
+	for _, id := range categoryIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		if _, err := s.categoryRepo.GetByID(id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	if err := s.assignmentRepo.ReplaceForUser(userID, ids); err != nil {
+		return nil, err
+	}
+
+	s.audit.Record(actor, domain.AuditActionAssign, domain.ResourceUser, userID,
+		map[string][]uint{"category_ids": previous}, map[string][]uint{"category_ids": ids})
+	return ids, nil
+}
+
+// normalizePermissions yetki listesini doğrular ve tekrarları ayıklar
+func normalizePermissions(permissions []string) ([]string, error) {
+	result := make([]string, 0, len(permissions))
+	for _, p := range permissions {
+		if !domain.IsKnownPermission(p) {
+			return nil, &domain.ValidationError{
+				Field:   "permissions",
+				Message: "Tanımsız yetki: " + p,
+			}
+		}
+		if !containsString(result, p) {
+			result = append(result, p)
+		}
+	}
+	return result, nil
+}
+
+// containsString dilimin değeri içerip içermediğini döndürür
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
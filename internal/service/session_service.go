@@ -0,0 +1,240 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/username/haber/internal/domain"
+	"github.com/username/haber/internal/repository"
+	"github.com/username/haber/pkg/auth"
+)
+
+// ISessionService kullanıcı oturumları için service interface
+type ISessionService interface {
+	StartSession(userID uint, familyID string, client *domain.ClientInfo, expiresAt time.Time) (*domain.Session, error)
+	TouchSession(userID uint, familyID string, client *domain.ClientInfo, expiresAt time.Time) error
+	ListSessions(userID uint, currentFamilyID string) ([]*domain.Session, error)
+	RevokeSession(userID, sessionID uint, actor *domain.Actor) error
+	RevokeAllSessions(userID uint, actor *domain.Actor) error
+	EndSession(familyID string) error
+}
+
+// SessionService oturum servisinin implementasyonu
+type SessionService struct {
+	sessionRepo repository.ISessionRepository
+	refreshRepo repository.IRefreshTokenRepository
+	jwtAuth     *auth.JWTAuth
+	revocations auth.RevocationStore
+	audit       IAuditService
+}
+
+// NewSessionService yeni bir SessionService oluşturur
+func NewSessionService(
+	sessionRepo repository.ISessionRepository,
+	refreshRepo repository.IRefreshTokenRepository,
+	jwtAuth *auth.JWTAuth,
+	revocations auth.RevocationStore,
+	audit IAuditService,
+) ISessionService {
+	return &SessionService{
+		sessionRepo: sessionRepo,
+		refreshRepo: refreshRepo,
+		jwtAuth:     jwtAuth,
+		revocations: revocations,
+		audit:       audit,
+	}
+}
+
+// StartSession yeni bir giriş için oturum kaydı oluşturur
+func (s *SessionService) StartSession(userID uint, familyID string, client *domain.ClientInfo, expiresAt time.Time) (*domain.Session, error) {
+	if client == nil {
+		client = &domain.ClientInfo{}
+	}
+
+	now := time.Now()
+	session := &domain.Session{
+		UserID:     userID,
+		FamilyID:   familyID,
+		Device:     describeDevice(client.UserAgent),
+		UserAgent:  truncate(client.UserAgent, 512),
+		IP:         client.IP,
+		LastSeenAt: now,
+		ExpiresAt:  expiresAt,
+		CreatedAt:  now,
+	}
+
+	if err := s.sessionRepo.Create(session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// TouchSession token yenilendiğinde oturumun son görülme bilgisini günceller
+//
+// Oturum kaydı olmayan eski token aileleri için kayıt oluşturulur.
+func (s *SessionService) TouchSession(userID uint, familyID string, client *domain.ClientInfo, expiresAt time.Time) error {
+	if client == nil {
+		client = &domain.ClientInfo{}
+	}
+
+	session, err := s.sessionRepo.GetByFamily(familyID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			_, err = s.StartSession(userID, familyID, client, expiresAt)
+		}
+		return err
+	}
+
+	// İstemci bilgisi gelmediyse önceki değerleri koru
+	ip, userAgent := client.IP, truncate(client.UserAgent, 512)
+	if ip == "" {
+		ip = session.IP
+	}
+	if userAgent == "" {
+		userAgent = session.UserAgent
+	}
+
+	return s.sessionRepo.Touch(familyID, ip, userAgent, time.Now(), expiresAt)
+}
+
+// ListSessions kullanıcının açık oturumlarını listeler
+//
+// currentFamilyID isteği yapan oturumu işaretlemek için kullanılır.
+func (s *SessionService) ListSessions(userID uint, currentFamilyID string) ([]*domain.Session, error) {
+	sessions, err := s.sessionRepo.ListActiveByUser(userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, session := range sessions {
+		session.Current = currentFamilyID != "" && session.FamilyID == currentFamilyID
+	}
+	return sessions, nil
+}
+
+// RevokeSession kullanıcının bir oturumunu sonlandırır
+func (s *SessionService) RevokeSession(userID, sessionID uint, actor *domain.Actor) error {
+	session, err := s.sessionRepo.GetByID(sessionID)
+	if err != nil {
+		return err
+	}
+
+	// Başka kullanıcının oturumunun varlığını belli etme
+	if session.UserID != userID {
+		return &domain.NotFoundError{
+			ResourceType: domain.ResourceSession,
+			ID:           sessionID,
+		}
+	}
+
+	if err := s.EndSession(session.FamilyID); err != nil {
+		return err
+	}
+
+	s.audit.Record(actor, domain.AuditActionRevoke, domain.ResourceSession, session.ID, session, nil)
+	return nil
+}
+
+// RevokeAllSessions kullanıcının tüm oturumlarını sonlandırır
+func (s *SessionService) RevokeAllSessions(userID uint, actor *domain.Actor) error {
+	// Erişim token'ları en fazla kendi ömürleri kadar geçerli kalabilir
+	accessTTL := s.jwtAuth.AccessTokenDuration()
+	if err := s.revocations.RevokeUser(userID, accessTTL); err != nil {
+		return err
+	}
+
+	// Kullanıcı bazlı iptal saniye hassasiyetindedir; iptalle aynı saniyede
+	// üretilmiş token'lar da reddedilsin diye açık oturumlar tek tek iptal edilir
+	sessions, err := s.sessionRepo.ListActiveByUser(userID, time.Now())
+	if err != nil {
+		return err
+	}
+	for _, session := range sessions {
+		if err := auth.RevokeSession(s.revocations, session.FamilyID, accessTTL); err != nil {
+			return err
+		}
+	}
+
+	if err := s.refreshRepo.RevokeByUser(userID); err != nil {
+		return err
+	}
+
+	if err := s.sessionRepo.RevokeByUser(userID); err != nil {
+		return err
+	}
+
+	s.audit.Record(actor, domain.AuditActionRevokeSessions, domain.ResourceUser, userID, nil, nil)
+	return nil
+}
+
+// EndSession oturumu, yenileme token ailesini ve oturumun erişim token'larını iptal eder
+func (s *SessionService) EndSession(familyID string) error {
+	if err := s.refreshRepo.RevokeFamily(familyID); err != nil {
+		return err
+	}
+
+	if err := auth.RevokeSession(s.revocations, familyID, s.jwtAuth.AccessTokenDuration()); err != nil {
+		return err
+	}
+
+	return s.sessionRepo.RevokeByFamily(familyID)
+}
+
+// describeDevice user agent bilgisinden okunur bir cihaz açıklaması üretir
+func describeDevice(userAgent string) string {
+	if userAgent == "" {
+		return "Bilinmeyen cihaz"
+	}
+
+	ua := strings.ToLower(userAgent)
+
+	browser := "Bilinmeyen tarayıcı"
+	switch {
+	case strings.Contains(ua, "edg/"):
+		browser = "Edge"
+	case strings.Contains(ua, "opr/") || strings.Contains(ua, "opera"):
+		browser = "Opera"
+	case strings.Contains(ua, "firefox/"):
+		browser = "Firefox"
+	case strings.Contains(ua, "chrome/") || strings.Contains(ua, "crios/"):
+		browser = "Chrome"
+	case strings.Contains(ua, "safari/"):
+		browser = "Safari"
+	case strings.Contains(ua, "curl/") || strings.Contains(ua, "postman") || strings.Contains(ua, "okhttp"):
+		browser = "API istemcisi"
+	}
+
+	platform := ""
+	switch {
+	case strings.Contains(ua, "iphone"):
+		platform = "iPhone"
+	case strings.Contains(ua, "ipad"):
+		platform = "iPad"
+	case strings.Contains(ua, "android"):
+		platform = "Android"
+	case strings.Contains(ua, "windows"):
+		platform = "Windows"
+	case strings.Contains(ua, "mac os") || strings.Contains(ua, "macintosh"):
+		platform = "macOS"
+	case strings.Contains(ua, "linux"):
+		platform = "Linux"
+	}
+
+	if platform == "" {
+		return browser
+	}
+	return browser + ", " + platform
+}
+
+// truncate metni en fazla limit bayt olacak şekilde, çok baytlı karakterleri bölmeden kısaltır
+func truncate(s string, limit int) string {
+	if len(s) <= limit {
+		return s
+	}
+	for limit > 0 && !utf8.RuneStart(s[limit]) {
+		limit--
+	}
+	return s[:limit]
+}
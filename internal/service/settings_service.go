@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/username/haber/internal/domain"
@@ -41,28 +42,31 @@ func getIntOrDefault(m map[string]string, key string, defaultValue int) int {
 // ISettingsService, ayarlar ile ilgili veritabanı işlemleri için arayüz
 type ISettingsService interface {
 	GetSetting(key string) (string, error)
-	SetSetting(key, value, group string) error
+	SetSetting(key, value, group string, actor *domain.Actor) error
 	GetSettingsByGroup(group string) (map[string]string, error)
 	GetAllSettings() (*domain.AllSettings, error)
-	SaveGeneralSettings(settings map[string]interface{}) error
-	SaveAppearanceSettings(settings map[string]interface{}) error
-	SaveIntegrationSettings(settings map[string]interface{}) error
-	SaveEmailSettings(settings map[string]interface{}) error
-	SaveSocialSettings(settings map[string]interface{}) error
-	SaveSEOSettings(settings map[string]interface{}) error
-	SaveCacheSettings(settings map[string]interface{}) error
-	SaveBackupSettings(settings map[string]interface{}) error
+	SaveGeneralSettings(settings map[string]interface{}, actor *domain.Actor) error
+	SaveAppearanceSettings(settings map[string]interface{}, actor *domain.Actor) error
+	SaveIntegrationSettings(settings map[string]interface{}, actor *domain.Actor) error
+	SaveEmailSettings(settings map[string]interface{}, actor *domain.Actor) error
+	SaveSocialSettings(settings map[string]interface{}, actor *domain.Actor) error
+	SaveSEOSettings(settings map[string]interface{}, actor *domain.Actor) error
+	SaveCacheSettings(settings map[string]interface{}, actor *domain.Actor) error
+	SaveBackupSettings(settings map[string]interface{}, actor *domain.Actor) error
+	SaveSecuritySettings(settings map[string]interface{}, actor *domain.Actor) error
 }
 
 // SettingsService, ayarlar servisi implementasyonu
 type SettingsService struct {
-	DB *gorm.DB
+	DB    *gorm.DB
+	audit IAuditService
 }
 
 // NewSettingsService yeni bir SettingsService oluşturur
-func NewSettingsService(db *gorm.DB) ISettingsService {
+func NewSettingsService(db *gorm.DB, audit IAuditService) ISettingsService {
 	return &SettingsService{
-		DB: db,
+		DB:    db,
+		audit: audit,
 	}
 }
 
@@ -80,7 +84,23 @@ func (s *SettingsService) GetSetting(key string) (string, error) {
 }
 
 // SetSetting, belirli bir ayarı kaydeder
-func (s *SettingsService) SetSetting(key, value, group string) error {
+func (s *SettingsService) SetSetting(key, value, group string, actor *domain.Actor) error {
+	previous, err := s.GetSetting(key)
+	if err != nil {
+		return err
+	}
+
+	if err := s.setSetting(key, value, group); err != nil {
+		return err
+	}
+
+	s.audit.Record(actor, domain.AuditActionUpdate, domain.ResourceSetting, key,
+		maskSettings(map[string]string{key: previous}), maskSettings(map[string]string{key: value}))
+	return nil
+}
+
+// setSetting ayarı denetim kaydı tutmadan yazar
+func (s *SettingsService) setSetting(key, value, group string) error {
 	now := time.Now()
 
 	var setting domain.Setting
@@ -157,47 +177,57 @@ func (s *SettingsService) GetAllSettings() (*domain.AllSettings, error) {
 }
 
 // SaveGeneralSettings, genel ayarları kaydeder
-func (s *SettingsService) SaveGeneralSettings(settings map[string]interface{}) error {
-	return s.saveSettingsToGorm(settings, "general")
+func (s *SettingsService) SaveGeneralSettings(settings map[string]interface{}, actor *domain.Actor) error {
+	return s.saveSettingsToGorm(settings, "general", actor)
 }
 
 // SaveAppearanceSettings, görünüm ayarlarını kaydeder
-func (s *SettingsService) SaveAppearanceSettings(settings map[string]interface{}) error {
-	return s.saveSettingsToGorm(settings, "appearance")
+func (s *SettingsService) SaveAppearanceSettings(settings map[string]interface{}, actor *domain.Actor) error {
+	return s.saveSettingsToGorm(settings, "appearance", actor)
 }
 
 // SaveIntegrationSettings, entegrasyon ayarlarını kaydeder
-func (s *SettingsService) SaveIntegrationSettings(settings map[string]interface{}) error {
-	return s.saveSettingsToGorm(settings, "integration")
+func (s *SettingsService) SaveIntegrationSettings(settings map[string]interface{}, actor *domain.Actor) error {
+	return s.saveSettingsToGorm(settings, "integration", actor)
 }
 
 // SaveEmailSettings, e-posta ayarlarını kaydeder
-func (s *SettingsService) SaveEmailSettings(settings map[string]interface{}) error {
-	return s.saveSettingsToGorm(settings, "email")
+func (s *SettingsService) SaveEmailSettings(settings map[string]interface{}, actor *domain.Actor) error {
+	return s.saveSettingsToGorm(settings, "email", actor)
 }
 
 // SaveSocialSettings, sosyal medya ayarlarını kaydeder
-func (s *SettingsService) SaveSocialSettings(settings map[string]interface{}) error {
-	return s.saveSettingsToGorm(settings, "social")
+func (s *SettingsService) SaveSocialSettings(settings map[string]interface{}, actor *domain.Actor) error {
+	return s.saveSettingsToGorm(settings, "social", actor)
 }
 
 // SaveSEOSettings, SEO ayarlarını kaydeder
-func (s *SettingsService) SaveSEOSettings(settings map[string]interface{}) error {
-	return s.saveSettingsToGorm(settings, "seo")
+func (s *SettingsService) SaveSEOSettings(settings map[string]interface{}, actor *domain.Actor) error {
+	return s.saveSettingsToGorm(settings, "seo", actor)
 }
 
 // SaveCacheSettings, önbellek ayarlarını kaydeder
-func (s *SettingsService) SaveCacheSettings(settings map[string]interface{}) error {
-	return s.saveSettingsToGorm(settings, "cache")
+func (s *SettingsService) SaveCacheSettings(settings map[string]interface{}, actor *domain.Actor) error {
+	return s.saveSettingsToGorm(settings, "cache", actor)
 }
 
 // SaveBackupSettings, yedekleme ayarlarını kaydeder
-func (s *SettingsService) SaveBackupSettings(settings map[string]interface{}) error {
-	return s.saveSettingsToGorm(settings, "backup")
+func (s *SettingsService) SaveBackupSettings(settings map[string]interface{}, actor *domain.Actor) error {
+	return s.saveSettingsToGorm(settings, "backup", actor)
+}
+
+// SaveSecuritySettings, güvenlik ayarlarını kaydeder
+func (s *SettingsService) SaveSecuritySettings(settings map[string]interface{}, actor *domain.Actor) error {
+	return s.saveSettingsToGorm(settings, "security", actor)
 }
 
 // GORM için yardımcı fonksiyon
-func (s *SettingsService) saveSettingsToGorm(settings map[string]interface{}, group string) error {
+func (s *SettingsService) saveSettingsToGorm(settings map[string]interface{}, group string, actor *domain.Actor) error {
+	before, err := s.GetSettingsByGroup(group)
+	if err != nil {
+		return err
+	}
+
 	for key, value := range settings {
 		var strValue string
 		switch v := value.(type) {
@@ -217,9 +247,36 @@ func (s *SettingsService) saveSettingsToGorm(settings map[string]interface{}, gr
 			strValue = string(bytes)
 		}
 
-		if err := s.SetSetting(key, strValue, group); err != nil {
+		if err := s.setSetting(key, strValue, group); err != nil {
 			return err
 		}
 	}
+
+	after, err := s.GetSettingsByGroup(group)
+	if err != nil {
+		return err
+	}
+
+	// Grup tek kayıt olarak tutulur; değişmeyen anahtarlar da görüntüye dahildir
+	s.audit.Record(actor, domain.AuditActionUpdate, domain.ResourceSetting, group, maskSettings(before), maskSettings(after))
 	return nil
 }
+
+// secretSettingMarkers değeri denetim kaydına yazılmayacak ayar adı parçaları
+var secretSettingMarkers = []string{"password", "secret", "token", "api_key", "apikey"}
+
+// maskSettings gizli ayarların değerlerini denetim kaydı için gizler
+func maskSettings(settings map[string]string) map[string]string {
+	masked := make(map[string]string, len(settings))
+	for key, value := range settings {
+		lower := strings.ToLower(key)
+		for _, marker := range secretSettingMarkers {
+			if value != "" && strings.Contains(lower, marker) {
+				value = "***"
+				break
+			}
+		}
+		masked[key] = value
+	}
+	return masked
+}
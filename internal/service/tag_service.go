@@ -13,21 +13,25 @@ type ITagService interface {
 	ListTags(offset, limit int) ([]*domain.Tag, int64, error)
 	GetTagByID(id uint) (*domain.Tag, error)
 	GetTagBySlug(slug string) (*domain.Tag, error)
-	CreateTag(name, slug string) (*domain.Tag, error)
-	UpdateTag(id uint, name, slug string) (*domain.Tag, error)
-	DeleteTag(id uint) error
+	CreateTag(name, slug string, actor *domain.Actor) (*domain.Tag, error)
+	UpdateTag(id uint, name, slug string, actor *domain.Actor) (*domain.Tag, error)
+	DeleteTag(id uint, actor *domain.Actor) error
 	GetPopularTags(limit int) ([]*domain.Tag, error)
 }
 
 // TagService etiket servisinin implementasyonu
 type TagService struct {
 	tagRepo repository.ITagRepository
+	policy  IPolicyService
+	audit   IAuditService
 }
 
 // NewTagService yeni bir TagService oluşturur
-func NewTagService(tagRepo repository.ITagRepository) ITagService {
+func NewTagService(tagRepo repository.ITagRepository, policy IPolicyService, audit IAuditService) ITagService {
 	return &TagService{
 		tagRepo: tagRepo,
+		policy:  policy,
+		audit:   audit,
 	}
 }
 
@@ -47,7 +51,11 @@ func (s *TagService) GetTagBySlug(slug string) (*domain.Tag, error) {
 }
 
 // CreateTag yeni bir etiket oluşturur
-func (s *TagService) CreateTag(name, tagSlug string) (*domain.Tag, error) {
+func (s *TagService) CreateTag(name, tagSlug string, actor *domain.Actor) (*domain.Tag, error) {
+	if err := s.policy.Authorize(actor, domain.PermTagManage); err != nil {
+		return nil, err
+	}
+
 	// Slug belirtilmemişse, isimden oluştur
 	if tagSlug == "" {
 		tagSlug = slug.Make(name)
@@ -65,15 +73,21 @@ func (s *TagService) CreateTag(name, tagSlug string) (*domain.Tag, error) {
 		return nil, err
 	}
 
+	s.audit.Record(actor, domain.AuditActionCreate, domain.ResourceTag, tag.ID, nil, tag)
 	return tag, nil
 }
 
 // UpdateTag etiketi günceller
-func (s *TagService) UpdateTag(id uint, name, tagSlug string) (*domain.Tag, error) {
+func (s *TagService) UpdateTag(id uint, name, tagSlug string, actor *domain.Actor) (*domain.Tag, error) {
+	if err := s.policy.Authorize(actor, domain.PermTagManage); err != nil {
+		return nil, err
+	}
+
 	tag, err := s.tagRepo.GetByID(id)
 	if err != nil {
 		return nil, err
 	}
+	before := auditSnapshot(tag)
 
 	if name != "" {
 		tag.Name = name
@@ -92,12 +106,27 @@ func (s *TagService) UpdateTag(id uint, name, tagSlug string) (*domain.Tag, erro
 		return nil, err
 	}
 
+	s.audit.Record(actor, domain.AuditActionUpdate, domain.ResourceTag, tag.ID, before, tag)
 	return tag, nil
 }
 
 // DeleteTag etiketi siler
-func (s *TagService) DeleteTag(id uint) error {
-	return s.tagRepo.Delete(id)
+func (s *TagService) DeleteTag(id uint, actor *domain.Actor) error {
+	if err := s.policy.Authorize(actor, domain.PermTagManage); err != nil {
+		return err
+	}
+
+	tag, err := s.tagRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.tagRepo.Delete(id); err != nil {
+		return err
+	}
+
+	s.audit.Record(actor, domain.AuditActionDelete, domain.ResourceTag, id, tag, nil)
+	return nil
 }
 
 // GetPopularTags popüler etiketleri getirir
@@ -1,6 +1,9 @@
 package service
 
 import (
+	"errors"
+	"log"
+	"strings"
 	"time"
 
 	"github.com/username/haber/internal/domain"
@@ -14,24 +17,35 @@ type IUserService interface {
 	GetUserByUsername(username string) (*domain.User, error)
 	GetUserByEmail(email string) (*domain.User, error)
 	ListUsers(offset, limit int, filters map[string]interface{}) ([]*domain.User, int64, error)
-	CreateUser(user *domain.User) error
-	UpdateUser(user *domain.User) error
-	DeleteUser(id uint) error
+	CreateUser(user *domain.User, actor *domain.Actor) error
+	UpdateUser(user *domain.User, actor *domain.Actor) error
+	DeleteUser(id uint, actor *domain.Actor) error
 	ChangePassword(id uint, currentPassword, newPassword string) error
 	UpdatePassword(id uint, currentPassword, newPassword string) error
 	CheckPassword(hashedPassword, password string) bool
 	SearchUsers(query string, offset, limit int) ([]*domain.User, int64, error)
+	ChangeRole(actor *domain.Actor, userID uint, role string) error
 }
 
 // UserService kullanıcı servis implementasyonu
 type UserService struct {
 	userRepo repository.IUserRepository
+	roleRepo repository.IRoleRepository
+	policy   IPolicyService
+	audit    IAuditService
+	usage    IMediaUsageService
+	sessions ISessionService
 }
 
 // NewUserService yeni bir UserService oluşturur
-func NewUserService(userRepo repository.IUserRepository) IUserService {
+func NewUserService(userRepo repository.IUserRepository, roleRepo repository.IRoleRepository, policy IPolicyService, audit IAuditService, usage IMediaUsageService, sessions ISessionService) IUserService {
 	return &UserService{
 		userRepo: userRepo,
+		roleRepo: roleRepo,
+		policy:   policy,
+		audit:    audit,
+		usage:    usage,
+		sessions: sessions,
 	}
 }
 
@@ -56,7 +70,14 @@ func (s *UserService) ListUsers(offset, limit int, filters map[string]interface{
 }
 
 // CreateUser yeni bir kullanıcı oluşturur
-func (s *UserService) CreateUser(user *domain.User) error {
+func (s *UserService) CreateUser(user *domain.User, actor *domain.Actor) error {
+	if err := s.validateRole(user.Role); err != nil {
+		return err
+	}
+	if err := s.checkUsername(user.Username); err != nil {
+		return err
+	}
+
 	// Şifreyi hashle
 	hashedPassword, err := auth.HashPassword(user.PasswordHash)
 	if err != nil {
@@ -68,18 +89,65 @@ func (s *UserService) CreateUser(user *domain.User) error {
 	user.CreatedAt = now
 	user.UpdatedAt = now
 
-	return s.userRepo.Create(user)
+	// Yönetici tarafından açılan hesaplar doğrulanmış kabul edilir
+	if user.Status == "" {
+		user.Status = domain.UserStatusActive
+		user.EmailVerifiedAt = &now
+	}
+
+	if err := s.userRepo.Create(user); err != nil {
+		return err
+	}
+	s.syncMediaUsage(user)
+
+	s.audit.Record(actor, domain.AuditActionCreate, domain.ResourceUser, user.ID, nil, user)
+	return nil
 }
 
 // UpdateUser kullanıcıyı günceller
-func (s *UserService) UpdateUser(user *domain.User) error {
+//
+// Rol bu yolla değiştirilemez, ChangeRole kullanılmalıdır.
+func (s *UserService) UpdateUser(user *domain.User, actor *domain.Actor) error {
+	// Çağıran nesneyi zaten değiştirmiş olduğundan önceki hal veritabanından alınır
+	existing, err := s.userRepo.GetByID(user.ID)
+	if err != nil {
+		return err
+	}
+	user.Role = existing.Role
+
+	if user.Username != existing.Username {
+		if err := s.checkUsername(user.Username); err != nil {
+			return err
+		}
+	}
+
 	user.UpdatedAt = time.Now()
-	return s.userRepo.Update(user)
+	if err := s.userRepo.Update(user); err != nil {
+		return err
+	}
+	s.syncMediaUsage(user)
+
+	s.audit.Record(actor, domain.AuditActionUpdate, domain.ResourceUser, user.ID, existing, user)
+	return nil
 }
 
 // DeleteUser kullanıcıyı siler
-func (s *UserService) DeleteUser(id uint) error {
-	return s.userRepo.Delete(id)
+func (s *UserService) DeleteUser(id uint, actor *domain.Actor) error {
+	user, err := s.userRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.userRepo.Delete(id); err != nil {
+		return err
+	}
+
+	if err := s.usage.RemoveOwner(domain.MediaOwnerUser, id); err != nil {
+		log.Printf("Kullanıcı %d medya kullanımları silinemedi: %v", id, err)
+	}
+
+	s.audit.Record(actor, domain.AuditActionDelete, domain.ResourceUser, id, user, nil)
+	return nil
 }
 
 // ChangePassword kullanıcı şifresini değiştirir
@@ -145,3 +213,81 @@ func (s *UserService) CheckPassword(hashedPassword, password string) bool {
 func (s *UserService) SearchUsers(query string, offset, limit int) ([]*domain.User, int64, error) {
 	return s.userRepo.Search(query, offset, limit)
 }
+
+// ChangeRole kullanıcının rolünü değiştirir
+//
+// Rol erişim token'larında taşındığından kullanıcının açık oturumları
+// sonlandırılır; yeni yetkiler bir sonraki girişte geçerli olur.
+func (s *UserService) ChangeRole(actor *domain.Actor, userID uint, role string) error {
+	if err := s.policy.Authorize(actor, domain.PermUserManage); err != nil {
+		return err
+	}
+
+	if err := s.validateRole(role); err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if user.Role == role {
+		return nil
+	}
+
+	before := auditSnapshot(user)
+	user.Role = role
+	user.UpdatedAt = time.Now()
+	if err := s.userRepo.Update(user); err != nil {
+		return err
+	}
+
+	s.audit.Record(actor, domain.AuditActionRoleChange, domain.ResourceUser, user.ID, before, user)
+	return s.sessions.RevokeAllSessions(user.ID, actor)
+}
+
+// checkUsername yeni kullanıcı adının kullanılabilir olup olmadığını kontrol eder
+func (s *UserService) checkUsername(username string) error {
+	// Giriş e-posta ile de yapılabildiğinden kullanıcı adı e-posta gibi görünemez
+	if strings.Contains(username, "@") {
+		return &domain.ValidationError{
+			Field:   "username",
+			Message: "Kullanıcı adı @ karakteri içeremez",
+		}
+	}
+
+	if _, err := s.userRepo.GetByUsername(username); err == nil {
+		return domain.NewDuplicateError(domain.ResourceUser, "username", username)
+	} else if !errors.Is(err, domain.ErrNotFound) {
+		return err
+	}
+	return nil
+}
+
+// validateRole rolün tanımlı olup olmadığını kontrol eder
+func (s *UserService) validateRole(role string) error {
+	if role == "" {
+		return nil
+	}
+
+	if _, err := s.roleRepo.GetByName(role); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return &domain.ValidationError{
+				Field:   "role",
+				Message: "Tanımsız rol: " + role,
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+// syncMediaUsage kullanıcının profil görselini medya kullanımı olarak kaydeder
+//
+// Kullanıcı kaydedildikten sonra çağrılır; hata kaydı geri almaz, loglanır.
+func (s *UserService) syncMediaUsage(user *domain.User) {
+	if err := s.usage.SyncUser(user); err != nil {
+		log.Printf("Kullanıcı %d medya kullanımları güncellenemedi: %v", user.ID, err)
+	}
+}
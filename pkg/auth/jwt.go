@@ -3,6 +3,8 @@ package auth
 import (
 	"errors"
 	"fmt"
+	"log"
+	"sync"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -26,13 +28,40 @@ type JWTCustomClaims struct {
 	Username  string `json:"username"`
 	Email     string `json:"email"`
 	Role      string `json:"role"`
+	Status    string `json:"status"`
 	TokenType string `json:"token_type"`
+	// Token'ın ait olduğu oturum (yenileme token ailesi)
+	SessionID string `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// keyReloadInterval bilinmeyen kid için depodan yeniden okuma arasındaki en kısa süre
+//
+// Başka bir sunucunun döndürdüğü anahtarla imzalanmış token'lar bu sayede tanınır,
+// uydurma kid değerleri ise depoya yük bindiremez.
+const keyReloadInterval = 30 * time.Second
+
+// keyExpirySkew emekliye ayrılan anahtarların token ömrüne ek olarak tutulma süresi
+const keyExpirySkew = 5 * time.Minute
+
 // JWTAuth JWT kimlik doğrulama işlemlerini yönetir
+//
+// Anahtar deposu verilmezse token'lar paylaşılan sır ile HS256 olarak imzalanır.
+// Depo verildiğinde token'lar kid başlığı taşıyan RS256/EdDSA imzalarıyla
+// üretilir ve yalnızca açık anahtarlarla doğrulanabilir.
 type JWTAuth struct {
 	cfg *config.JWTConfig
+
+	keyStore  KeyStore
+	algorithm string
+	rotation  time.Duration
+	// Asimetrik moda geçişte eski HS256 token'larının süreleri dolana kadar kabul edilmesi
+	acceptLegacy bool
+
+	mu         sync.RWMutex
+	keys       map[string]*SigningKey
+	current    *SigningKey
+	lastReload time.Time
 }
 
 // NewJWTAuth yeni bir JWTAuth örneği oluşturur
@@ -42,19 +71,296 @@ func NewJWTAuth(secret string, accessTokenExp, refreshTokenExp int) *JWTAuth {
 		AccessTokenExp:  accessTokenExp,
 		RefreshTokenExp: refreshTokenExp,
 	}
-	return &JWTAuth{cfg: cfg}
+	return &JWTAuth{cfg: cfg, algorithm: AlgHS256}
+}
+
+// NewJWTAuthWithKeys asimetrik anahtarlarla imzalayan bir JWTAuth örneği oluşturur
+//
+// Depoda etkin anahtar yoksa yeni bir anahtar üretilir. rotation sıfırdan büyükse
+// etkin anahtar bu süreyi aştığında RotateKeys yeni anahtara geçer.
+func NewJWTAuthWithKeys(store KeyStore, algorithm string, rotation time.Duration, accessTokenExp, refreshTokenExp int) (*JWTAuth, error) {
+	if algorithm != AlgRS256 && algorithm != AlgEdDSA {
+		return nil, fmt.Errorf("desteklenmeyen imza algoritması: %s", algorithm)
+	}
+
+	j := &JWTAuth{
+		cfg: &config.JWTConfig{
+			AccessTokenExp:  accessTokenExp,
+			RefreshTokenExp: refreshTokenExp,
+		},
+		keyStore:  store,
+		algorithm: algorithm,
+		rotation:  rotation,
+		keys:      make(map[string]*SigningKey),
+	}
+
+	if _, err := j.RotateKeys(false); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+// NewJWTAuthFromConfig yapılandırmadaki algoritmaya göre JWTAuth oluşturur
+func NewJWTAuthFromConfig(cfg config.IJWTConfig) (*JWTAuth, error) {
+	algorithm := cfg.GetAlgorithm()
+	if algorithm == "" || algorithm == AlgHS256 {
+		j := NewJWTAuth(cfg.GetSecret(), cfg.GetAccessTokenExp(), cfg.GetRefreshTokenExp())
+		j.cfg.RememberRefreshTokenExp = cfg.GetRememberRefreshTokenExp()
+		return j, nil
+	}
+
+	store, err := NewFileKeyStore(cfg.GetKeysDir())
+	if err != nil {
+		return nil, err
+	}
+
+	rotation := time.Duration(cfg.GetKeyRotationHours()) * time.Hour
+	j, err := NewJWTAuthWithKeys(store, algorithm, rotation, cfg.GetAccessTokenExp(), cfg.GetRefreshTokenExp())
+	if err != nil {
+		return nil, err
+	}
+	j.cfg.RememberRefreshTokenExp = cfg.GetRememberRefreshTokenExp()
+
+	if cfg.GetAcceptLegacyHS256() && cfg.GetSecret() != "" {
+		j.cfg.Secret = cfg.GetSecret()
+		j.acceptLegacy = true
+	}
+	return j, nil
+}
+
+// Algorithm token imzalamada kullanılan algoritmayı döndürür
+func (j *JWTAuth) Algorithm() string {
+	return j.algorithm
+}
+
+// IsAsymmetric token'ların asimetrik anahtarlarla imzalanıp imzalanmadığını döndürür
+func (j *JWTAuth) IsAsymmetric() bool {
+	return j.keyStore != nil
+}
+
+// RotateKeys gerekiyorsa yeni imza anahtarına geçer ve süresi dolan anahtarları siler
+//
+// force false ise yalnızca etkin anahtar yoksa ya da döndürme süresi dolduysa
+// yeni anahtar üretilir. Önceki anahtarlar emekliye ayrılır ve en uzun token
+// ömrü boyunca doğrulama için tutulur. Etkin anahtarı döndürür.
+func (j *JWTAuth) RotateKeys(force bool) (*SigningKey, error) {
+	if j.keyStore == nil {
+		return nil, errors.New("HS256 modunda anahtar döndürme desteklenmez")
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	// Diğer sunucuların yaptığı döndürmeleri görmek için depodan oku
+	keys, err := j.keyStore.LoadKeys()
+	if err != nil {
+		return nil, fmt.Errorf("imza anahtarları okunamadı: %w", err)
+	}
+
+	now := time.Now().UTC()
+	var current *SigningKey
+	live := make([]*SigningKey, 0, len(keys))
+	for _, key := range keys {
+		if key.IsExpired(now) {
+			if err := j.keyStore.DeleteKey(key.ID); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		live = append(live, key)
+	}
+	sortKeysNewestFirst(live)
+
+	for _, key := range live {
+		if key.IsActive() && key.Algorithm == j.algorithm {
+			current = key
+			break
+		}
+	}
+
+	due := current == nil || force ||
+		(j.rotation > 0 && now.Sub(current.CreatedAt) >= j.rotation)
+	if due {
+		current, err = GenerateSigningKey(j.algorithm)
+		if err != nil {
+			return nil, err
+		}
+		if err := j.keyStore.SaveKey(current); err != nil {
+			return nil, fmt.Errorf("imza anahtarı kaydedilemedi: %w", err)
+		}
+		live = append([]*SigningKey{current}, live...)
+	}
+
+	// Yeni token imzalamayacak anahtarları emekliye ayır
+	expiresAt := now.Add(j.maxTokenLifetime() + keyExpirySkew)
+	for _, key := range live {
+		if key == current || !key.IsActive() {
+			continue
+		}
+		retiredAt := now
+		key.RetiredAt = &retiredAt
+		key.ExpiresAt = &expiresAt
+		if err := j.keyStore.SaveKey(key); err != nil {
+			return nil, err
+		}
+	}
+
+	j.setKeys(live, current, now)
+	return current, nil
+}
+
+// StartKeyRotation anahtarların periyodik olarak döndürülmesini başlatır
+//
+// Dönen fonksiyon çağrıldığında döndürme durdurulur.
+func (j *JWTAuth) StartKeyRotation(interval time.Duration) (stop func()) {
+	done := make(chan struct{})
+	ticker := time.NewTicker(interval)
+
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if _, err := j.RotateKeys(false); err != nil {
+					log.Printf("İmza anahtarları döndürülemedi: %v", err)
+				}
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() { once.Do(func() { close(done) }) }
+}
+
+// JWKS doğrulamada kullanılabilecek açık anahtarları döndürür
+//
+// HS256 modunda paylaşılabilecek bir açık anahtar olmadığından küme boştur.
+func (j *JWTAuth) JWKS() (*JSONWebKeySet, error) {
+	set := &JSONWebKeySet{Keys: []JSONWebKey{}}
+	if j.keyStore == nil {
+		return set, nil
+	}
+
+	j.mu.RLock()
+	keys := make([]*SigningKey, 0, len(j.keys))
+	for _, key := range j.keys {
+		keys = append(keys, key)
+	}
+	j.mu.RUnlock()
+
+	sortKeysNewestFirst(keys)
+	now := time.Now()
+	for _, key := range keys {
+		if key.IsExpired(now) {
+			continue
+		}
+		jwk, err := key.toJWK()
+		if err != nil {
+			return nil, err
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set, nil
+}
+
+// setKeys bellekteki anahtar kümesini değiştirir, kilit tutulurken çağrılmalıdır
+func (j *JWTAuth) setKeys(keys []*SigningKey, current *SigningKey, loadedAt time.Time) {
+	j.keys = make(map[string]*SigningKey, len(keys))
+	for _, key := range keys {
+		j.keys[key.ID] = key
+	}
+	j.current = current
+	j.lastReload = loadedAt
+}
+
+// maxTokenLifetime üretilen token'ların en uzun geçerlilik süresi
+func (j *JWTAuth) maxTokenLifetime() time.Duration {
+	if refresh := j.RememberRefreshTokenDuration(); refresh > j.AccessTokenDuration() {
+		return refresh
+	}
+	return j.AccessTokenDuration()
+}
+
+// currentKey imzalamada kullanılacak anahtarı döndürür
+func (j *JWTAuth) currentKey() (*SigningKey, error) {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	if j.current == nil {
+		return nil, errors.New("etkin imza anahtarı yok")
+	}
+	return j.current, nil
+}
+
+// lookupKey kid ile doğrulama anahtarını bulur
+//
+// Bellekte bulunmayan anahtarlar için depo, keyReloadInterval'da en fazla bir kez
+// yeniden okunur.
+func (j *JWTAuth) lookupKey(kid string) (*SigningKey, error) {
+	j.mu.RLock()
+	key, ok := j.keys[kid]
+	stale := time.Since(j.lastReload) >= keyReloadInterval
+	j.mu.RUnlock()
+
+	if !ok && stale {
+		if err := j.reloadKeys(); err != nil {
+			return nil, err
+		}
+		j.mu.RLock()
+		key, ok = j.keys[kid]
+		j.mu.RUnlock()
+	}
+
+	if !ok || key.IsExpired(time.Now()) {
+		return nil, fmt.Errorf("bilinmeyen imza anahtarı: %s", kid)
+	}
+	return key, nil
+}
+
+// reloadKeys anahtarları depodan yeniden okur
+func (j *JWTAuth) reloadKeys() error {
+	keys, err := j.keyStore.LoadKeys()
+	if err != nil {
+		return fmt.Errorf("imza anahtarları okunamadı: %w", err)
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	current := j.current
+	sortKeysNewestFirst(keys)
+	for _, key := range keys {
+		if key.IsActive() && key.Algorithm == j.algorithm {
+			current = key
+			break
+		}
+	}
+	j.setKeys(keys, current, time.Now())
+	return nil
 }
 
 // GenerateTokens erişim ve yenileme tokenlarını oluşturur
 func (j *JWTAuth) GenerateTokens(user *domain.User) (accessToken, refreshToken string, err error) {
+	return j.GenerateSessionTokens(user, "")
+}
+
+// GenerateSessionTokens verilen oturuma bağlı erişim ve yenileme tokenlarını oluşturur
+func (j *JWTAuth) GenerateSessionTokens(user *domain.User, sessionID string) (accessToken, refreshToken string, err error) {
+	return j.GenerateSessionTokensWithExpiry(user, sessionID, j.RefreshTokenDuration())
+}
+
+// GenerateSessionTokensWithExpiry yenileme token'ı verilen süre geçerli olan token çifti oluşturur
+func (j *JWTAuth) GenerateSessionTokensWithExpiry(user *domain.User, sessionID string, refreshExpiration time.Duration) (accessToken, refreshToken string, err error) {
 	// Access token oluşturma
-	accessToken, err = j.generateToken(user, AccessToken, time.Minute*time.Duration(j.cfg.AccessTokenExp))
+	accessToken, err = j.generateToken(user, sessionID, AccessToken, j.AccessTokenDuration())
 	if err != nil {
 		return "", "", fmt.Errorf("erişim tokeni oluşturulurken hata: %w", err)
 	}
 
 	// Refresh token oluşturma
-	refreshToken, err = j.generateToken(user, RefreshToken, time.Hour*time.Duration(j.cfg.RefreshTokenExp))
+	refreshToken, err = j.generateToken(user, sessionID, RefreshToken, refreshExpiration)
 	if err != nil {
 		return "", "", fmt.Errorf("yenileme tokeni oluşturulurken hata: %w", err)
 	}
@@ -62,18 +368,46 @@ func (j *JWTAuth) GenerateTokens(user *domain.User) (accessToken, refreshToken s
 	return accessToken, refreshToken, nil
 }
 
+// AccessTokenDuration erişim token'ının geçerlilik süresini döndürür
+func (j *JWTAuth) AccessTokenDuration() time.Duration {
+	return time.Minute * time.Duration(j.cfg.AccessTokenExp)
+}
+
+// RefreshTokenDuration yenileme token'ının geçerlilik süresini döndürür
+func (j *JWTAuth) RefreshTokenDuration() time.Duration {
+	return time.Hour * time.Duration(j.cfg.RefreshTokenExp)
+}
+
+// RememberRefreshTokenDuration "beni hatırla" ile yapılan girişlerde yenileme token'ının geçerlilik süresini döndürür
+//
+// Ayarlanmamışsa veya normal süreden kısaysa normal süre kullanılır.
+func (j *JWTAuth) RememberRefreshTokenDuration() time.Duration {
+	if remember := time.Hour * time.Duration(j.cfg.RememberRefreshTokenExp); remember > j.RefreshTokenDuration() {
+		return remember
+	}
+	return j.RefreshTokenDuration()
+}
+
 // generateToken belirtilen tipte ve sürede token oluşturur
-func (j *JWTAuth) generateToken(user *domain.User, tokenType TokenType, expiration time.Duration) (string, error) {
+func (j *JWTAuth) generateToken(user *domain.User, sessionID string, tokenType TokenType, expiration time.Duration) (string, error) {
 	// Token sona erme süresi
 	expirationTime := time.Now().Add(expiration)
 
+	// Aynı saniyede üretilen token'ların birbirinden ayrışması için benzersiz kimlik
+	jti, err := GenerateRandomToken(16)
+	if err != nil {
+		return "", err
+	}
+
 	// Token claim'leri
 	claims := &JWTCustomClaims{
 		UserID:    user.ID,
 		Username:  user.Username,
 		Email:     user.Email,
 		Role:      user.Role,
+		Status:    user.Status,
 		TokenType: string(tokenType),
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			// Standart JWT claim'leri
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
@@ -81,33 +415,62 @@ func (j *JWTAuth) generateToken(user *domain.User, tokenType TokenType, expirati
 			NotBefore: jwt.NewNumericDate(time.Now()),
 			Issuer:    "haber-sitesi",
 			Subject:   fmt.Sprintf("%d", user.ID),
+			ID:        jti,
 		},
 	}
 
-	// Token oluşturma
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	// HS256 modunda paylaşılan sır ile imzala
+	if j.keyStore == nil {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		return token.SignedString([]byte(j.cfg.Secret))
+	}
 
-	// Token'ı imzalayıp dizeye dönüştür
-	tokenString, err := token.SignedString([]byte(j.cfg.Secret))
+	// Etkin asimetrik anahtarla imzala, doğrulayıcılar anahtarı kid ile bulur
+	key, err := j.currentKey()
 	if err != nil {
 		return "", err
 	}
 
-	return tokenString, nil
+	token := jwt.NewWithClaims(jwt.GetSigningMethod(key.Algorithm), claims)
+	token.Header["kid"] = key.ID
+
+	return token.SignedString(key.PrivateKey)
 }
 
 // ValidateToken tokeni doğrular ve kullanıcı bilgilerini döndürür
 func (j *JWTAuth) ValidateToken(tokenString string) (*domain.User, error) {
-	// Token'ı parse et
-	token, err := jwt.ParseWithClaims(tokenString, &JWTCustomClaims{}, func(token *jwt.Token) (interface{}, error) {
-		// HS256 algoritmasını kullandığımızı kontrol et
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("beklenmeyen imza metodu: %v", token.Header["alg"])
-		}
+	claims, err := j.ParseAccessToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
 
-		// Secret key döndür
-		return []byte(j.cfg.Secret), nil
-	})
+	return claims.User(), nil
+}
+
+// ValidateRefreshToken yenileme tokenini doğrular ve kullanıcı bilgilerini döndürür
+func (j *JWTAuth) ValidateRefreshToken(refreshTokenString string) (*domain.User, error) {
+	claims, err := j.ParseRefreshToken(refreshTokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	return claims.User(), nil
+}
+
+// ParseAccessToken erişim token'ını doğrular ve claim'lerini döndürür
+func (j *JWTAuth) ParseAccessToken(tokenString string) (*JWTCustomClaims, error) {
+	return j.parseToken(tokenString, AccessToken)
+}
+
+// ParseRefreshToken yenileme token'ını doğrular ve claim'lerini döndürür
+func (j *JWTAuth) ParseRefreshToken(tokenString string) (*JWTCustomClaims, error) {
+	return j.parseToken(tokenString, RefreshToken)
+}
+
+// parseToken token'ı doğrular ve beklenen tipte olduğunu kontrol eder
+func (j *JWTAuth) parseToken(tokenString string, expectedType TokenType) (*JWTCustomClaims, error) {
+	// Token'ı parse et
+	token, err := jwt.ParseWithClaims(tokenString, &JWTCustomClaims{}, j.verificationKey)
 
 	if err != nil {
 		return nil, fmt.Errorf("token parse hatası: %w", err)
@@ -124,62 +487,53 @@ func (j *JWTAuth) ValidateToken(tokenString string) (*domain.User, error) {
 		return nil, errors.New("token claim'leri alınamadı")
 	}
 
-	// Token tipini kontrol et (sadece erişim tokenleri için)
-	if claims.TokenType != string(AccessToken) {
-		return nil, errors.New("token tipini doğrulama hatası, access token bekleniyor")
-	}
-
-	// Kullanıcı nesnesini oluştur ve döndür
-	user := &domain.User{
-		ID:       claims.UserID,
-		Username: claims.Username,
-		Email:    claims.Email,
-		Role:     claims.Role,
+	// Token tipini kontrol et
+	if claims.TokenType != string(expectedType) {
+		return nil, fmt.Errorf("token tipini doğrulama hatası, %s token bekleniyor", expectedType)
 	}
 
-	return user, nil
+	return claims, nil
 }
 
-// ValidateRefreshToken yenileme tokenini doğrular ve kullanıcı bilgilerini döndürür
-func (j *JWTAuth) ValidateRefreshToken(refreshTokenString string) (*domain.User, error) {
-	// Token'ı parse et
-	token, err := jwt.ParseWithClaims(refreshTokenString, &JWTCustomClaims{}, func(token *jwt.Token) (interface{}, error) {
-		// HS256 algoritmasını kullandığımızı kontrol et
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+// verificationKey token başlığına göre doğrulama anahtarını seçer
+func (j *JWTAuth) verificationKey(token *jwt.Token) (interface{}, error) {
+	// HS256 token'ları yalnızca paylaşılan sır biliniyorsa kabul edilir
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+		if j.keyStore != nil && !j.acceptLegacy {
 			return nil, fmt.Errorf("beklenmeyen imza metodu: %v", token.Header["alg"])
 		}
-
-		// Secret key döndür
 		return []byte(j.cfg.Secret), nil
-	})
-
-	if err != nil {
-		return nil, fmt.Errorf("token parse hatası: %w", err)
 	}
 
-	// Token'ın geçerli olup olmadığını kontrol et
-	if !token.Valid {
-		return nil, errors.New("token geçerli değil")
+	if j.keyStore == nil {
+		return nil, fmt.Errorf("beklenmeyen imza metodu: %v", token.Header["alg"])
 	}
 
-	// Token claim'lerini döndür
-	claims, ok := token.Claims.(*JWTCustomClaims)
-	if !ok {
-		return nil, errors.New("token claim'leri alınamadı")
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		return nil, errors.New("token kid başlığı içermiyor")
 	}
 
-	// Token tipini kontrol et
-	if claims.TokenType != string(RefreshToken) {
-		return nil, errors.New("token tipini doğrulama hatası, refresh token bekleniyor")
+	key, err := j.lookupKey(kid)
+	if err != nil {
+		return nil, err
 	}
 
-	// Kullanıcı nesnesini oluştur ve döndür
-	user := &domain.User{
-		ID:       claims.UserID,
-		Username: claims.Username,
-		Email:    claims.Email,
-		Role:     claims.Role,
+	// Algoritma karışıklığı saldırılarına karşı anahtarın algoritmasını zorunlu kıl
+	if token.Method.Alg() != key.Algorithm {
+		return nil, fmt.Errorf("beklenmeyen imza metodu: %v", token.Header["alg"])
 	}
 
-	return user, nil
+	return key.PublicKey(), nil
+}
+
+// User claim'lerden kullanıcı nesnesini oluşturur
+func (c *JWTCustomClaims) User() *domain.User {
+	return &domain.User{
+		ID:       c.UserID,
+		Username: c.Username,
+		Email:    c.Email,
+		Role:     c.Role,
+		Status:   c.Status,
+	}
 }
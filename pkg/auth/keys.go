@@ -0,0 +1,361 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Desteklenen imza algoritmaları
+const (
+	AlgHS256 = "HS256"
+	AlgRS256 = "RS256"
+	AlgEdDSA = "EdDSA"
+)
+
+// rsaKeyBits üretilen RSA anahtarlarının boyutu
+const rsaKeyBits = 2048
+
+// SigningKey token imzalamak için kullanılan asimetrik anahtar
+//
+// Emekliye ayrılan anahtarlar yeni token imzalamaz, ancak ExpiresAt anına kadar
+// daha önce imzaladıkları token'ları doğrulamak için tutulur.
+type SigningKey struct {
+	ID         string
+	Algorithm  string
+	PrivateKey crypto.Signer
+	CreatedAt  time.Time
+	RetiredAt  *time.Time
+	ExpiresAt  *time.Time
+}
+
+// PublicKey anahtarın açık bileşenini döndürür
+func (k *SigningKey) PublicKey() crypto.PublicKey {
+	return k.PrivateKey.Public()
+}
+
+// IsActive anahtarın yeni token imzalayıp imzalayamayacağını döndürür
+func (k *SigningKey) IsActive() bool {
+	return k.RetiredAt == nil
+}
+
+// IsExpired anahtarın doğrulama için de kullanılamaz hale gelip gelmediğini döndürür
+func (k *SigningKey) IsExpired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
+
+// GenerateSigningKey verilen algoritma için yeni bir anahtar üretir
+func GenerateSigningKey(algorithm string) (*SigningKey, error) {
+	var signer crypto.Signer
+	switch algorithm {
+	case AlgRS256:
+		key, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+		if err != nil {
+			return nil, err
+		}
+		signer = key
+	case AlgEdDSA:
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		signer = key
+	default:
+		return nil, fmt.Errorf("desteklenmeyen imza algoritması: %s", algorithm)
+	}
+
+	kid, err := GenerateRandomToken(8)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SigningKey{
+		ID:         kid,
+		Algorithm:  algorithm,
+		PrivateKey: signer,
+		CreatedAt:  time.Now().UTC(),
+	}, nil
+}
+
+// KeyStore imza anahtarlarını saklayan depo arayüzü
+//
+// Birden çok sunucu aynı anahtarları kullanacaksa depo paylaşılmalıdır.
+type KeyStore interface {
+	LoadKeys() ([]*SigningKey, error)
+	SaveKey(key *SigningKey) error
+	DeleteKey(kid string) error
+}
+
+// MemoryKeyStore bellek içi anahtar deposu (tek sunuculu kurulumlar ve testler için)
+//
+// Anahtarlar yeniden başlatmada kaybolur; bu durumda önceki token'lar doğrulanamaz.
+type MemoryKeyStore struct {
+	keys map[string]*SigningKey
+	mu   sync.RWMutex
+}
+
+// NewMemoryKeyStore yeni bir MemoryKeyStore oluşturur
+func NewMemoryKeyStore() *MemoryKeyStore {
+	return &MemoryKeyStore{keys: make(map[string]*SigningKey)}
+}
+
+// LoadKeys tüm anahtarları döndürür
+func (s *MemoryKeyStore) LoadKeys() ([]*SigningKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make([]*SigningKey, 0, len(s.keys))
+	for _, key := range s.keys {
+		copied := *key
+		keys = append(keys, &copied)
+	}
+	return keys, nil
+}
+
+// SaveKey anahtarı ekler ya da günceller
+func (s *MemoryKeyStore) SaveKey(key *SigningKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	copied := *key
+	s.keys[key.ID] = &copied
+	return nil
+}
+
+// DeleteKey anahtarı siler
+func (s *MemoryKeyStore) DeleteKey(kid string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.keys, kid)
+	return nil
+}
+
+// PEM başlıklarında tutulan anahtar bilgileri
+const (
+	pemHeaderKeyID     = "Key-Id"
+	pemHeaderAlgorithm = "Algorithm"
+	pemHeaderCreatedAt = "Created-At"
+	pemHeaderRetiredAt = "Retired-At"
+	pemHeaderExpiresAt = "Expires-At"
+)
+
+// FileKeyStore anahtarları bir dizinde PKCS#8 PEM dosyaları olarak saklayan depo
+//
+// Her anahtar "<kid>.pem" dosyasında, durum bilgileri PEM başlıklarında tutulur.
+// Birden çok sunucuda paylaşılan bir dizin (ör. ortak disk) kullanılmalıdır.
+type FileKeyStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewFileKeyStore yeni bir FileKeyStore oluşturur, dizin yoksa oluşturur
+func NewFileKeyStore(dir string) (*FileKeyStore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("anahtar dizini oluşturulamadı: %w", err)
+	}
+	return &FileKeyStore{dir: dir}, nil
+}
+
+// LoadKeys dizindeki tüm anahtarları okur
+func (s *FileKeyStore) LoadKeys() ([]*SigningKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	paths, err := filepath.Glob(filepath.Join(s.dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]*SigningKey, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		key, err := decodeSigningKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s okunamadı: %w", filepath.Base(path), err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// SaveKey anahtarı dosyaya yazar
+//
+// Yazma geçici dosya üzerinden yapılır; diğer sunucular yarım dosya okumaz.
+func (s *FileKeyStore) SaveKey(key *SigningKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := encodeSigningKey(key)
+	if err != nil {
+		return err
+	}
+
+	path := s.path(key.ID)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// DeleteKey anahtar dosyasını siler
+func (s *FileKeyStore) DeleteKey(kid string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.Remove(s.path(kid)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// path anahtar dosyasının yolunu döndürür
+func (s *FileKeyStore) path(kid string) string {
+	return filepath.Join(s.dir, filepath.Base(kid)+".pem")
+}
+
+// encodeSigningKey anahtarı PEM biçimine dönüştürür
+func encodeSigningKey(key *SigningKey) ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := map[string]string{
+		pemHeaderKeyID:     key.ID,
+		pemHeaderAlgorithm: key.Algorithm,
+		pemHeaderCreatedAt: key.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if key.RetiredAt != nil {
+		headers[pemHeaderRetiredAt] = key.RetiredAt.UTC().Format(time.RFC3339)
+	}
+	if key.ExpiresAt != nil {
+		headers[pemHeaderExpiresAt] = key.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{
+		Type:    "PRIVATE KEY",
+		Headers: headers,
+		Bytes:   der,
+	}), nil
+}
+
+// decodeSigningKey PEM verisinden anahtarı okur
+func decodeSigningKey(data []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PRIVATE KEY" {
+		return nil, errors.New("geçerli bir PKCS#8 PEM bloğu bulunamadı")
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := parsed.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("desteklenmeyen anahtar tipi")
+	}
+
+	key := &SigningKey{
+		ID:         block.Headers[pemHeaderKeyID],
+		Algorithm:  block.Headers[pemHeaderAlgorithm],
+		PrivateKey: signer,
+	}
+	if key.ID == "" || key.Algorithm == "" {
+		return nil, errors.New("anahtar kimliği veya algoritması eksik")
+	}
+
+	if key.CreatedAt, err = time.Parse(time.RFC3339, block.Headers[pemHeaderCreatedAt]); err != nil {
+		return nil, fmt.Errorf("geçersiz oluşturulma zamanı: %w", err)
+	}
+	if key.RetiredAt, err = parseOptionalTime(block.Headers[pemHeaderRetiredAt]); err != nil {
+		return nil, err
+	}
+	if key.ExpiresAt, err = parseOptionalTime(block.Headers[pemHeaderExpiresAt]); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// parseOptionalTime boş olmayan RFC3339 zamanını çözümler
+func parseOptionalTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("geçersiz zaman değeri %q: %w", value, err)
+	}
+	return &t, nil
+}
+
+// JSONWebKey RFC 7517 açık anahtar gösterimi
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	// RSA anahtarları için
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Ed25519 anahtarları için
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+}
+
+// JSONWebKeySet JWKS belgesi
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// toJWK anahtarın açık bileşenini JWK olarak döndürür
+func (k *SigningKey) toJWK() (JSONWebKey, error) {
+	jwk := JSONWebKey{
+		KeyID:     k.ID,
+		Use:       "sig",
+		Algorithm: k.Algorithm,
+	}
+
+	switch pub := k.PublicKey().(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+	default:
+		return JSONWebKey{}, fmt.Errorf("desteklenmeyen açık anahtar tipi: %T", pub)
+	}
+
+	return jwk, nil
+}
+
+// sortKeysNewestFirst anahtarları en yeni önce olacak şekilde sıralar
+func sortKeysNewestFirst(keys []*SigningKey) {
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].CreatedAt.Equal(keys[j].CreatedAt) {
+			return strings.Compare(keys[i].ID, keys[j].ID) > 0
+		}
+		return keys[i].CreatedAt.After(keys[j].CreatedAt)
+	})
+}
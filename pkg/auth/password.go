@@ -1,6 +1,8 @@
 package auth
 
 import (
+	"sync"
+
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -20,3 +22,19 @@ func CheckPassword(hashedPassword, password string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
 	return err == nil
 }
+
+var (
+	dummyHashOnce sync.Once
+	dummyHash     []byte
+)
+
+// SimulatePasswordCheck bulunamayan kullanıcılar için gerçek bir karşılaştırma kadar süren sahte kontrol yapar
+//
+// Giriş yanıt süresinden kullanıcı adının kayıtlı olup olmadığı
+// anlaşılmasın diye kullanılır.
+func SimulatePasswordCheck(password string) {
+	dummyHashOnce.Do(func() {
+		dummyHash, _ = bcrypt.GenerateFromPassword([]byte("haber-dummy-password"), bcrypt.DefaultCost)
+	})
+	_ = bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+}
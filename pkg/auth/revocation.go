@@ -0,0 +1,135 @@
+package auth
+
+import (
+	"sync"
+	"time"
+)
+
+// RevocationStore iptal edilen token'ları tutan depo arayüzü
+//
+// Tek tek token'lar jti değeriyle, bir kullanıcının tüm token'ları ise
+// belirli bir andan önce üretilmiş olmalarına göre iptal edilir. Token'ların
+// iat değeri saniye hassasiyetinde olduğundan iptalle aynı saniyede üretilen
+// token'lar (ör. parola sıfırlamanın hemen ardından yapılan giriş) geçerli
+// sayılır; o saniyede iptal edilen oturumların token'ları ayrıca RevokeSession
+// ile iptal edilmelidir.
+type RevocationStore interface {
+	RevokeToken(jti string, expiresAt time.Time) error
+	IsTokenRevoked(jti string) (bool, error)
+	RevokeUser(userID uint, ttl time.Duration) error
+	IsUserRevoked(userID uint, issuedAt time.Time) (bool, error)
+}
+
+// sessionRevocationPrefix oturum iptallerinin token iptalleriyle çakışmaması için ön ek
+const sessionRevocationPrefix = "sid:"
+
+// RevokeSession oturuma ait tüm erişim token'larını ttl süresince iptal eder
+//
+// Erişim token'ları en fazla kendi ömürleri kadar geçerli kalabileceği için
+// ttl olarak erişim token süresi verilmelidir.
+func RevokeSession(store RevocationStore, sessionID string, ttl time.Duration) error {
+	return store.RevokeToken(sessionRevocationPrefix+sessionID, time.Now().Add(ttl))
+}
+
+// IsClaimsRevoked claim'lere ait token'ın iptal edilip edilmediğini kontrol eder
+func IsClaimsRevoked(store RevocationStore, claims *JWTCustomClaims) (bool, error) {
+	if claims.ID != "" {
+		revoked, err := store.IsTokenRevoked(claims.ID)
+		if err != nil || revoked {
+			return revoked, err
+		}
+	}
+
+	if claims.SessionID != "" {
+		revoked, err := store.IsTokenRevoked(sessionRevocationPrefix + claims.SessionID)
+		if err != nil || revoked {
+			return revoked, err
+		}
+	}
+
+	if claims.IssuedAt == nil {
+		return false, nil
+	}
+	return store.IsUserRevoked(claims.UserID, claims.IssuedAt.Time)
+}
+
+// userRevocation kullanıcı bazlı iptal kaydı
+type userRevocation struct {
+	revokedAt time.Time
+	expiresAt time.Time
+}
+
+// MemoryRevocationStore bellek içi iptal deposu (tek sunuculu kurulumlar ve testler için)
+type MemoryRevocationStore struct {
+	tokens map[string]time.Time
+	users  map[uint]userRevocation
+	mu     sync.RWMutex
+}
+
+// NewMemoryRevocationStore yeni bir MemoryRevocationStore oluşturur
+func NewMemoryRevocationStore() *MemoryRevocationStore {
+	return &MemoryRevocationStore{
+		tokens: make(map[string]time.Time),
+		users:  make(map[uint]userRevocation),
+	}
+}
+
+// RevokeToken token'ı süresi dolana kadar iptal listesine ekler
+func (s *MemoryRevocationStore) RevokeToken(jti string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.cleanup()
+	s.tokens[jti] = expiresAt
+	return nil
+}
+
+// IsTokenRevoked token'ın iptal edilip edilmediğini döndürür
+func (s *MemoryRevocationStore) IsTokenRevoked(jti string) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	expiresAt, ok := s.tokens[jti]
+	return ok && time.Now().Before(expiresAt), nil
+}
+
+// RevokeUser kullanıcının şu ana kadar üretilmiş tüm token'larını iptal eder
+func (s *MemoryRevocationStore) RevokeUser(userID uint, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.cleanup()
+	s.users[userID] = userRevocation{
+		revokedAt: now,
+		expiresAt: now.Add(ttl),
+	}
+	return nil
+}
+
+// IsUserRevoked verilen anda üretilmiş bir token'ın kullanıcı bazlı iptale takılıp takılmadığını döndürür
+func (s *MemoryRevocationStore) IsUserRevoked(userID uint, issuedAt time.Time) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	revocation, ok := s.users[userID]
+	if !ok || !time.Now().Before(revocation.expiresAt) {
+		return false, nil
+	}
+	return issuedAt.Before(revocation.revokedAt.Truncate(time.Second)), nil
+}
+
+// cleanup süresi dolmuş kayıtları temizler, çağıran kilidi tutmalıdır
+func (s *MemoryRevocationStore) cleanup() {
+	now := time.Now()
+	for jti, expiresAt := range s.tokens {
+		if !now.Before(expiresAt) {
+			delete(s.tokens, jti)
+		}
+	}
+	for userID, revocation := range s.users {
+		if !now.Before(revocation.expiresAt) {
+			delete(s.users, userID)
+		}
+	}
+}
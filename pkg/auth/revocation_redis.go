@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/username/haber/internal/config"
+)
+
+// RedisRevocationStore Redis tabanlı iptal deposu (birden çok sunucu için)
+type RedisRevocationStore struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisRevocationStore yeni bir RedisRevocationStore oluşturur
+func NewRedisRevocationStore(cfg config.IRedisConfig) (*RedisRevocationStore, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr:     cfg.GetRedisAddr(),
+		Password: cfg.GetPassword(),
+		DB:       cfg.GetDB(),
+	})
+
+	// Bağlantıyı kontrol et
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		return nil, err
+	}
+
+	return &RedisRevocationStore{
+		client: client,
+		prefix: "auth:revoked:",
+	}, nil
+}
+
+// RevokeToken token'ı süresi dolana kadar iptal listesine ekler
+func (s *RedisRevocationStore) RevokeToken(jti string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+
+	return s.client.Set(context.Background(), s.tokenKey(jti), 1, ttl).Err()
+}
+
+// IsTokenRevoked token'ın iptal edilip edilmediğini döndürür
+func (s *RedisRevocationStore) IsTokenRevoked(jti string) (bool, error) {
+	count, err := s.client.Exists(context.Background(), s.tokenKey(jti)).Result()
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// RevokeUser kullanıcının şu ana kadar üretilmiş tüm token'larını iptal eder
+func (s *RedisRevocationStore) RevokeUser(userID uint, ttl time.Duration) error {
+	revokedAt := strconv.FormatInt(time.Now().Unix(), 10)
+	return s.client.Set(context.Background(), s.userKey(userID), revokedAt, ttl).Err()
+}
+
+// IsUserRevoked verilen anda üretilmiş bir token'ın kullanıcı bazlı iptale takılıp takılmadığını döndürür
+func (s *RedisRevocationStore) IsUserRevoked(userID uint, issuedAt time.Time) (bool, error) {
+	value, err := s.client.Get(context.Background(), s.userKey(userID)).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	revokedAt, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return false, err
+	}
+	return issuedAt.Unix() < revokedAt, nil
+}
+
+// Close Redis bağlantısını kapatır
+func (s *RedisRevocationStore) Close() error {
+	return s.client.Close()
+}
+
+func (s *RedisRevocationStore) tokenKey(jti string) string {
+	return s.prefix + "jti:" + jti
+}
+
+func (s *RedisRevocationStore) userKey(userID uint) string {
+	return fmt.Sprintf("%suser:%d", s.prefix, userID)
+}
@@ -0,0 +1,344 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"strings"
+	"time"
+
+	"github.com/rwcarlsen/goexif/exif"
+)
+
+// Metadata görselin EXIF bilgilerinden okunan alanlar
+type Metadata struct {
+	CapturedAt  *time.Time
+	CameraMake  string
+	CameraModel string
+	Artist      string
+	Copyright   string
+	HasGPS      bool
+}
+
+// EXIF ve XMP yapılarında kullanılan sabitler
+const (
+	tiffTagGPSInfo = 0x8825
+	jpegMarkerAPP1 = 0xE1
+	jpegMarkerSOS  = 0xDA
+	jpegMarkerEOI  = 0xD9
+)
+
+var (
+	exifHeader   = []byte("Exif\x00\x00")
+	xmpHeader    = []byte("http://ns.adobe.com/xap/1.0/\x00")
+	xmpKeyword   = []byte("XML:com.adobe.xmp\x00")
+	xmpGPSMarker = []byte("GPS")
+	pngSignature = []byte("\x89PNG\r\n\x1a\n")
+)
+
+// tiffTypeSizes TIFF alan türlerinin bayt cinsinden boyutları
+var tiffTypeSizes = map[uint16]int64{
+	1: 1, 2: 1, 3: 2, 4: 4, 5: 8, 6: 1, 7: 1, 8: 2, 9: 4, 10: 8, 11: 4, 12: 8,
+}
+
+// ReadMetadata JPEG veya PNG görselin EXIF bilgilerini okur
+//
+// EXIF bulunmayan görseller için boş Metadata döner; bozuk EXIF yüklemeyi
+// engellemez.
+func ReadMetadata(data []byte, format string) *Metadata {
+	meta := &Metadata{}
+
+	var raw []byte
+	switch format {
+	case "jpeg":
+		raw = data
+	case "png":
+		raw = pngExifChunk(data)
+	}
+	if raw == nil {
+		return meta
+	}
+
+	x, err := exif.Decode(bytes.NewReader(raw))
+	if err != nil || x == nil {
+		return meta
+	}
+
+	if t, err := x.DateTime(); err == nil && !t.IsZero() {
+		meta.CapturedAt = &t
+	}
+	meta.CameraMake = exifString(x, exif.Make)
+	meta.CameraModel = exifString(x, exif.Model)
+	meta.Artist = exifString(x, exif.Artist)
+	meta.Copyright = exifString(x, exif.Copyright)
+	if _, _, err := x.LatLong(); err == nil {
+		meta.HasGPS = true
+	}
+
+	return meta
+}
+
+// StripGPS görseldeki konum bilgilerini kaldırır ve değişiklik yapılıp
+// yapılmadığını döner
+//
+// EXIF içindeki GPS dizini boşaltılır, konum içeren XMP blokları atılır.
+// Yön (orientation), tarih ve telif gibi diğer alanlar korunur.
+func StripGPS(data []byte, format string) ([]byte, bool) {
+	switch format {
+	case "jpeg":
+		return stripJPEGGPS(data)
+	case "png":
+		return stripPNGGPS(data)
+	}
+	return data, false
+}
+
+// exifString EXIF alanını boşlukları kırpılmış metin olarak döner
+func exifString(x *exif.Exif, name exif.FieldName) string {
+	tag, err := x.Get(name)
+	if err != nil {
+		return ""
+	}
+	value, err := tag.StringVal()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(strings.Trim(value, "\x00"))
+}
+
+// stripJPEGGPS JPEG segmentlerini dolaşarak APP1 EXIF/XMP bloklarını temizler
+func stripJPEGGPS(data []byte) ([]byte, bool) {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return data, false
+	}
+
+	out := make([]byte, 0, len(data))
+	out = append(out, data[:2]...)
+	changed := false
+	pos := 2
+
+	for pos+4 <= len(data) {
+		if data[pos] != 0xFF {
+			break
+		}
+		marker := data[pos+1]
+
+		// Görüntü verisi başladıktan sonra meta veri segmenti bulunmaz
+		if marker == jpegMarkerSOS || marker == jpegMarkerEOI {
+			break
+		}
+		// Dolgu baytları ve uzunluğu olmayan işaretler
+		if marker == 0xFF {
+			out = append(out, data[pos])
+			pos++
+			continue
+		}
+		if marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7) {
+			out = append(out, data[pos:pos+2]...)
+			pos += 2
+			continue
+		}
+
+		length := int(binary.BigEndian.Uint16(data[pos+2:]))
+		end := pos + 2 + length
+		if length < 2 || end > len(data) {
+			break
+		}
+		segment := data[pos:end]
+
+		if marker == jpegMarkerAPP1 {
+			payload := segment[4:]
+			switch {
+			case bytes.HasPrefix(payload, exifHeader):
+				cleaned := append([]byte(nil), segment...)
+				if scrubTIFFGPS(cleaned[4+len(exifHeader):]) {
+					segment = cleaned
+					changed = true
+				}
+			case bytes.HasPrefix(payload, xmpHeader) && bytes.Contains(payload, xmpGPSMarker):
+				pos = end
+				changed = true
+				continue
+			}
+		}
+
+		out = append(out, segment...)
+		pos = end
+	}
+
+	out = append(out, data[pos:]...)
+	if !changed {
+		return data, false
+	}
+	return out, true
+}
+
+// stripPNGGPS PNG parçalarını dolaşarak eXIf parçasını temizler ve konum
+// içeren XMP metin parçalarını atar
+func stripPNGGPS(data []byte) ([]byte, bool) {
+	if !bytes.HasPrefix(data, pngSignature) {
+		return data, false
+	}
+
+	out := make([]byte, 0, len(data))
+	out = append(out, pngSignature...)
+	changed := false
+	pos := len(pngSignature)
+
+	for pos+12 <= len(data) {
+		length := int(binary.BigEndian.Uint32(data[pos:]))
+		end := pos + 12 + length
+		if length < 0 || end > len(data) {
+			break
+		}
+		chunkType := string(data[pos+4 : pos+8])
+		chunkData := data[pos+8 : pos+8+length]
+
+		switch {
+		case chunkType == "eXIf":
+			cleaned := append([]byte(nil), chunkData...)
+			if scrubTIFFGPS(cleaned) {
+				out = appendPNGChunk(out, chunkType, cleaned)
+				changed = true
+				pos = end
+				continue
+			}
+		case pngXMPMayHaveGPS(chunkType, chunkData):
+			changed = true
+			pos = end
+			continue
+		}
+
+		out = append(out, data[pos:end]...)
+		pos = end
+	}
+
+	out = append(out, data[pos:]...)
+	if !changed {
+		return data, false
+	}
+	return out, true
+}
+
+// pngXMPMayHaveGPS XMP taşıyan PNG metin parçasının konum bilgisi içerip
+// içeremeyeceğini bildirir
+//
+// Sıkıştırılmış XMP içeriği denetlenmediğinden konum içerdiği varsayılır.
+func pngXMPMayHaveGPS(chunkType string, chunkData []byte) bool {
+	if !bytes.HasPrefix(chunkData, xmpKeyword) {
+		return false
+	}
+	switch chunkType {
+	case "tEXt":
+		return bytes.Contains(chunkData, xmpGPSMarker)
+	case "zTXt":
+		return true
+	case "iTXt":
+		compressed := len(chunkData) > len(xmpKeyword) && chunkData[len(xmpKeyword)] != 0
+		return compressed || bytes.Contains(chunkData, xmpGPSMarker)
+	}
+	return false
+}
+
+// pngExifChunk PNG içindeki eXIf parçasının verisini döner
+func pngExifChunk(data []byte) []byte {
+	if !bytes.HasPrefix(data, pngSignature) {
+		return nil
+	}
+
+	pos := len(pngSignature)
+	for pos+12 <= len(data) {
+		length := int(binary.BigEndian.Uint32(data[pos:]))
+		end := pos + 12 + length
+		if length < 0 || end > len(data) {
+			return nil
+		}
+		if string(data[pos+4:pos+8]) == "eXIf" {
+			return data[pos+8 : pos+8+length]
+		}
+		pos = end
+	}
+	return nil
+}
+
+// appendPNGChunk PNG parçasını uzunluk ve CRC ile birlikte ekler
+func appendPNGChunk(out []byte, chunkType string, chunkData []byte) []byte {
+	var header [8]byte
+	binary.BigEndian.PutUint32(header[:4], uint32(len(chunkData)))
+	copy(header[4:], chunkType)
+	out = append(out, header[:]...)
+	out = append(out, chunkData...)
+
+	crc := crc32.NewIEEE()
+	crc.Write(header[4:])
+	crc.Write(chunkData)
+	return binary.BigEndian.AppendUint32(out, crc.Sum32())
+}
+
+// scrubTIFFGPS TIFF yapısındaki GPS dizinini yerinde boşaltır
+//
+// Ofsetlerin bozulmaması için veri silinmez; GPS alanları ve değerleri
+// sıfırlanır ve dizinin alan sayısı sıfıra çekilir.
+func scrubTIFFGPS(tiff []byte) bool {
+	if len(tiff) < 8 {
+		return false
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return false
+	}
+
+	size := int64(len(tiff))
+	ifd0 := int64(order.Uint32(tiff[4:8]))
+	if ifd0+2 > size {
+		return false
+	}
+
+	count := int64(order.Uint16(tiff[ifd0:]))
+	for i := int64(0); i < count; i++ {
+		entry := ifd0 + 2 + i*12
+		if entry+12 > size {
+			return false
+		}
+		if order.Uint16(tiff[entry:]) != tiffTagGPSInfo {
+			continue
+		}
+		return scrubTIFFDir(tiff, int64(order.Uint32(tiff[entry+8:])), order)
+	}
+	return false
+}
+
+// scrubTIFFDir verilen TIFF dizinindeki tüm alanları ve değerlerini sıfırlar
+func scrubTIFFDir(tiff []byte, offset int64, order binary.ByteOrder) bool {
+	size := int64(len(tiff))
+	if offset <= 0 || offset+2 > size {
+		return false
+	}
+
+	count := int64(order.Uint16(tiff[offset:]))
+	for i := int64(0); i < count; i++ {
+		entry := offset + 2 + i*12
+		if entry+12 > size {
+			break
+		}
+
+		// 4 bayttan büyük değerler dizinin dışında tutulur
+		valueSize := tiffTypeSizes[order.Uint16(tiff[entry+2:])] * int64(order.Uint32(tiff[entry+4:]))
+		if valueSize > 4 {
+			valueOffset := int64(order.Uint32(tiff[entry+8:]))
+			if valueOffset > 0 && valueOffset+valueSize <= size {
+				clear(tiff[valueOffset : valueOffset+valueSize])
+			}
+		}
+		clear(tiff[entry : entry+12])
+	}
+
+	order.PutUint16(tiff[offset:], 0)
+	return true
+}
@@ -0,0 +1,150 @@
+// Package imaging görsel türevleri (küçük resim, kart, manşet vb.) üretmek
+// için saf Go ile çözme, yeniden boyutlandırma ve kodlama işlevleri sağlar.
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif" // GIF çözücüsünü image.Decode için kaydeder
+	"image/jpeg"
+	"image/png"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/image/draw"
+)
+
+// DefaultJPEGQuality yapılandırılmadığında kullanılan JPEG kalitesi
+const DefaultJPEGQuality = 82
+
+// ErrTooManyPixels görselin piksel sayısı izin verilen sınırı aştığında döner
+var ErrTooManyPixels = errors.New("görsel boyutları izin verilen sınırı aşıyor")
+
+// Preset aynı amaçla kullanılan türevlerin adı ve genişlikleri (ör. card: 480, 960)
+type Preset struct {
+	Name   string
+	Widths []int
+}
+
+// ParsePresets "ad:genişlik,genişlik;ad:genişlik" biçimindeki tanımı çözümler
+//
+// Örnek: "thumbnail:150,300;card:480,960;hero:1280,1920"
+func ParsePresets(spec string) ([]Preset, error) {
+	presets := make([]Preset, 0)
+	seen := make(map[string]bool)
+
+	for _, part := range strings.Split(spec, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		name, widthList, ok := strings.Cut(part, ":")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !ok || name == "" {
+			return nil, fmt.Errorf("geçersiz türev tanımı: %q", part)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("türev adı birden fazla tanımlanmış: %s", name)
+		}
+		seen[name] = true
+
+		preset := Preset{Name: name}
+		for _, value := range strings.Split(widthList, ",") {
+			width, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil || width <= 0 {
+				return nil, fmt.Errorf("geçersiz türev genişliği: %s:%s", name, value)
+			}
+			preset.Widths = append(preset.Widths, width)
+		}
+		sort.Ints(preset.Widths)
+		presets = append(presets, preset)
+	}
+
+	return presets, nil
+}
+
+// SupportedContentTypes türev üretilebilen görsel içerik türleri
+var SupportedContentTypes = []string{"image/jpeg", "image/png", "image/gif"}
+
+// IsSupported içerik türünün türev üretimi için desteklenip desteklenmediğini döner
+func IsSupported(contentType string) bool {
+	contentType = strings.ToLower(contentType)
+	for _, supported := range SupportedContentTypes {
+		if contentType == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// DecodeConfig görselin yalnızca başlığını okuyarak boyutlarını ve biçimini
+// döner; piksel sınırını aşan görseller için ErrTooManyPixels döner
+//
+// maxPixels sıfır veya negatifse sınır uygulanmaz.
+func DecodeConfig(r io.Reader, maxPixels int64) (image.Config, string, error) {
+	cfg, format, err := image.DecodeConfig(r)
+	if err != nil {
+		return cfg, format, err
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return cfg, format, fmt.Errorf("geçersiz görsel boyutları: %dx%d", cfg.Width, cfg.Height)
+	}
+	if maxPixels > 0 && int64(cfg.Width)*int64(cfg.Height) > maxPixels {
+		return cfg, format, ErrTooManyPixels
+	}
+	return cfg, format, nil
+}
+
+// Decode JPEG, PNG veya GIF görseli çözer; GIF'lerde ilk kare kullanılır
+//
+// Piksel verisi çözülmeden önce başlıktaki boyutlar maxPixels ile
+// karşılaştırılır; böylece sıkıştırma bombaları belleği tüketemez.
+func Decode(r io.Reader, maxPixels int64) (image.Image, string, error) {
+	// Başlık okunurken tüketilen baytlar asıl çözümde yeniden kullanılır
+	var header bytes.Buffer
+	if _, _, err := DecodeConfig(io.TeeReader(r, &header), maxPixels); err != nil {
+		return nil, "", err
+	}
+	return image.Decode(io.MultiReader(&header, r))
+}
+
+// Resize görseli en-boy oranını koruyarak verilen genişliğe ölçekler
+func Resize(img image.Image, width int) image.Image {
+	bounds := img.Bounds()
+	height := bounds.Dy() * width / bounds.Dx()
+	if height < 1 {
+		height = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Src, nil)
+	return dst
+}
+
+// Encode görseli kaynak biçimine uygun şekilde kodlar ve içerik türü ile
+// dosya uzantısını döner
+//
+// JPEG kaynaklar JPEG olarak, PNG ve GIF kaynaklar saydamlığı korumak için
+// PNG olarak kodlanır.
+func Encode(w io.Writer, img image.Image, format string, quality int) (contentType, ext string, err error) {
+	if format == "jpeg" {
+		if quality < 1 || quality > 100 {
+			quality = DefaultJPEGQuality
+		}
+		if err := jpeg.Encode(w, img, &jpeg.Options{Quality: quality}); err != nil {
+			return "", "", err
+		}
+		return "image/jpeg", ".jpg", nil
+	}
+
+	encoder := png.Encoder{CompressionLevel: png.BestSpeed}
+	if err := encoder.Encode(w, img); err != nil {
+		return "", "", err
+	}
+	return "image/png", ".png", nil
+}
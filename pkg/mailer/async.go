@@ -0,0 +1,136 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+)
+
+// ErrQueueFull kuyruk dolu olduğunda döndürülen hata
+var ErrQueueFull = errors.New("e-posta kuyruğu dolu")
+
+// ErrMailerClosed kapatılmış kuyruğa mesaj eklenmeye çalışıldığında döndürülen hata
+var ErrMailerClosed = errors.New("e-posta kuyruğu kapatıldı")
+
+// AsyncConfig asenkron gönderim ayarları
+type AsyncConfig struct {
+	Workers    int
+	QueueSize  int
+	MaxRetries int
+	BaseDelay  time.Duration
+	Timeout    time.Duration
+}
+
+// AsyncMailer mesajları kuyruğa alır ve arka planda yeniden deneyerek gönderir
+type AsyncMailer struct {
+	inner  Mailer
+	cfg    AsyncConfig
+	queue  chan *Message
+	wg     sync.WaitGroup
+	mu     sync.RWMutex
+	closed bool
+}
+
+// NewAsyncMailer yeni bir AsyncMailer oluşturur ve işçileri başlatır
+func NewAsyncMailer(inner Mailer, cfg AsyncConfig) *AsyncMailer {
+	if cfg.Workers < 1 {
+		cfg.Workers = 2
+	}
+	if cfg.QueueSize < 1 {
+		cfg.QueueSize = 100
+	}
+	if cfg.MaxRetries < 1 {
+		cfg.MaxRetries = 5
+	}
+	if cfg.BaseDelay == 0 {
+		cfg.BaseDelay = 2 * time.Second
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 30 * time.Second
+	}
+
+	m := &AsyncMailer{
+		inner: inner,
+		cfg:   cfg,
+		queue: make(chan *Message, cfg.QueueSize),
+	}
+
+	for i := 0; i < cfg.Workers; i++ {
+		m.wg.Add(1)
+		go m.worker()
+	}
+
+	return m
+}
+
+// Send mesajı doğrular ve gönderim kuyruğuna ekler
+func (m *AsyncMailer) Send(ctx context.Context, msg *Message) error {
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.closed {
+		return ErrMailerClosed
+	}
+
+	select {
+	case m.queue <- msg:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Close yeni mesaj kabulünü durdurur ve kuyruktaki mesajların gönderilmesini bekler
+func (m *AsyncMailer) Close() {
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		return
+	}
+	m.closed = true
+	close(m.queue)
+	m.mu.Unlock()
+
+	m.wg.Wait()
+}
+
+// worker kuyruktaki mesajları gönderir
+func (m *AsyncMailer) worker() {
+	defer m.wg.Done()
+
+	for msg := range m.queue {
+		if err := m.sendWithRetry(msg); err != nil {
+			log.Printf("E-posta gönderilemedi (%v): %v", msg.To, err)
+		}
+	}
+}
+
+// sendWithRetry mesajı üstel bekleme ile yeniden deneyerek gönderir
+func (m *AsyncMailer) sendWithRetry(msg *Message) error {
+	var err error
+	delay := m.cfg.BaseDelay
+
+	for attempt := 1; attempt <= m.cfg.MaxRetries; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), m.cfg.Timeout)
+		err = m.inner.Send(ctx, msg)
+		cancel()
+		if err == nil {
+			return nil
+		}
+
+		if attempt < m.cfg.MaxRetries {
+			log.Printf("E-posta gönderimi başarısız, %s sonra tekrar denenecek (%d/%d): %v", delay, attempt, m.cfg.MaxRetries, err)
+			time.Sleep(delay)
+			delay *= 2
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,58 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileMailer e-postaları göndermek yerine .eml dosyası olarak diske yazar
+//
+// Geliştirme ortamında ve testlerde gönderilen postaları incelemek için
+// kullanılır.
+type FileMailer struct {
+	dir  string
+	from string
+}
+
+// NewFileMailer yeni bir FileMailer oluşturur
+func NewFileMailer(dir, from string) (*FileMailer, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileMailer{dir: dir, from: from}, nil
+}
+
+// Send mesajı outbox dizinine yazar
+func (m *FileMailer) Send(ctx context.Context, msg *Message) error {
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if msg.From == "" {
+		msg.From = m.from
+	}
+
+	body, err := msg.Bytes()
+	if err != nil {
+		return err
+	}
+
+	suffix, err := randomBoundary()
+	if err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%s-%s.eml", time.Now().Format("20060102-150405"), suffix[:8])
+
+	return os.WriteFile(filepath.Join(m.dir, name), body, 0o644)
+}
+
+// Dir outbox dizinini döndürür
+func (m *FileMailer) Dir() string {
+	return m.dir
+}
@@ -0,0 +1,110 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+// ErrNoRecipients alıcı belirtilmediğinde döndürülen hata
+var ErrNoRecipients = errors.New("e-posta için en az bir alıcı gereklidir")
+
+// Message gönderilecek e-posta mesajı
+type Message struct {
+	From     string
+	To       []string
+	Subject  string
+	HTMLBody string
+	TextBody string
+}
+
+// Mailer e-posta gönderim arayüzü
+type Mailer interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// Validate mesajın gönderilebilir olup olmadığını kontrol eder
+func (m *Message) Validate() error {
+	if len(m.To) == 0 {
+		return ErrNoRecipients
+	}
+	for _, to := range m.To {
+		if _, err := mail.ParseAddress(to); err != nil {
+			return fmt.Errorf("geçersiz alıcı adresi %q: %w", to, err)
+		}
+	}
+	if m.HTMLBody == "" && m.TextBody == "" {
+		return errors.New("e-posta gövdesi boş olamaz")
+	}
+	return nil
+}
+
+// Bytes mesajı RFC 5322 biçiminde multipart/alternative olarak serileştirir
+func (m *Message) Bytes() ([]byte, error) {
+	boundary, err := randomBoundary()
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	writeHeader(&buf, "From", m.From)
+	writeHeader(&buf, "To", strings.Join(m.To, ", "))
+	writeHeader(&buf, "Subject", mime.QEncoding.Encode("utf-8", m.Subject))
+	writeHeader(&buf, "Date", time.Now().Format(time.RFC1123Z))
+	writeHeader(&buf, "MIME-Version", "1.0")
+	writeHeader(&buf, "Content-Type", fmt.Sprintf("multipart/alternative; boundary=%q", boundary))
+	buf.WriteString("\r\n")
+
+	// Düz metin önce, HTML sonra gelir; istemciler son desteklenen parçayı gösterir
+	if m.TextBody != "" {
+		if err := writePart(&buf, boundary, "text/plain; charset=utf-8", m.TextBody); err != nil {
+			return nil, err
+		}
+	}
+	if m.HTMLBody != "" {
+		if err := writePart(&buf, boundary, "text/html; charset=utf-8", m.HTMLBody); err != nil {
+			return nil, err
+		}
+	}
+	fmt.Fprintf(&buf, "--%s--\r\n", boundary)
+
+	return buf.Bytes(), nil
+}
+
+func writeHeader(buf *bytes.Buffer, key, value string) {
+	// Başlık enjeksiyonuna karşı satır sonlarını temizle
+	value = strings.NewReplacer("\r", "", "\n", "").Replace(value)
+	fmt.Fprintf(buf, "%s: %s\r\n", key, value)
+}
+
+func writePart(buf *bytes.Buffer, boundary, contentType, body string) error {
+	fmt.Fprintf(buf, "--%s\r\n", boundary)
+	fmt.Fprintf(buf, "Content-Type: %s\r\n", contentType)
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+	qp := quotedprintable.NewWriter(buf)
+	if _, err := qp.Write([]byte(body)); err != nil {
+		return err
+	}
+	if err := qp.Close(); err != nil {
+		return err
+	}
+	buf.WriteString("\r\n")
+	return nil
+}
+
+func randomBoundary() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,145 @@
+package mailer
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// SMTP şifreleme türleri
+const (
+	EncryptionNone     = "none"
+	EncryptionSTARTTLS = "starttls"
+	EncryptionSSL      = "ssl"
+)
+
+// SMTPConfig SMTP sunucu ayarları
+type SMTPConfig struct {
+	Host       string
+	Port       int
+	Username   string
+	Password   string
+	Encryption string
+	FromEmail  string
+	FromName   string
+	Timeout    time.Duration
+}
+
+// SMTPMailer SMTP üzerinden e-posta gönderir
+type SMTPMailer struct {
+	cfg SMTPConfig
+}
+
+// NewSMTPMailer yeni bir SMTPMailer oluşturur
+func NewSMTPMailer(cfg SMTPConfig) *SMTPMailer {
+	if cfg.Port == 0 {
+		cfg.Port = 587
+	}
+	if cfg.Encryption == "" {
+		cfg.Encryption = EncryptionSTARTTLS
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 30 * time.Second
+	}
+	return &SMTPMailer{cfg: cfg}
+}
+
+// Send mesajı SMTP sunucusuna iletir
+func (m *SMTPMailer) Send(ctx context.Context, msg *Message) error {
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+
+	if msg.From == "" {
+		from := mail.Address{Name: m.cfg.FromName, Address: m.cfg.FromEmail}
+		msg.From = from.String()
+	}
+
+	body, err := msg.Bytes()
+	if err != nil {
+		return err
+	}
+
+	client, err := m.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if m.cfg.Username != "" {
+		auth := smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("SMTP kimlik doğrulama hatası: %w", err)
+		}
+	}
+
+	if err := client.Mail(m.cfg.FromEmail); err != nil {
+		return err
+	}
+	for _, to := range msg.To {
+		addr, err := mail.ParseAddress(to)
+		if err != nil {
+			return err
+		}
+		if err := client.Rcpt(addr.Address); err != nil {
+			return err
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(body); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+// dial yapılandırmaya göre SMTP bağlantısı kurar
+func (m *SMTPMailer) dial(ctx context.Context) (*smtp.Client, error) {
+	addr := net.JoinHostPort(m.cfg.Host, strconv.Itoa(m.cfg.Port))
+	dialer := &net.Dialer{Timeout: m.cfg.Timeout}
+	tlsConfig := &tls.Config{ServerName: m.cfg.Host}
+
+	var conn net.Conn
+	var err error
+	if m.cfg.Encryption == EncryptionSSL {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: tlsConfig}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("SMTP sunucusuna bağlanılamadı: %w", err)
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	} else {
+		_ = conn.SetDeadline(time.Now().Add(m.cfg.Timeout))
+	}
+
+	client, err := smtp.NewClient(conn, m.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if m.cfg.Encryption == EncryptionSTARTTLS {
+		if err := client.StartTLS(tlsConfig); err != nil {
+			client.Close()
+			return nil, fmt.Errorf("STARTTLS başlatılamadı: %w", err)
+		}
+	}
+
+	return client, nil
+}
@@ -0,0 +1,94 @@
+package mailer
+
+import (
+	"bytes"
+	"errors"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"strings"
+	texttemplate "text/template"
+)
+
+// Renderer dile göre HTML ve düz metin e-posta şablonlarını işler
+//
+// Şablonlar "<dil>/<ad>.html" ve "<dil>/<ad>.txt" yollarında aranır. Konu
+// satırı düz metin şablonundaki {{define "subject"}} bloğundan alınır. İstenen
+// dilde şablon yoksa varsayılan dile düşülür.
+type Renderer struct {
+	fsys          fs.FS
+	defaultLocale string
+}
+
+// NewRenderer yeni bir Renderer oluşturur
+func NewRenderer(fsys fs.FS, defaultLocale string) *Renderer {
+	return &Renderer{
+		fsys:          fsys,
+		defaultLocale: defaultLocale,
+	}
+}
+
+// Render şablonu işler ve alıcısı boş bir mesaj döndürür
+func (r *Renderer) Render(locale, name string, data interface{}) (*Message, error) {
+	locale = r.resolveLocale(locale, name)
+
+	textSrc, err := fs.ReadFile(r.fsys, path.Join(locale, name+".txt"))
+	if err != nil {
+		return nil, err
+	}
+
+	textTmpl, err := texttemplate.New(name).Parse(string(textSrc))
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &Message{}
+
+	// Konu satırı
+	if textTmpl.Lookup("subject") == nil {
+		return nil, errors.New("şablonda subject bloğu tanımlı değil: " + name)
+	}
+	var subject bytes.Buffer
+	if err := textTmpl.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, err
+	}
+	msg.Subject = strings.TrimSpace(subject.String())
+
+	// Düz metin gövde
+	var text bytes.Buffer
+	if err := textTmpl.Execute(&text, data); err != nil {
+		return nil, err
+	}
+	msg.TextBody = strings.TrimSpace(text.String())
+
+	// HTML gövde isteğe bağlıdır
+	htmlSrc, err := fs.ReadFile(r.fsys, path.Join(locale, name+".html"))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return msg, nil
+		}
+		return nil, err
+	}
+
+	htmlTmpl, err := htmltemplate.New(name).Parse(string(htmlSrc))
+	if err != nil {
+		return nil, err
+	}
+	var html bytes.Buffer
+	if err := htmlTmpl.Execute(&html, data); err != nil {
+		return nil, err
+	}
+	msg.HTMLBody = html.String()
+
+	return msg, nil
+}
+
+// resolveLocale şablonun bulunduğu dili seçer
+func (r *Renderer) resolveLocale(locale, name string) string {
+	if locale != "" {
+		if _, err := fs.Stat(r.fsys, path.Join(locale, name+".txt")); err == nil {
+			return locale
+		}
+	}
+	return r.defaultLocale
+}
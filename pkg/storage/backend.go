@@ -0,0 +1,133 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/username/haber/internal/config"
+)
+
+// Desteklenen depolama sürücüleri
+const (
+	DriverLocal = "local"
+	DriverMinio = "minio"
+)
+
+var (
+	// ErrNotFound istenen nesne depoda bulunamadığında döner
+	ErrNotFound = errors.New("nesne bulunamadı")
+	// ErrInvalidKey nesne anahtarı geçersiz olduğunda döner
+	ErrInvalidKey = errors.New("geçersiz nesne anahtarı")
+	// ErrInvalidRange istenen bayt aralığı nesnenin dışında kaldığında döner
+	ErrInvalidRange = errors.New("geçersiz bayt aralığı")
+)
+
+// ObjectInfo depodaki bir nesnenin bilgileri
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	ContentType  string
+	ETag         string
+	LastModified time.Time
+}
+
+// Range okunacak bayt aralığı
+//
+// Length sıfırdan küçükse nesnenin sonuna kadar okunur.
+type Range struct {
+	Offset int64
+	Length int64
+}
+
+// Backend medya dosyalarının tutulduğu depolama arayüzü
+//
+// Anahtarlar "/" ile ayrılmış göreli yollardır (ör. "general/2024/01/31/abc.jpg");
+// uygulamanın geri kalanı dosyaların diskte mi yoksa nesne deposunda mı
+// tutulduğunu bilmez.
+type Backend interface {
+	// Put nesneyi yazar; size bilinmiyorsa -1 verilebilir
+	Put(key string, r io.Reader, size int64, contentType string) (*ObjectInfo, error)
+	// Get nesneyi okur; rng nil ise nesnenin tamamı döner. Dönen bilgideki
+	// Size her zaman nesnenin toplam boyutudur.
+	Get(key string, rng *Range) (io.ReadCloser, *ObjectInfo, error)
+	// Stat nesnenin bilgilerini döner
+	Stat(key string) (*ObjectInfo, error)
+	// Delete nesneyi siler; nesne yoksa hata dönmez
+	Delete(key string) error
+	// List öneki uyan tüm nesneleri listeler
+	List(prefix string) ([]ObjectInfo, error)
+	// URL nesnenin doğrudan indirilebileceği adresi döner; depo doğrudan
+	// erişime kapalıysa boş döner ve dosya API üzerinden sunulur
+	URL(key string, expires time.Duration) (string, error)
+}
+
+// PresignedUploader istemcilerin dosyaları sunucudan geçirmeden doğrudan
+// yükleyebildiği depolar
+//
+// Yerel disk bu arayüzü uygulamaz; kullanımdan önce tür kontrolü yapılmalıdır.
+type PresignedUploader interface {
+	// PresignPut nesnenin expires süresince PUT ile yüklenebileceği imzalı adresi döner
+	PresignPut(key string, expires time.Duration) (string, error)
+}
+
+// NewBackendFromConfig yapılandırmada seçilen sürücüye göre depolama oluşturur
+func NewBackendFromConfig(cfg config.IConfig) (Backend, error) {
+	storageCfg := cfg.GetStorage()
+
+	switch driver := strings.ToLower(storageCfg.GetDriver()); driver {
+	case "", DriverLocal:
+		return NewLocalBackend(cfg.GetServer().GetUploadsDir(), storageCfg.GetPublicURL())
+	case DriverMinio, "s3":
+		minioCfg := cfg.GetMinIO()
+		return NewMinioBackend(&MinioConfig{
+			Endpoint:        minioCfg.GetEndpoint(),
+			AccessKeyID:     minioCfg.GetAccessKeyID(),
+			SecretAccessKey: minioCfg.GetSecretAccessKey(),
+			UseSSL:          minioCfg.GetUseSSL(),
+			BucketName:      minioCfg.GetBucketName(),
+			Location:        minioCfg.GetLocation(),
+		}, storageCfg.GetPublicURL())
+	default:
+		return nil, fmt.Errorf("bilinmeyen depolama sürücüsü: %s", driver)
+	}
+}
+
+// ValidateKey nesne anahtarının depo kökünün dışına çıkamayacağını doğrular
+func ValidateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return ErrInvalidKey
+	}
+	if path.Clean(key) != key {
+		return ErrInvalidKey
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "." || part == ".." {
+			return ErrInvalidKey
+		}
+	}
+	return nil
+}
+
+// publicObjectURL herkese açık kök adres ile nesne anahtarını birleştirir
+func publicObjectURL(publicURL, key string) string {
+	return strings.TrimRight(publicURL, "/") + "/" + key
+}
+
+// validateRange aralığı nesne boyutuna göre doğrular ve okunacak uzunluğu döner
+func validateRange(rng *Range, size int64) (int64, error) {
+	if rng.Offset < 0 || rng.Offset >= size {
+		return 0, ErrInvalidRange
+	}
+	length := rng.Length
+	if length < 0 || rng.Offset+length > size {
+		length = size - rng.Offset
+	}
+	if length == 0 {
+		return 0, ErrInvalidRange
+	}
+	return length, nil
+}
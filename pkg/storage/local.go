@@ -0,0 +1,253 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// localTempPrefix yazımı süren geçici dosyaların ön eki
+const localTempPrefix = ".upload-"
+
+// LocalBackend dosyaları yerel dosya sisteminde saklayan depolama
+type LocalBackend struct {
+	root      string
+	publicURL string
+}
+
+// NewLocalBackend yeni bir LocalBackend oluşturur
+//
+// publicURL boşsa dosyalar doğrudan sunulmaz ve URL boş döner.
+func NewLocalBackend(root, publicURL string) (*LocalBackend, error) {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(absRoot, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &LocalBackend{
+		root:      absRoot,
+		publicURL: publicURL,
+	}, nil
+}
+
+// Put dosyayı önce geçici bir dosyaya yazar, ardından yerine taşır; böylece
+// yarım kalan yüklemeler okuyuculara görünmez
+func (b *LocalBackend) Put(key string, r io.Reader, size int64, contentType string) (*ObjectInfo, error) {
+	filePath, err := b.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Dir(filePath)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	tmp, err := os.CreateTemp(dir, localTempPrefix+"*")
+	if err != nil {
+		return nil, err
+	}
+	// Taşıma başarılı olduktan sonra Remove hata döner ve yok sayılır
+	defer os.Remove(tmp.Name())
+
+	written, err := io.Copy(tmp, r)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	if size >= 0 && written != size {
+		return nil, fmt.Errorf("eksik yazım: %d/%d bayt", written, size)
+	}
+
+	if err := os.Rename(tmp.Name(), filePath); err != nil {
+		return nil, err
+	}
+
+	info, err := b.Stat(key)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		info.ContentType = contentType
+	}
+	return info, nil
+}
+
+// Get dosyayı okur
+func (b *LocalBackend) Get(key string, rng *Range) (io.ReadCloser, *ObjectInfo, error) {
+	filePath, err := b.path(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, nil, mapLocalError(err)
+	}
+
+	stat, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+	info := b.objectInfo(key, stat)
+
+	if rng == nil {
+		return file, info, nil
+	}
+
+	length, err := validateRange(rng, info.Size)
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+	if _, err := file.Seek(rng.Offset, io.SeekStart); err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+
+	return &limitedReadCloser{
+		Reader: io.LimitReader(file, length),
+		Closer: file,
+	}, info, nil
+}
+
+// Stat dosyanın bilgilerini döner
+func (b *LocalBackend) Stat(key string) (*ObjectInfo, error) {
+	filePath, err := b.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	stat, err := os.Stat(filePath)
+	if err != nil {
+		return nil, mapLocalError(err)
+	}
+	if stat.IsDir() {
+		return nil, ErrNotFound
+	}
+
+	return b.objectInfo(key, stat), nil
+}
+
+// Delete dosyayı siler
+func (b *LocalBackend) Delete(key string) error {
+	filePath, err := b.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// List öneki uyan dosyaları listeler
+func (b *LocalBackend) List(prefix string) ([]ObjectInfo, error) {
+	// Taramayı önekin bulunduğu en derin klasörden başlat
+	start := b.root
+	if i := strings.LastIndex(prefix, "/"); i > 0 {
+		dir := prefix[:i]
+		if err := ValidateKey(dir); err != nil {
+			return nil, err
+		}
+		start = filepath.Join(b.root, filepath.FromSlash(dir))
+	}
+
+	objects := make([]ObjectInfo, 0)
+	err := filepath.WalkDir(start, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && filePath == start {
+				return fs.SkipAll
+			}
+			return err
+		}
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), localTempPrefix) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(b.root, filePath)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+
+		stat, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, *b.objectInfo(key, stat))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return objects, nil
+}
+
+// URL dosyanın herkese açık adresini döner
+func (b *LocalBackend) URL(key string, expires time.Duration) (string, error) {
+	if err := ValidateKey(key); err != nil {
+		return "", err
+	}
+	if b.publicURL == "" {
+		return "", nil
+	}
+	return publicObjectURL(b.publicURL, key), nil
+}
+
+// path anahtarı depo kökü altındaki dosya yoluna çevirir
+func (b *LocalBackend) path(key string) (string, error) {
+	if err := ValidateKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(b.root, filepath.FromSlash(key)), nil
+}
+
+// objectInfo dosya bilgisinden nesne bilgisi oluşturur
+func (b *LocalBackend) objectInfo(key string, stat fs.FileInfo) *ObjectInfo {
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	return &ObjectInfo{
+		Key:          key,
+		Size:         stat.Size(),
+		ContentType:  contentType,
+		ETag:         fmt.Sprintf("%x-%x", stat.ModTime().UnixNano(), stat.Size()),
+		LastModified: stat.ModTime(),
+	}
+}
+
+// mapLocalError dosya sistemi hatalarını depolama hatalarına çevirir
+func mapLocalError(err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// limitedReadCloser aralıklı okumalarda alttaki dosyayı kapatabilmek için
+// sınırlı okuyucuyu kapatıcıyla birleştirir
+type limitedReadCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"io"
 	"log"
-	"path/filepath"
 	"time"
 
 	"github.com/minio/minio-go/v7"
@@ -21,15 +20,18 @@ type MinioConfig struct {
 	Location        string
 }
 
-// MinioService MinIO işlemleri için servis
-type MinioService struct {
+// MinioBackend dosyaları MinIO veya S3 uyumlu bir nesne deposunda saklayan depolama
+type MinioBackend struct {
 	Client     *minio.Client
 	BucketName string
 	Location   string
+	publicURL  string
 }
 
-// NewMinioService yeni bir MinIO servisi oluşturur
-func NewMinioService(config *MinioConfig) (*MinioService, error) {
+// NewMinioBackend yeni bir MinioBackend oluşturur
+//
+// publicURL boşsa URL süreli imzalı adresler üretir.
+func NewMinioBackend(config *MinioConfig, publicURL string) (*MinioBackend, error) {
 	// MinIO client oluştur
 	client, err := minio.New(config.Endpoint, &minio.Options{
 		Creds:  credentials.NewStaticV4(config.AccessKeyID, config.SecretAccessKey, ""),
@@ -56,70 +58,90 @@ func NewMinioService(config *MinioConfig) (*MinioService, error) {
 		log.Printf("Bucket oluşturuldu: %s", config.BucketName)
 	}
 
-	return &MinioService{
+	return &MinioBackend{
 		Client:     client,
 		BucketName: config.BucketName,
 		Location:   config.Location,
+		publicURL:  publicURL,
 	}, nil
 }
 
-// UploadFile dosyayı MinIO'ya yükler
-func (s *MinioService) UploadFile(fileReader io.Reader, fileName string, fileSize int64, contentType string) (string, error) {
-	ctx := context.Background()
-
-	// Dosya uzantısını kontrol et
-	ext := filepath.Ext(fileName)
-	if ext == "" {
-		// Varsayılan olarak .bin uzantısı ekle
-		fileName = fileName + ".bin"
+// Put nesneyi MinIO'ya yükler
+func (b *MinioBackend) Put(key string, r io.Reader, size int64, contentType string) (*ObjectInfo, error) {
+	if err := ValidateKey(key); err != nil {
+		return nil, err
 	}
 
-	// Dosya adı prefix'i oluştur (YYYY-MM-DD/)
-	prefix := time.Now().Format("2006-01-02") + "/"
-	objectName := prefix + fileName
-
-	// Dosyayı yükle
-	_, err := s.Client.PutObject(ctx, s.BucketName, objectName, fileReader, fileSize, minio.PutObjectOptions{
+	info, err := b.Client.PutObject(context.Background(), b.BucketName, key, r, size, minio.PutObjectOptions{
 		ContentType: contentType,
 	})
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return objectName, nil
+	return &ObjectInfo{
+		Key:          key,
+		Size:         info.Size,
+		ContentType:  contentType,
+		ETag:         info.ETag,
+		LastModified: info.LastModified,
+	}, nil
 }
 
-// GetFileURL MinIO'da depolanan dosyanın URL'sini döner
-func (s *MinioService) GetFileURL(objectName string, expires time.Duration) (string, error) {
-	ctx := context.Background()
+// Get nesneyi MinIO'dan okur
+func (b *MinioBackend) Get(key string, rng *Range) (io.ReadCloser, *ObjectInfo, error) {
+	// Aralık doğrulaması için toplam boyut gerekir
+	info, err := b.Stat(key)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	// Geçici URL oluştur
-	presignedURL, err := s.Client.PresignedGetObject(ctx, s.BucketName, objectName, expires, nil)
+	opts := minio.GetObjectOptions{}
+	if rng != nil {
+		length, err := validateRange(rng, info.Size)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := opts.SetRange(rng.Offset, rng.Offset+length-1); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	object, err := b.Client.GetObject(context.Background(), b.BucketName, key, opts)
 	if err != nil {
-		return "", err
+		return nil, nil, mapMinioError(err)
 	}
 
-	return presignedURL.String(), nil
+	return object, info, nil
 }
 
-// DeleteFile MinIO'dan dosyayı siler
-func (s *MinioService) DeleteFile(objectName string) error {
-	ctx := context.Background()
+// Stat nesnenin bilgilerini döner
+func (b *MinioBackend) Stat(key string) (*ObjectInfo, error) {
+	if err := ValidateKey(key); err != nil {
+		return nil, err
+	}
 
-	err := s.Client.RemoveObject(ctx, s.BucketName, objectName, minio.RemoveObjectOptions{})
+	info, err := b.Client.StatObject(context.Background(), b.BucketName, key, minio.StatObjectOptions{})
 	if err != nil {
-		return err
+		return nil, mapMinioError(err)
 	}
 
-	return nil
+	return minioObjectInfo(info), nil
 }
 
-// ListFiles MinIO'daki dosyaları listeler
-func (s *MinioService) ListFiles(prefix string) ([]minio.ObjectInfo, error) {
-	ctx := context.Background()
+// Delete nesneyi MinIO'dan siler
+func (b *MinioBackend) Delete(key string) error {
+	if err := ValidateKey(key); err != nil {
+		return err
+	}
 
-	objects := make([]minio.ObjectInfo, 0)
-	objectCh := s.Client.ListObjects(ctx, s.BucketName, minio.ListObjectsOptions{
+	return b.Client.RemoveObject(context.Background(), b.BucketName, key, minio.RemoveObjectOptions{})
+}
+
+// List MinIO'daki nesneleri listeler
+func (b *MinioBackend) List(prefix string) ([]ObjectInfo, error) {
+	objects := make([]ObjectInfo, 0)
+	objectCh := b.Client.ListObjects(context.Background(), b.BucketName, minio.ListObjectsOptions{
 		Prefix:    prefix,
 		Recursive: true,
 	})
@@ -128,8 +150,59 @@ func (s *MinioService) ListFiles(prefix string) ([]minio.ObjectInfo, error) {
 		if object.Err != nil {
 			return nil, object.Err
 		}
-		objects = append(objects, object)
+		objects = append(objects, *minioObjectInfo(object))
 	}
 
 	return objects, nil
 }
+
+// URL nesnenin herkese açık veya süreli imzalı adresini döner
+func (b *MinioBackend) URL(key string, expires time.Duration) (string, error) {
+	if err := ValidateKey(key); err != nil {
+		return "", err
+	}
+	if b.publicURL != "" {
+		return publicObjectURL(b.publicURL, key), nil
+	}
+
+	presignedURL, err := b.Client.PresignedGetObject(context.Background(), b.BucketName, key, expires, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return presignedURL.String(), nil
+}
+
+// PresignPut nesnenin doğrudan MinIO'ya yüklenebileceği süreli imzalı adresi döner
+func (b *MinioBackend) PresignPut(key string, expires time.Duration) (string, error) {
+	if err := ValidateKey(key); err != nil {
+		return "", err
+	}
+
+	presignedURL, err := b.Client.PresignedPutObject(context.Background(), b.BucketName, key, expires)
+	if err != nil {
+		return "", err
+	}
+
+	return presignedURL.String(), nil
+}
+
+// minioObjectInfo MinIO nesne bilgisini depolama nesne bilgisine çevirir
+func minioObjectInfo(info minio.ObjectInfo) *ObjectInfo {
+	return &ObjectInfo{
+		Key:          info.Key,
+		Size:         info.Size,
+		ContentType:  info.ContentType,
+		ETag:         info.ETag,
+		LastModified: info.LastModified,
+	}
+}
+
+// mapMinioError MinIO hatalarını depolama hatalarına çevirir
+func mapMinioError(err error) error {
+	switch minio.ToErrorResponse(err).Code {
+	case "NoSuchKey", "NotFound":
+		return ErrNotFound
+	}
+	return err
+}